@@ -10,12 +10,20 @@ import (
 )
 
 func NewPermissionAssignmentAPI(ctx context.Context, m any) PermissionAssignmentAPI {
-	return PermissionAssignmentAPI{m.(*common.DatabricksClient), ctx}
+	return PermissionAssignmentAPI{m.(*common.DatabricksClient), ctx, "/preview/permissionassignments"}
+}
+
+// NewAccountPermissionAssignmentAPI manages permission assignments of the given workspace from the account context
+func NewAccountPermissionAssignmentAPI(ctx context.Context, m any, workspaceID int64) PermissionAssignmentAPI {
+	client := m.(*common.DatabricksClient)
+	return PermissionAssignmentAPI{client, ctx, fmt.Sprintf("/accounts/%s/workspaces/%d/permissionassignments",
+		client.Config.AccountID, workspaceID)}
 }
 
 type PermissionAssignmentAPI struct {
 	client  *common.DatabricksClient
 	context context.Context
+	path    string
 }
 
 type Permissions struct {
@@ -23,12 +31,12 @@ type Permissions struct {
 }
 
 func (a PermissionAssignmentAPI) CreateOrUpdate(principalId int64, r Permissions) error {
-	path := fmt.Sprintf("/preview/permissionassignments/principals/%d", principalId)
+	path := fmt.Sprintf("%s/principals/%d", a.path, principalId)
 	return a.client.Put(a.context, path, r)
 }
 
 func (a PermissionAssignmentAPI) Remove(principalId string) error {
-	path := fmt.Sprintf("/preview/permissionassignments/principals/%s", principalId)
+	path := fmt.Sprintf("%s/principals/%s", a.path, principalId)
 	return a.client.Delete(a.context, path, nil)
 }
 
@@ -60,7 +68,7 @@ func (l PermissionAssignmentList) ForPrincipal(principalId int64) (res Permissio
 }
 
 func (a PermissionAssignmentAPI) List() (list PermissionAssignmentList, err error) {
-	err = a.client.Get(a.context, "/preview/permissionassignments", nil, &list)
+	err = a.client.Get(a.context, a.path, nil, &list)
 	return
 }
 
@@ -0,0 +1,287 @@
+package access
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/databricks/terraform-provider-databricks/common"
+	"github.com/databricks/terraform-provider-databricks/scim"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const permissionAssignmentRulePageSize = 100
+
+// ruleAssignment is an assignment of a group to a workspace, that was made by the rule
+type ruleAssignment struct {
+	WorkspaceID int64 `json:"workspace_id"`
+	PrincipalID int64 `json:"principal_id"`
+}
+
+// PermissionAssignmentRule assigns every account group, that matches the rule, to the workspaces
+type PermissionAssignmentRule struct {
+	WorkspaceIDs       []int64  `json:"workspace_ids" tf:"slice_set"`
+	DisplayNamePattern string   `json:"display_name_pattern,omitempty" tf:"force_new"`
+	ExternalIDPrefix   string   `json:"external_id_prefix,omitempty" tf:"force_new"`
+	Permissions        []string `json:"permissions" tf:"slice_set"`
+	PrincipalIDs       []int64  `json:"principal_ids,omitempty" tf:"slice_set,computed"`
+	// Assignments made by the rule, so that assignments, that existed before, are never removed
+	Assignments []ruleAssignment `json:"assignments,omitempty" tf:"computed"`
+	// Read sets it to false, whenever assignments drift from the rule, so that the next apply reconciles them
+	InSync bool `json:"in_sync,omitempty" tf:"default:true"`
+}
+
+func (r PermissionAssignmentRule) id() string {
+	return fmt.Sprintf("%s|%s", r.DisplayNamePattern, r.ExternalIDPrefix)
+}
+
+func (r PermissionAssignmentRule) filter() string {
+	conditions := []string{}
+	// SCIM filters don't support wildcards, so we narrow down the list by the literal prefix of the pattern
+	literal := r.DisplayNamePattern
+	if i := strings.IndexAny(literal, `*?[\`); i >= 0 {
+		literal = literal[:i]
+	}
+	if literal != "" {
+		conditions = append(conditions, fmt.Sprintf(`displayName sw "%s"`, literal))
+	}
+	if r.ExternalIDPrefix != "" {
+		conditions = append(conditions, fmt.Sprintf(`externalId sw "%s"`, r.ExternalIDPrefix))
+	}
+	return strings.Join(conditions, " and ")
+}
+
+func (r PermissionAssignmentRule) matches(group scim.Group) (bool, error) {
+	if !strings.HasPrefix(group.ExternalID, r.ExternalIDPrefix) {
+		return false, nil
+	}
+	if r.DisplayNamePattern == "" {
+		return true, nil
+	}
+	return path.Match(r.DisplayNamePattern, group.DisplayName)
+}
+
+// matchingPrincipals returns sorted IDs of account groups, that match the rule
+func (r PermissionAssignmentRule) matchingPrincipals(ctx context.Context, c *common.DatabricksClient) ([]int64, error) {
+	principals := []int64{}
+	for startIndex := 1; ; startIndex += permissionAssignmentRulePageSize {
+		req := map[string]string{
+			"attributes": "id,displayName,externalId",
+			"startIndex": strconv.Itoa(startIndex),
+			"count":      strconv.Itoa(permissionAssignmentRulePageSize),
+		}
+		if filter := r.filter(); filter != "" {
+			req["filter"] = filter
+		}
+		var page scim.GroupList
+		err := c.Scim(ctx, http.MethodGet, "/preview/scim/v2/Groups", req, &page)
+		if err != nil {
+			return nil, err
+		}
+		for _, group := range page.Resources {
+			ok, err := r.matches(group)
+			if err != nil {
+				return nil, fmt.Errorf("invalid display_name_pattern: %w", err)
+			}
+			if !ok {
+				continue
+			}
+			principalID, err := strconv.ParseInt(group.ID, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("group %s: %w", group.DisplayName, err)
+			}
+			principals = append(principals, principalID)
+		}
+		if len(page.Resources) < permissionAssignmentRulePageSize {
+			break
+		}
+	}
+	slices.Sort(principals)
+	return principals, nil
+}
+
+func samePermissions(a, b []string) bool {
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(a, b)
+}
+
+type assignmentChange struct {
+	workspaceID int64
+	principalID int64
+	remove      bool
+}
+
+// plan compares the current assignments with the rule and returns the changes together with assignments, that are
+// made by the rule. Groups, that were assigned to a workspace before the rule, are left untouched. Assignments
+// of the rule are removed, if groups no longer match it, and from workspaces, that were removed from it.
+func (r PermissionAssignmentRule) plan(ctx context.Context, c *common.DatabricksClient,
+	matching []int64, previous PermissionAssignmentRule) ([]assignmentChange, []ruleAssignment, error) {
+	changes := []assignmentChange{}
+	assigned := []ruleAssignment{}
+	workspaces := slices.Clone(r.WorkspaceIDs)
+	for _, workspaceID := range previous.WorkspaceIDs {
+		if !slices.Contains(workspaces, workspaceID) {
+			workspaces = append(workspaces, workspaceID)
+		}
+	}
+	for _, workspaceID := range workspaces {
+		list, err := NewAccountPermissionAssignmentAPI(ctx, c, workspaceID).List()
+		if err != nil {
+			return nil, nil, fmt.Errorf("workspace %d: %w", workspaceID, err)
+		}
+		desired := []int64{}
+		if slices.Contains(r.WorkspaceIDs, workspaceID) {
+			desired = matching
+		}
+		for _, principalID := range desired {
+			assignment := ruleAssignment{workspaceID, principalID}
+			current, err := list.ForPrincipal(principalID)
+			if err == nil && !slices.Contains(previous.Assignments, assignment) {
+				log.Printf("[DEBUG] Group %d was assigned to workspace %d before the rule, skipping it",
+					principalID, workspaceID)
+				continue
+			}
+			assigned = append(assigned, assignment)
+			if err == nil && samePermissions(current.Permissions, r.Permissions) {
+				continue
+			}
+			changes = append(changes, assignmentChange{workspaceID, principalID, false})
+		}
+		for _, assignment := range previous.Assignments {
+			if assignment.WorkspaceID != workspaceID || slices.Contains(desired, assignment.PrincipalID) {
+				continue
+			}
+			if _, err := list.ForPrincipal(assignment.PrincipalID); err != nil {
+				continue
+			}
+			changes = append(changes, assignmentChange{workspaceID, assignment.PrincipalID, true})
+		}
+	}
+	return changes, assigned, nil
+}
+
+func (r PermissionAssignmentRule) apply(ctx context.Context, c *common.DatabricksClient, changes []assignmentChange) error {
+	for _, change := range changes {
+		api := NewAccountPermissionAssignmentAPI(ctx, c, change.workspaceID)
+		if change.remove {
+			log.Printf("[INFO] Unassigning group %d from workspace %d", change.principalID, change.workspaceID)
+			err := api.Remove(fmt.Sprintf("%d", change.principalID))
+			if err != nil {
+				return fmt.Errorf("workspace %d: %w", change.workspaceID, err)
+			}
+			continue
+		}
+		log.Printf("[INFO] Assigning group %d to workspace %d with %v", change.principalID, change.workspaceID, r.Permissions)
+		err := api.CreateOrUpdate(change.principalID, Permissions{r.Permissions})
+		if err != nil {
+			return fmt.Errorf("workspace %d: %w", change.workspaceID, err)
+		}
+	}
+	return nil
+}
+
+// reconcile makes assignments match the rule and returns the matching principals and assignments made by the rule
+func (r PermissionAssignmentRule) reconcile(ctx context.Context, c *common.DatabricksClient,
+	previous PermissionAssignmentRule) ([]int64, []ruleAssignment, error) {
+	matching, err := r.matchingPrincipals(ctx, c)
+	if err != nil {
+		return nil, nil, err
+	}
+	changes, assigned, err := r.plan(ctx, c, matching, previous)
+	if err != nil {
+		return nil, nil, err
+	}
+	return matching, assigned, r.apply(ctx, c, changes)
+}
+
+// permissionAssignmentRuleFromState returns workspaces and assignments, that were made by the last apply
+func permissionAssignmentRuleFromState(d *schema.ResourceData) (previous PermissionAssignmentRule) {
+	for _, v := range d.Get("assignments").([]any) {
+		assignment := v.(map[string]any)
+		previous.Assignments = append(previous.Assignments, ruleAssignment{
+			WorkspaceID: int64(assignment["workspace_id"].(int)),
+			PrincipalID: int64(assignment["principal_id"].(int)),
+		})
+	}
+	old, _ := d.GetChange("workspace_ids")
+	for _, v := range old.(*schema.Set).List() {
+		previous.WorkspaceIDs = append(previous.WorkspaceIDs, int64(v.(int)))
+	}
+	return
+}
+
+// ResourceMwsPermissionAssignmentRule assigns account groups to workspaces by their display names or external IDs,
+// so that groups created by the identity provider later on are assigned on the next apply.
+func ResourceMwsPermissionAssignmentRule() common.Resource {
+	s := common.StructToSchema(PermissionAssignmentRule{},
+		func(m map[string]*schema.Schema) map[string]*schema.Schema {
+			for _, field := range []string{"display_name_pattern", "external_id_prefix"} {
+				common.CustomizeSchemaPath(m, field).SetAtLeastOneOf([]string{"display_name_pattern", "external_id_prefix"})
+			}
+			return m
+		})
+	reconcile := func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+		if c.Config.AccountID == "" {
+			return fmt.Errorf("account_id is required in the provider configuration")
+		}
+		var rule PermissionAssignmentRule
+		common.DataToStructPointer(d, s, &rule)
+		principals, assigned, err := rule.reconcile(ctx, c, permissionAssignmentRuleFromState(d))
+		if err != nil {
+			return err
+		}
+		d.Set("principal_ids", principals)
+		// StructToData skips empty lists, so that stale assignments would stay in the state
+		state := []any{}
+		for _, a := range assigned {
+			state = append(state, map[string]any{
+				"workspace_id": int(a.WorkspaceID),
+				"principal_id": int(a.PrincipalID),
+			})
+		}
+		d.Set("assignments", state)
+		d.Set("in_sync", true)
+		d.SetId(rule.id())
+		return nil
+	}
+	return common.Resource{
+		Schema: s,
+		Create: reconcile,
+		Read: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			var rule PermissionAssignmentRule
+			common.DataToStructPointer(d, s, &rule)
+			matching, err := rule.matchingPrincipals(ctx, c)
+			if err != nil {
+				return err
+			}
+			previous := permissionAssignmentRuleFromState(d)
+			// workspaces, that were removed from the rule, are only cleaned up on update
+			previous.WorkspaceIDs = rule.WorkspaceIDs
+			changes, _, err := rule.plan(ctx, c, matching, previous)
+			if err != nil {
+				return err
+			}
+			if len(changes) > 0 {
+				log.Printf("[INFO] %d assignments drifted from rule %s", len(changes), d.Id())
+			}
+			return d.Set("in_sync", len(changes) == 0)
+		},
+		Update: reconcile,
+		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			var rule PermissionAssignmentRule
+			common.DataToStructPointer(d, s, &rule)
+			changes, _, err := rule.plan(ctx, c, []int64{}, permissionAssignmentRuleFromState(d))
+			if err != nil {
+				return err
+			}
+			return rule.apply(ctx, c, changes)
+		},
+	}
+}
@@ -0,0 +1,332 @@
+package access
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/databricks/terraform-provider-databricks/qa"
+	"github.com/databricks/terraform-provider-databricks/scim"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPermissionAssignmentRuleFilter(t *testing.T) {
+	assert.Equal(t, `displayName sw "ws-"`, PermissionAssignmentRule{
+		DisplayNamePattern: "ws-*-users",
+	}.filter())
+	assert.Equal(t, `displayName sw "ws-a" and externalId sw "okta-"`, PermissionAssignmentRule{
+		DisplayNamePattern: "ws-a",
+		ExternalIDPrefix:   "okta-",
+	}.filter())
+	assert.Equal(t, ``, PermissionAssignmentRule{
+		DisplayNamePattern: "*-users",
+	}.filter())
+}
+
+const wsUsersGroupsFilter = "/api/2.0/accounts/abc/scim/v2/Groups?attributes=id%2CdisplayName%2CexternalId" +
+	"&count=100&filter=displayName%20sw%20%22ws-%22&startIndex=1"
+
+var wsUsersGroups = scim.GroupList{
+	Resources: []scim.Group{
+		{ID: "1001", DisplayName: "ws-a-users"},
+		{ID: "1002", DisplayName: "ws-a-admins"},
+		{ID: "1003", DisplayName: "ws-b-users"},
+	},
+}
+
+func assignments(principalIDs ...int64) PermissionAssignmentList {
+	list := PermissionAssignmentList{}
+	for _, principalID := range principalIDs {
+		list.PermissionAssignments = append(list.PermissionAssignments, PermissionAssignment{
+			Permissions: []string{"USER"},
+			Principal:   Principal{PrincipalID: principalID},
+		})
+	}
+	return list
+}
+
+func TestPermissionAssignmentRuleCreate(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: wsUsersGroupsFilter,
+				Response: wsUsersGroups,
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/workspaces/123/permissionassignments",
+				Response: assignments(1001),
+			},
+			{
+				Method:   "PUT",
+				Resource: "/api/2.0/accounts/abc/workspaces/123/permissionassignments/principals/1003",
+				ExpectedRequest: Permissions{
+					Permissions: []string{"USER"},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: wsUsersGroupsFilter,
+				Response: wsUsersGroups,
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/workspaces/123/permissionassignments",
+				Response: assignments(1001, 1003),
+			},
+		},
+		Resource:  ResourceMwsPermissionAssignmentRule(),
+		Create:    true,
+		AccountID: "abc",
+		HCL: `
+		workspace_ids        = [123]
+		display_name_pattern = "ws-*-users"
+		permissions          = ["USER"]
+		`,
+	}.ApplyAndExpectData(t, map[string]any{
+		"id":              "ws-*-users|",
+		"in_sync":         true,
+		"principal_ids.#": 2,
+		// 1001 was assigned before the rule
+		"assignments.#":              1,
+		"assignments.0.workspace_id": 123,
+		"assignments.0.principal_id": 1003,
+	})
+}
+
+func TestPermissionAssignmentRuleCreate_NoAccountID(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceMwsPermissionAssignmentRule(),
+		Create:   true,
+		HCL: `
+		workspace_ids      = [123]
+		external_id_prefix = "okta-"
+		permissions        = ["USER"]
+		`,
+	}.ExpectError(t, "account_id is required in the provider configuration")
+}
+
+func permissionAssignmentRuleState(workspaceIDs []int, assigned ...ruleAssignment) map[string]string {
+	state := map[string]string{
+		"display_name_pattern": "ws-*-users",
+		"permissions.#":        "1",
+		"permissions.0":        "USER",
+		"in_sync":              "true",
+		"workspace_ids.#":      fmt.Sprintf("%d", len(workspaceIDs)),
+		"assignments.#":        fmt.Sprintf("%d", len(assigned)),
+	}
+	hash := schema.HashSchema(&schema.Schema{Type: schema.TypeInt})
+	for _, id := range workspaceIDs {
+		state[fmt.Sprintf("workspace_ids.%d", hash(id))] = fmt.Sprintf("%d", id)
+	}
+	for i, a := range assigned {
+		state[fmt.Sprintf("assignments.%d.workspace_id", i)] = fmt.Sprintf("%d", a.WorkspaceID)
+		state[fmt.Sprintf("assignments.%d.principal_id", i)] = fmt.Sprintf("%d", a.PrincipalID)
+	}
+	return state
+}
+
+func TestPermissionAssignmentRuleRead_Drift(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: wsUsersGroupsFilter,
+				Response: wsUsersGroups,
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/workspaces/123/permissionassignments",
+				Response: assignments(1001),
+			},
+		},
+		Resource:      ResourceMwsPermissionAssignmentRule(),
+		Read:          true,
+		AccountID:     "abc",
+		ID:            "ws-*-users|",
+		InstanceState: permissionAssignmentRuleState([]int{123}, ruleAssignment{123, 1001}, ruleAssignment{123, 1003}),
+		HCL: `
+		workspace_ids        = [123]
+		display_name_pattern = "ws-*-users"
+		permissions          = ["USER"]
+		`,
+	}.ApplyAndExpectData(t, map[string]any{
+		"in_sync": false,
+	})
+}
+
+func TestPermissionAssignmentRuleUpdate(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: wsUsersGroupsFilter,
+				Response: scim.GroupList{
+					Resources: []scim.Group{
+						{ID: "1001", DisplayName: "ws-a-users"},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/workspaces/123/permissionassignments",
+				Response: assignments(1001, 1003),
+			},
+			{
+				Method:   "DELETE",
+				Resource: "/api/2.0/accounts/abc/workspaces/123/permissionassignments/principals/1003",
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/workspaces/456/permissionassignments",
+				Response: assignments(1001),
+			},
+			{
+				Method:   "DELETE",
+				Resource: "/api/2.0/accounts/abc/workspaces/456/permissionassignments/principals/1001",
+			},
+			{
+				Method:   "GET",
+				Resource: wsUsersGroupsFilter,
+				Response: scim.GroupList{
+					Resources: []scim.Group{
+						{ID: "1001", DisplayName: "ws-a-users"},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/workspaces/123/permissionassignments",
+				Response: assignments(1001),
+			},
+		},
+		Resource:  ResourceMwsPermissionAssignmentRule(),
+		Update:    true,
+		AccountID: "abc",
+		ID:        "ws-*-users|",
+		InstanceState: permissionAssignmentRuleState([]int{123, 456},
+			ruleAssignment{123, 1001}, ruleAssignment{123, 1003}, ruleAssignment{456, 1001}),
+		HCL: `
+		workspace_ids        = [123]
+		display_name_pattern = "ws-*-users"
+		permissions          = ["USER"]
+		`,
+	}.ApplyAndExpectData(t, map[string]any{
+		"in_sync":         true,
+		"principal_ids.#": 1,
+		"assignments.#":   1,
+	})
+}
+
+func TestPermissionAssignmentRuleDelete(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/workspaces/123/permissionassignments",
+				Response: assignments(1001, 1002),
+			},
+			{
+				Method:   "DELETE",
+				Resource: "/api/2.0/accounts/abc/workspaces/123/permissionassignments/principals/1001",
+			},
+		},
+		Resource:      ResourceMwsPermissionAssignmentRule(),
+		Delete:        true,
+		AccountID:     "abc",
+		ID:            "ws-*-users|",
+		InstanceState: permissionAssignmentRuleState([]int{123}, ruleAssignment{123, 1001}, ruleAssignment{123, 1003}),
+	}.ApplyNoError(t)
+}
+
+func TestPermissionAssignmentRuleRead_Pages(t *testing.T) {
+	firstPage := scim.GroupList{}
+	for i := 0; i < 100; i++ {
+		firstPage.Resources = append(firstPage.Resources, scim.Group{
+			ID:          fmt.Sprintf("%d", 2000+i),
+			DisplayName: fmt.Sprintf("ws-%d-admins", i),
+		})
+	}
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: wsUsersGroupsFilter,
+				Response: firstPage,
+			},
+			{
+				Method: "GET",
+				Resource: "/api/2.0/accounts/abc/scim/v2/Groups?attributes=id%2CdisplayName%2CexternalId" +
+					"&count=100&filter=displayName%20sw%20%22ws-%22&startIndex=101",
+				Response: scim.GroupList{
+					Resources: []scim.Group{
+						{ID: "1001", DisplayName: "ws-a-users"},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/workspaces/123/permissionassignments",
+				Response: assignments(1001),
+			},
+		},
+		Resource:      ResourceMwsPermissionAssignmentRule(),
+		Read:          true,
+		AccountID:     "abc",
+		ID:            "ws-*-users|",
+		InstanceState: permissionAssignmentRuleState([]int{123}, ruleAssignment{123, 1001}),
+		HCL: `
+		workspace_ids        = [123]
+		display_name_pattern = "ws-*-users"
+		permissions          = ["USER"]
+		`,
+	}.ApplyAndExpectData(t, map[string]any{
+		"in_sync": true,
+	})
+}
+
+func TestPermissionAssignmentRuleUpdate_KeepsAssignmentsMadeBeforeTheRule(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: wsUsersGroupsFilter,
+				Response: scim.GroupList{},
+			},
+			{
+				// 1001 was assigned by the rule and 1003 before it
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/workspaces/123/permissionassignments",
+				Response: assignments(1001, 1003),
+			},
+			{
+				Method:   "DELETE",
+				Resource: "/api/2.0/accounts/abc/workspaces/123/permissionassignments/principals/1001",
+			},
+			{
+				Method:   "GET",
+				Resource: wsUsersGroupsFilter,
+				Response: scim.GroupList{},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/workspaces/123/permissionassignments",
+				Response: assignments(1003),
+			},
+		},
+		Resource:      ResourceMwsPermissionAssignmentRule(),
+		Update:        true,
+		AccountID:     "abc",
+		ID:            "ws-*-users|",
+		InstanceState: permissionAssignmentRuleState([]int{123}, ruleAssignment{123, 1001}),
+		HCL: `
+		workspace_ids        = [123]
+		display_name_pattern = "ws-*-users"
+		permissions          = ["USER"]
+		`,
+	}.ApplyAndExpectData(t, map[string]any{
+		"in_sync":       true,
+		"assignments.#": 0,
+	})
+}
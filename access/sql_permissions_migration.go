@@ -0,0 +1,152 @@
+package access
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// hiveMetastoreCatalog is the key of catalog mapping, that is used for databases without explicit mapping
+const hiveMetastoreCatalog = "hive_metastore"
+
+// legacyToUnityCatalogPrivileges translates table ACL privileges to Unity Catalog privileges
+// on the equivalent securable.
+var legacyToUnityCatalogPrivileges = map[string]map[string]string{
+	"CATALOG": {
+		"ALL_PRIVILEGES":        "ALL_PRIVILEGES",
+		"CREATE":                "CREATE_SCHEMA",
+		"CREATE_NAMED_FUNCTION": "CREATE_FUNCTION",
+		"MODIFY":                "MODIFY",
+		"READ_METADATA":         "BROWSE",
+		"SELECT":                "SELECT",
+		"USAGE":                 "USE_CATALOG",
+	},
+	"DATABASE": {
+		"ALL_PRIVILEGES":        "ALL_PRIVILEGES",
+		"CREATE":                "CREATE_TABLE",
+		"CREATE_NAMED_FUNCTION": "CREATE_FUNCTION",
+		"MODIFY":                "MODIFY",
+		"SELECT":                "SELECT",
+		"USAGE":                 "USE_SCHEMA",
+	},
+	"TABLE": {
+		"ALL_PRIVILEGES": "ALL_PRIVILEGES",
+		"MODIFY":         "MODIFY",
+		"SELECT":         "SELECT",
+	},
+	"VIEW": {
+		"ALL_PRIVILEGES": "ALL_PRIVILEGES",
+		"SELECT":         "SELECT",
+	},
+}
+
+// legacyUsersGroup is the workspace-local group of all users, that can't be granted privileges in Unity Catalog
+const legacyUsersGroup = "users"
+
+// accountUsersGroup is the account group of all users, that replaces the workspace-local `users` group
+const accountUsersGroup = "account users"
+
+var legacyToUnityCatalogSecurables = map[string]string{
+	"CATALOG":  "catalog",
+	"DATABASE": "schema",
+	"TABLE":    "table",
+	"VIEW":     "table",
+}
+
+// UnityCatalogGrants are the privileges of table ACL translated to a Unity Catalog securable
+type UnityCatalogGrants struct {
+	// SecurableType is the argument of databricks_grants, like `catalog`, `schema` or `table`
+	SecurableType        string
+	Name                 string
+	PrivilegeAssignments []PrivilegeAssignment
+	// Untranslatable explains privileges, that have no equivalent in Unity Catalog or need a review
+	Untranslatable []string
+}
+
+func (ta *SqlPermissions) unityCatalogName(catalogMapping map[string]string) (string, error) {
+	database := ta.actualDatabase()
+	// privileges on the catalog itself only follow the mapping of `hive_metastore`,
+	// even if the `default` database is mapped to another catalog
+	catalog, ok := "", false
+	if !ta.Catalog {
+		catalog, ok = catalogMapping[database]
+	}
+	if !ok {
+		catalog, ok = catalogMapping[hiveMetastoreCatalog]
+	}
+	if !ok {
+		return "", fmt.Errorf("no Unity Catalog catalog is mapped for %s", ta.ID())
+	}
+	switch {
+	case ta.Table != "":
+		return fmt.Sprintf("%s.%s.%s", catalog, database, ta.Table), nil
+	case ta.View != "":
+		return fmt.Sprintf("%s.%s.%s", catalog, database, ta.View), nil
+	case ta.Database != "":
+		return fmt.Sprintf("%s.%s", catalog, database), nil
+	}
+	return catalog, nil
+}
+
+// ToUnityCatalog translates table ACL to equivalent Unity Catalog grants. Catalog mapping has names
+// of Hive databases, or `hive_metastore` for all other databases and the catalog itself, as keys
+// and names of Unity Catalog catalogs as values.
+func (ta *SqlPermissions) ToUnityCatalog(catalogMapping map[string]string) (UnityCatalogGrants, error) {
+	objectType, _ := ta.typeAndKey()
+	securableType, ok := legacyToUnityCatalogSecurables[objectType]
+	if !ok {
+		return UnityCatalogGrants{
+			Untranslatable: []string{fmt.Sprintf("%s has no equivalent securable in Unity Catalog", ta.ID())},
+		}, nil
+	}
+	name, err := ta.unityCatalogName(catalogMapping)
+	if err != nil {
+		return UnityCatalogGrants{}, err
+	}
+	grants := UnityCatalogGrants{
+		SecurableType: securableType,
+		Name:          name,
+	}
+	translation := legacyToUnityCatalogPrivileges[objectType]
+	for _, pa := range ta.PrivilegeAssignments {
+		privileges := []string{}
+		for _, p := range pa.Privileges {
+			legacy := strings.ReplaceAll(strings.ToUpper(p), " ", "_")
+			privilege, ok := translation[legacy]
+			if !ok && legacy == "READ_METADATA" {
+				grants.Untranslatable = append(grants.Untranslatable, fmt.Sprintf(
+					"READ_METADATA of %s on %s has no equivalent in Unity Catalog, as BROWSE can only be "+
+						"granted on the whole catalog", pa.Principal, ta.ID()))
+				continue
+			}
+			if !ok {
+				grants.Untranslatable = append(grants.Untranslatable, fmt.Sprintf(
+					"%s of %s on %s has no equivalent in Unity Catalog", legacy, pa.Principal, ta.ID()))
+				continue
+			}
+			if !slices.Contains(privileges, privilege) {
+				privileges = append(privileges, privilege)
+			}
+		}
+		if len(privileges) == 0 {
+			continue
+		}
+		principal := pa.Principal
+		if principal == legacyUsersGroup {
+			principal = accountUsersGroup
+			grants.Untranslatable = append(grants.Untranslatable, fmt.Sprintf(
+				"privileges of `%s` on %s are granted to `%s`, that has all users of the account and not "+
+					"only the ones of the workspace", legacyUsersGroup, ta.ID(), accountUsersGroup))
+		}
+		sort.Strings(privileges)
+		grants.PrivilegeAssignments = append(grants.PrivilegeAssignments, PrivilegeAssignment{
+			Principal:  principal,
+			Privileges: privileges,
+		})
+	}
+	sort.Slice(grants.PrivilegeAssignments, func(i, j int) bool {
+		return grants.PrivilegeAssignments[i].Principal < grants.PrivilegeAssignments[j].Principal
+	})
+	return grants, nil
+}
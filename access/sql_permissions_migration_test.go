@@ -0,0 +1,125 @@
+package access
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSqlPermissionsToUnityCatalog(t *testing.T) {
+	mapping := map[string]string{
+		"hive_metastore": "main",
+		"finance":        "finance_catalog",
+	}
+	ta := SqlPermissions{
+		Database: "finance",
+		PrivilegeAssignments: []PrivilegeAssignment{
+			{
+				Principal:  "users",
+				Privileges: []string{"USAGE", "READ_METADATA", "SELECT"},
+			},
+			{
+				Principal:  "admins",
+				Privileges: []string{"ALL PRIVILEGES"},
+			},
+		},
+	}
+	grants, err := ta.ToUnityCatalog(mapping)
+	require.NoError(t, err)
+	assert.Equal(t, UnityCatalogGrants{
+		SecurableType: "schema",
+		Name:          "finance_catalog.finance",
+		PrivilegeAssignments: []PrivilegeAssignment{
+			{
+				Principal:  "account users",
+				Privileges: []string{"SELECT", "USE_SCHEMA"},
+			},
+			{
+				Principal:  "admins",
+				Privileges: []string{"ALL_PRIVILEGES"},
+			},
+		},
+		Untranslatable: []string{
+			"READ_METADATA of users on database/finance has no equivalent in Unity Catalog, " +
+				"as BROWSE can only be granted on the whole catalog",
+			"privileges of `users` on database/finance are granted to `account users`, " +
+				"that has all users of the account and not only the ones of the workspace",
+		},
+	}, grants)
+
+	ta = SqlPermissions{
+		View: "recent_orders",
+		PrivilegeAssignments: []PrivilegeAssignment{
+			{
+				Principal:  "users",
+				Privileges: []string{"SELECT", "MODIFY"},
+			},
+		},
+	}
+	grants, err = ta.ToUnityCatalog(mapping)
+	require.NoError(t, err)
+	assert.Equal(t, "table", grants.SecurableType)
+	assert.Equal(t, "main.default.recent_orders", grants.Name)
+	assert.Equal(t, []string{"SELECT"}, grants.PrivilegeAssignments[0].Privileges)
+	assert.Len(t, grants.Untranslatable, 2)
+
+	ta = SqlPermissions{
+		Catalog: true,
+		PrivilegeAssignments: []PrivilegeAssignment{
+			{
+				Principal:  "users",
+				Privileges: []string{"USAGE", "READ_METADATA", "CREATE"},
+			},
+		},
+	}
+	grants, err = ta.ToUnityCatalog(mapping)
+	require.NoError(t, err)
+	assert.Equal(t, "catalog", grants.SecurableType)
+	assert.Equal(t, "main", grants.Name)
+	assert.Equal(t, "account users", grants.PrivilegeAssignments[0].Principal)
+	assert.Equal(t, []string{"BROWSE", "CREATE_SCHEMA", "USE_CATALOG"}, grants.PrivilegeAssignments[0].Privileges)
+}
+
+func TestSqlPermissionsToUnityCatalog_Untranslatable(t *testing.T) {
+	ta := SqlPermissions{
+		AnyFile: true,
+		PrivilegeAssignments: []PrivilegeAssignment{
+			{
+				Principal:  "users",
+				Privileges: []string{"SELECT"},
+			},
+		},
+	}
+	grants, err := ta.ToUnityCatalog(map[string]string{"hive_metastore": "main"})
+	require.NoError(t, err)
+	assert.Equal(t, "", grants.SecurableType)
+	assert.Equal(t, []string{"any file/ has no equivalent securable in Unity Catalog"}, grants.Untranslatable)
+}
+
+func TestSqlPermissionsToUnityCatalog_NoMapping(t *testing.T) {
+	ta := SqlPermissions{Table: "foo", Database: "sales"}
+	_, err := ta.ToUnityCatalog(map[string]string{"finance": "main"})
+	assert.EqualError(t, err, "no Unity Catalog catalog is mapped for table/sales.foo")
+}
+
+func TestSqlPermissionsToUnityCatalog_CatalogWithMappedDefaultDatabase(t *testing.T) {
+	mapping := map[string]string{
+		"hive_metastore": "main",
+		"default":        "sandbox",
+	}
+	catalog := SqlPermissions{Catalog: true}
+	name, err := catalog.unityCatalogName(mapping)
+	require.NoError(t, err)
+	assert.Equal(t, "main", name)
+
+	database := SqlPermissions{Database: "default"}
+	name, err = database.unityCatalogName(mapping)
+	require.NoError(t, err)
+	assert.Equal(t, "sandbox.default", name)
+
+	table := SqlPermissions{Table: "orders"}
+	name, err = table.unityCatalogName(mapping)
+	require.NoError(t, err)
+	assert.Equal(t, "sandbox.default.orders", name)
+}
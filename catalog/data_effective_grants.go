@@ -0,0 +1,101 @@
+package catalog
+
+import (
+	"context"
+	"sort"
+
+	"github.com/databricks/databricks-sdk-go/service/catalog"
+	"github.com/databricks/terraform-provider-databricks/catalog/permissions"
+	"github.com/databricks/terraform-provider-databricks/common"
+	"github.com/databricks/terraform-provider-databricks/scim"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// EffectivePrivilege is a privilege together with the securable it's inherited from
+type EffectivePrivilege struct {
+	Privilege         string `json:"privilege"`
+	InheritedFromType string `json:"inherited_from_type,omitempty"`
+	InheritedFromName string `json:"inherited_from_name,omitempty"`
+}
+
+// EffectivePrivilegeAssignment reflects on `grant` block of effective grants
+type EffectivePrivilegeAssignment struct {
+	Principal  string               `json:"principal"`
+	Privileges []EffectivePrivilege `json:"privileges,omitempty"`
+}
+
+type effectiveGrants struct {
+	Principal     string                         `json:"principal,omitempty"`
+	IncludeGroups bool                           `json:"include_groups,omitempty" tf:"default:true"`
+	Assignments   []EffectivePrivilegeAssignment `json:"grant,omitempty" tf:"computed"`
+}
+
+func effectiveAssignments(effective *catalog.EffectivePermissionsList) (assignments []EffectivePrivilegeAssignment) {
+	for _, pa := range effective.PrivilegeAssignments {
+		assignment := EffectivePrivilegeAssignment{
+			Principal: pa.Principal,
+		}
+		for _, p := range pa.Privileges {
+			assignment.Privileges = append(assignment.Privileges, EffectivePrivilege{
+				Privilege:         permissions.NormalizePrivilege(p.Privilege.String()),
+				InheritedFromType: p.InheritedFromType.String(),
+				InheritedFromName: p.InheritedFromName,
+			})
+		}
+		assignments = append(assignments, assignment)
+	}
+	return
+}
+
+// DataSourceEffectiveGrants returns effective privileges on a securable, including the ones
+// inherited from parent securables
+func DataSourceEffectiveGrants() common.Resource {
+	s := common.StructToSchema(effectiveGrants{}, func(s map[string]*schema.Schema) map[string]*schema.Schema {
+		eoo := []string{}
+		for field := range permissions.Mappings {
+			s[field] = &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			}
+			eoo = append(eoo, field)
+		}
+		for field := range permissions.Mappings {
+			s[field].ExactlyOneOf = eoo
+		}
+		return s
+	})
+	return common.Resource{
+		Schema: s,
+		Read: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			securable, name := permissions.Mappings.KeyValue(d)
+			principal := d.Get("principal").(string)
+			principals := []string{principal}
+			if principal != "" && d.Get("include_groups").(bool) {
+				// the API only returns privileges assigned to the principal itself
+				groups, err := scim.PrincipalGroups(ctx, c, principal)
+				if err != nil {
+					return err
+				}
+				principals = append(principals, groups...)
+			}
+			unityCatalogPermissionsAPI := permissions.NewUnityCatalogPermissionsAPI(ctx, c)
+			var grants effectiveGrants
+			for _, p := range principals {
+				effective, err := unityCatalogPermissionsAPI.GetEffectivePermissions(
+					permissions.Mappings.GetSecurableType(securable), name, p)
+				if err != nil {
+					return err
+				}
+				grants.Assignments = append(grants.Assignments, effectiveAssignments(effective)...)
+			}
+			// so that the order of principals is stable between reads
+			sort.Slice(grants.Assignments, func(i, j int) bool {
+				return grants.Assignments[i].Principal < grants.Assignments[j].Principal
+			})
+			grants.Principal = principal
+			grants.IncludeGroups = d.Get("include_groups").(bool)
+			d.SetId(permissions.Mappings.Id(d))
+			return common.StructToData(grants, s, d)
+		},
+	}
+}
@@ -0,0 +1,233 @@
+package catalog
+
+import (
+	"testing"
+
+	"github.com/databricks/databricks-sdk-go/service/catalog"
+	"github.com/databricks/terraform-provider-databricks/qa"
+	"github.com/databricks/terraform-provider-databricks/scim"
+)
+
+func TestDataSourceEffectiveGrants(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/unity-catalog/effective-permissions/table/foo.bar.baz?",
+				Response: catalog.EffectivePermissionsList{
+					PrivilegeAssignments: []catalog.EffectivePrivilegeAssignment{
+						{
+							Principal: "someone-else",
+							Privileges: []catalog.EffectivePrivilege{
+								{
+									Privilege: "MODIFY",
+								},
+							},
+						},
+						{
+							Principal: "data engineers",
+							Privileges: []catalog.EffectivePrivilege{
+								{
+									Privilege:         "SELECT",
+									InheritedFromType: "catalog",
+									InheritedFromName: "foo",
+								},
+								{
+									Privilege:         "USE SCHEMA",
+									InheritedFromType: "schema",
+									InheritedFromName: "foo.bar",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Resource:    DataSourceEffectiveGrants(),
+		Read:        true,
+		NonWritable: true,
+		ID:          "_",
+		HCL:         `table = "foo.bar.baz"`,
+	}.ApplyAndExpectData(t, map[string]any{
+		"id":                             "table/foo.bar.baz",
+		"grant.#":                        2,
+		"grant.0.principal":              "data engineers",
+		"grant.0.privileges.0.privilege": "SELECT",
+		"grant.0.privileges.0.inherited_from_type": "catalog",
+		"grant.0.privileges.0.inherited_from_name": "foo",
+		"grant.0.privileges.1.privilege":           "USE_SCHEMA",
+		"grant.1.principal":                        "someone-else",
+		"grant.1.privileges.0.privilege":           "MODIFY",
+		"grant.1.privileges.0.inherited_from_type": "",
+	})
+}
+
+func TestDataSourceEffectiveGrants_Principal(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/unity-catalog/effective-permissions/schema/foo.bar?principal=me",
+				Response: catalog.EffectivePermissionsList{
+					PrivilegeAssignments: []catalog.EffectivePrivilegeAssignment{
+						{
+							Principal: "me",
+							Privileges: []catalog.EffectivePrivilege{
+								{
+									Privilege:         "USE_CATALOG",
+									InheritedFromType: "metastore",
+									InheritedFromName: "abc",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Resource:    DataSourceEffectiveGrants(),
+		Read:        true,
+		NonWritable: true,
+		ID:          "_",
+		HCL: `
+		schema         = "foo.bar"
+		principal      = "me"
+		include_groups = false
+		`,
+	}.ApplyAndExpectData(t, map[string]any{
+		"id":      "schema/foo.bar",
+		"grant.#": 1,
+		"grant.0.privileges.0.inherited_from_type": "metastore",
+	})
+}
+
+func TestDataSourceEffectiveGrants_Share(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/unity-catalog/shares/myshare/permissions?",
+				Response: catalog.PermissionsList{
+					PrivilegeAssignments: []catalog.PrivilegeAssignment{
+						{
+							Principal:  "me",
+							Privileges: []catalog.Privilege{"SELECT"},
+						},
+						{
+							Principal:  "someone-else",
+							Privileges: []catalog.Privilege{"SELECT"},
+						},
+					},
+				},
+			},
+		},
+		Resource:    DataSourceEffectiveGrants(),
+		Read:        true,
+		NonWritable: true,
+		ID:          "_",
+		HCL: `
+		share          = "myshare"
+		principal      = "me"
+		include_groups = false
+		`,
+	}.ApplyAndExpectData(t, map[string]any{
+		"id":                             "share/myshare",
+		"grant.#":                        1,
+		"grant.0.principal":              "me",
+		"grant.0.privileges.0.privilege": "SELECT",
+	})
+}
+
+func TestDataSourceEffectiveGrants_PrincipalGroups(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Users?excludedAttributes=roles&filter=userName%20eq%20%22me%22",
+				Response: scim.UserList{
+					Resources: []scim.User{
+						{
+							ID:       "123",
+							UserName: "me",
+							Groups: []scim.ComplexValue{
+								{Value: "g1"},
+							},
+						},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Groups/g1?attributes=members,roles,entitlements,externalId",
+				Response: scim.Group{
+					ID:          "g1",
+					DisplayName: "data engineers",
+					Groups: []scim.ComplexValue{
+						{Value: "g2"},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Groups/g2?attributes=members,roles,entitlements,externalId",
+				Response: scim.Group{
+					ID:          "g2",
+					DisplayName: "all analysts",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/unity-catalog/effective-permissions/schema/foo.bar?principal=me",
+				Response: catalog.EffectivePermissionsList{},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/unity-catalog/effective-permissions/schema/foo.bar?principal=data+engineers",
+				Response: catalog.EffectivePermissionsList{
+					PrivilegeAssignments: []catalog.EffectivePrivilegeAssignment{
+						{
+							Principal: "data engineers",
+							Privileges: []catalog.EffectivePrivilege{
+								{
+									Privilege: "MODIFY",
+								},
+							},
+						},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/unity-catalog/effective-permissions/schema/foo.bar?principal=all+analysts",
+				Response: catalog.EffectivePermissionsList{
+					PrivilegeAssignments: []catalog.EffectivePrivilegeAssignment{
+						{
+							Principal: "all analysts",
+							Privileges: []catalog.EffectivePrivilege{
+								{
+									Privilege:         "SELECT",
+									InheritedFromType: "catalog",
+									InheritedFromName: "foo",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Resource:    DataSourceEffectiveGrants(),
+		Read:        true,
+		NonWritable: true,
+		ID:          "_",
+		HCL: `
+		schema    = "foo.bar"
+		principal = "me"
+		`,
+	}.ApplyAndExpectData(t, map[string]any{
+		"id":                             "schema/foo.bar",
+		"grant.#":                        2,
+		"grant.0.principal":              "all analysts",
+		"grant.0.privileges.0.privilege": "SELECT",
+		"grant.1.principal":              "data engineers",
+		"grant.1.privileges.0.privilege": "MODIFY",
+	})
+}
@@ -0,0 +1,29 @@
+package catalog
+
+import (
+	"context"
+
+	"github.com/databricks/databricks-sdk-go"
+	"github.com/databricks/databricks-sdk-go/service/catalog"
+	"github.com/databricks/terraform-provider-databricks/common"
+)
+
+func DataSourceFunctions() common.Resource {
+	return common.WorkspaceData(func(ctx context.Context, data *struct {
+		CatalogName   string                 `json:"catalog_name"`
+		SchemaName    string                 `json:"schema_name"`
+		IncludeBrowse bool                   `json:"include_browse,omitempty"`
+		Functions     []catalog.FunctionInfo `json:"functions,omitempty" tf:"computed"`
+	}, w *databricks.WorkspaceClient) error {
+		functions, err := w.Functions.ListAll(ctx, catalog.ListFunctionsRequest{
+			CatalogName:   data.CatalogName,
+			SchemaName:    data.SchemaName,
+			IncludeBrowse: data.IncludeBrowse,
+		})
+		if err != nil {
+			return err
+		}
+		data.Functions = functions
+		return nil
+	})
+}
@@ -0,0 +1,42 @@
+package catalog
+
+import (
+	"testing"
+
+	"github.com/databricks/databricks-sdk-go/service/catalog"
+	"github.com/databricks/terraform-provider-databricks/qa"
+)
+
+func TestDataSourceFunctions(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/unity-catalog/functions?catalog_name=main&schema_name=default",
+				Response: catalog.ListFunctionsResponse{
+					Functions: []catalog.FunctionInfo{sqlFunctionInfo},
+				},
+			},
+		},
+		Resource: DataSourceFunctions(),
+		HCL: `
+		catalog_name = "main"
+		schema_name = "default"`,
+		Read:        true,
+		NonWritable: true,
+		ID:          "_",
+	}.ApplyAndExpectData(t, map[string]any{
+		"functions.#":           1,
+		"functions.0.full_name": "main.default.add_one",
+	})
+}
+
+func TestDataSourceFunctions_Error(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures:    qa.HTTPFailures,
+		Resource:    DataSourceFunctions(),
+		Read:        true,
+		NonWritable: true,
+		ID:          "_",
+	}.ExpectError(t, "i'm a teapot")
+}
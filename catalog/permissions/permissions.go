@@ -37,6 +37,38 @@ func (a UnityCatalogPermissionsAPI) GetPermissions(securable catalog.SecurableTy
 	return
 }
 
+// GetEffectivePermissions returns privileges of all principals or of the given principal, including
+// the ones inherited from parent securables. Shares don't support inheritance, so only privileges
+// assigned directly to them are returned.
+func (a UnityCatalogPermissionsAPI) GetEffectivePermissions(securable catalog.SecurableType, name, principal string) (*catalog.EffectivePermissionsList, error) {
+	if securable.String() == "share" {
+		list, err := a.GetPermissions(securable, name)
+		if err != nil {
+			return nil, err
+		}
+		effective := &catalog.EffectivePermissionsList{}
+		for _, pa := range list.PrivilegeAssignments {
+			if principal != "" && pa.Principal != principal {
+				continue
+			}
+			privileges := []catalog.EffectivePrivilege{}
+			for _, p := range pa.Privileges {
+				privileges = append(privileges, catalog.EffectivePrivilege{Privilege: p})
+			}
+			effective.PrivilegeAssignments = append(effective.PrivilegeAssignments, catalog.EffectivePrivilegeAssignment{
+				Principal:  pa.Principal,
+				Privileges: privileges,
+			})
+		}
+		return effective, nil
+	}
+	return a.client.Grants.GetEffective(a.context, catalog.GetEffectiveRequest{
+		SecurableType: securable,
+		FullName:      name,
+		Principal:     principal,
+	})
+}
+
 func (a UnityCatalogPermissionsAPI) UpdatePermissions(securable catalog.SecurableType, name string, diff []catalog.PermissionsChange) error {
 	if securable.String() == "share" {
 		return a.client.Shares.UpdatePermissions(a.context, sharing.UpdateSharePermissions{
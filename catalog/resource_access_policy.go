@@ -0,0 +1,336 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/databricks/databricks-sdk-go"
+	"github.com/databricks/databricks-sdk-go/service/catalog"
+	"github.com/databricks/databricks-sdk-go/service/sql"
+	"github.com/databricks/terraform-provider-databricks/catalog/permissions"
+	"github.com/databricks/terraform-provider-databricks/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// AccessPolicy grants the same privileges to a principal on all schemas matching a selector
+type AccessPolicy struct {
+	Principal         string            `json:"principal" tf:"force_new"`
+	Privileges        []string          `json:"privileges" tf:"slice_set"`
+	Catalog           string            `json:"catalog" tf:"force_new"`
+	SchemaNamePattern string            `json:"schema_name_pattern,omitempty" tf:"force_new"`
+	Tags              map[string]string `json:"tags,omitempty" tf:"force_new"`
+	WarehouseID       string            `json:"warehouse_id,omitempty"`
+	Schemas           []string          `json:"schemas,omitempty" tf:"computed,slice_set"`
+	// schemas, that privileges were granted on by the last apply, so that they're revoked,
+	// once the schemas stop matching, even if they no longer show up in `schemas`
+	GrantedSchemas []string `json:"granted_schemas,omitempty" tf:"computed,slice_set"`
+}
+
+// id is `catalog/principal/pattern`, followed by `/tags` in query string format for tag selectors
+func (p AccessPolicy) id() string {
+	id := fmt.Sprintf("%s/%s/%s", p.Catalog, p.Principal, p.SchemaNamePattern)
+	if len(p.Tags) == 0 {
+		return id
+	}
+	tags := url.Values{}
+	for k, v := range p.Tags {
+		tags.Set(k, v)
+	}
+	return id + "/" + tags.Encode()
+}
+
+func (p *AccessPolicy) parseID(id string) error {
+	split := strings.SplitN(id, "/", 4)
+	if len(split) < 3 {
+		return fmt.Errorf("ID must be three or four elements split by `/`: %s", id)
+	}
+	p.Catalog, p.Principal, p.SchemaNamePattern = split[0], split[1], split[2]
+	if len(split) == 3 {
+		return nil
+	}
+	tags, err := url.ParseQuery(split[3])
+	if err != nil {
+		return fmt.Errorf("invalid tags in ID %s: %w", id, err)
+	}
+	p.Tags = map[string]string{}
+	for k := range tags {
+		p.Tags[k] = tags.Get(k)
+	}
+	return nil
+}
+
+// schemaTags returns tags of all schemas in the catalog, as reported by its information schema
+func schemaTags(ctx context.Context, w *databricks.WorkspaceClient, catalogName, warehouseID string) (map[string]map[string]string, error) {
+	query := fmt.Sprintf("SELECT schema_name, tag_name, tag_value FROM `%s`.information_schema.schema_tags",
+		strings.ReplaceAll(catalogName, "`", "``"))
+	log.Printf("[INFO] Executing Sql: %s", query)
+	res, err := w.StatementExecution.ExecuteAndWait(ctx, sql.ExecuteStatementRequest{
+		Statement:   query,
+		WarehouseId: warehouseID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	tags := map[string]map[string]string{}
+	chunk := res.Result
+	for chunk != nil {
+		for _, row := range chunk.DataArray {
+			if len(row) != 3 {
+				continue
+			}
+			if _, ok := tags[row[0]]; !ok {
+				tags[row[0]] = map[string]string{}
+			}
+			tags[row[0]][row[1]] = row[2]
+		}
+		if chunk.NextChunkIndex == 0 {
+			break
+		}
+		chunk, err = w.StatementExecution.GetStatementResultChunkNByStatementIdAndChunkIndex(
+			ctx, res.StatementId, chunk.NextChunkIndex)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return tags, nil
+}
+
+// matchingSchemas returns full names of the schemas, that are selected by the policy
+func (p AccessPolicy) matchingSchemas(ctx context.Context, w *databricks.WorkspaceClient) ([]string, error) {
+	schemas, err := w.Schemas.ListAll(ctx, catalog.ListSchemasRequest{
+		CatalogName: p.Catalog,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var tags map[string]map[string]string
+	if len(p.Tags) > 0 {
+		tags, err = schemaTags(ctx, w, p.Catalog, p.WarehouseID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	matching := []string{}
+	for _, s := range schemas {
+		if s.Name == "information_schema" {
+			continue
+		}
+		if p.SchemaNamePattern != "" {
+			ok, err := path.Match(p.SchemaNamePattern, s.Name)
+			if err != nil {
+				return nil, fmt.Errorf("invalid schema_name_pattern: %w", err)
+			}
+			if !ok {
+				continue
+			}
+		}
+		hasTags := true
+		for k, v := range p.Tags {
+			if tags[s.Name][k] != v {
+				hasTags = false
+				break
+			}
+		}
+		if !hasTags {
+			continue
+		}
+		matching = append(matching, s.FullName)
+	}
+	sort.Strings(matching)
+	return matching, nil
+}
+
+// apply grants privileges of the policy on the given schemas and revokes them from the
+// schemas that shouldn't have them anymore, leaving other principals untouched.
+func (p AccessPolicy) apply(a permissions.UnityCatalogPermissionsAPI, grant []string, revoke []string) error {
+	privileges := []catalog.Privilege{}
+	for _, v := range p.Privileges {
+		privileges = append(privileges, catalog.Privilege(permissions.NormalizePrivilege(v)))
+	}
+	desired := catalog.PermissionsList{
+		PrivilegeAssignments: []catalog.PrivilegeAssignment{
+			{
+				Principal:  p.Principal,
+				Privileges: privileges,
+			},
+		},
+	}
+	for _, name := range grant {
+		err := replacePermissionsForPrincipal(a, "schema", name, p.Principal, desired)
+		if err != nil {
+			return fmt.Errorf("cannot grant privileges on %s: %w", name, err)
+		}
+	}
+	for _, name := range revoke {
+		err := replacePermissionsForPrincipal(a, "schema", name, p.Principal, catalog.PermissionsList{})
+		if err != nil {
+			return fmt.Errorf("cannot revoke privileges on %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// granted returns schemas, that privileges were granted on. States created before `granted_schemas`
+// was introduced only have the schemas matching at the time of the last refresh.
+func (p AccessPolicy) granted() []string {
+	if len(p.GrantedSchemas) > 0 {
+		return p.GrantedSchemas
+	}
+	return p.Schemas
+}
+
+func previousSchemas(d *schema.ResourceData, field string) (schemas []string) {
+	old, _ := d.GetChange(field)
+	for _, v := range old.(*schema.Set).List() {
+		schemas = append(schemas, v.(string))
+	}
+	return
+}
+
+func withoutSchemas(in []string, remove []string) (out []string) {
+	for _, v := range in {
+		if !slices.Contains(remove, v) {
+			out = append(out, v)
+		}
+	}
+	return
+}
+
+func ResourceAccessPolicy() common.Resource {
+	s := common.StructToSchema(AccessPolicy{},
+		func(m map[string]*schema.Schema) map[string]*schema.Schema {
+			// set custom hash function for privileges
+			common.MustSchemaPath(m, "privileges").Set = func(i any) int {
+				privilege := i.(string)
+				return schema.HashString(permissions.NormalizePrivilege(privilege))
+			}
+			common.CustomizeSchemaPath(m, "warehouse_id").SetRequiredWith([]string{"tags"})
+			common.CustomizeSchemaPath(m, "tags").SetRequiredWith([]string{"warehouse_id"})
+			return m
+		})
+	return common.Resource{
+		Schema: s,
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff) error {
+			// schemas, that stopped or started matching since the last apply, are only visible
+			// after the refresh, so the update is planned to revoke or grant privileges on them
+			schemas := d.Get("schemas").(*schema.Set)
+			granted := d.Get("granted_schemas").(*schema.Set)
+			if schemas.Equal(granted) {
+				return nil
+			}
+			return d.SetNewComputed("granted_schemas")
+		},
+		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			w, err := c.WorkspaceClient()
+			if err != nil {
+				return err
+			}
+			var policy AccessPolicy
+			common.DataToStructPointer(d, s, &policy)
+			matching, err := policy.matchingSchemas(ctx, w)
+			if err != nil {
+				return err
+			}
+			err = policy.apply(permissions.NewUnityCatalogPermissionsAPI(ctx, c), matching, nil)
+			if err != nil {
+				return err
+			}
+			d.Set("granted_schemas", matching)
+			d.SetId(policy.id())
+			return nil
+		},
+		Read: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			w, err := c.WorkspaceClient()
+			if err != nil {
+				return err
+			}
+			var policy AccessPolicy
+			common.DataToStructPointer(d, s, &policy)
+			if policy.Catalog == "" {
+				// imported resource
+				err = policy.parseID(d.Id())
+				if err != nil {
+					return err
+				}
+			}
+			matching, err := policy.matchingSchemas(ctx, w)
+			if err != nil {
+				return err
+			}
+			// Privileges in the state are the ones granted on every matching schema, plus the ones
+			// that aren't in the configuration, but are granted on some of them. This way any drift,
+			// including newly created matching schemas, shows up as a change of privileges.
+			configured := map[string]bool{}
+			for _, v := range policy.Privileges {
+				configured[permissions.NormalizePrivilege(v)] = true
+			}
+			everywhere := map[string]int{}
+			extra := map[string]bool{}
+			a := permissions.NewUnityCatalogPermissionsAPI(ctx, c)
+			for _, name := range matching {
+				existing, err := a.GetPermissions(permissions.Mappings.GetSecurableType("schema"), name)
+				if err != nil {
+					return err
+				}
+				for _, pa := range existing.PrivilegeAssignments {
+					if pa.Principal != policy.Principal {
+						continue
+					}
+					for _, p := range pa.Privileges {
+						privilege := permissions.NormalizePrivilege(p.String())
+						everywhere[privilege]++
+						if !configured[privilege] {
+							extra[privilege] = true
+						}
+					}
+				}
+			}
+			privileges := []string{}
+			if len(matching) == 0 {
+				privileges = policy.Privileges
+			}
+			for privilege, count := range everywhere {
+				if count == len(matching) || extra[privilege] {
+					privileges = append(privileges, privilege)
+				}
+			}
+			policy.Privileges = privileges
+			policy.Schemas = matching
+			return common.StructToData(policy, s, d)
+		},
+		Update: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			w, err := c.WorkspaceClient()
+			if err != nil {
+				return err
+			}
+			var policy AccessPolicy
+			common.DataToStructPointer(d, s, &policy)
+			matching, err := policy.matchingSchemas(ctx, w)
+			if err != nil {
+				return err
+			}
+			// granted_schemas is unknown in the plan, so the previous value is taken from the state
+			previous := AccessPolicy{
+				Schemas:        previousSchemas(d, "schemas"),
+				GrantedSchemas: previousSchemas(d, "granted_schemas"),
+			}
+			err = policy.apply(permissions.NewUnityCatalogPermissionsAPI(ctx, c),
+				matching, withoutSchemas(previous.granted(), matching))
+			if err != nil {
+				return err
+			}
+			return d.Set("granted_schemas", matching)
+		},
+		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			var policy AccessPolicy
+			common.DataToStructPointer(d, s, &policy)
+			return policy.apply(permissions.NewUnityCatalogPermissionsAPI(ctx, c), nil, policy.granted())
+		},
+	}
+}
@@ -0,0 +1,517 @@
+package catalog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/databricks/databricks-sdk-go/service/catalog"
+	"github.com/databricks/databricks-sdk-go/service/sql"
+	"github.com/databricks/terraform-provider-databricks/qa"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var accessPolicySchemas = qa.HTTPFixture{
+	Method:   "GET",
+	Resource: "/api/2.1/unity-catalog/schemas?catalog_name=main",
+	Response: catalog.ListSchemasResponse{
+		Schemas: []catalog.SchemaInfo{
+			{
+				Name:     "sales_eu",
+				FullName: "main.sales_eu",
+			},
+			{
+				Name:     "hr",
+				FullName: "main.hr",
+			},
+			{
+				Name:     "information_schema",
+				FullName: "main.information_schema",
+			},
+		},
+	},
+	ReuseRequest: true,
+}
+
+func TestAccessPolicyCornerCases(t *testing.T) {
+	qa.ResourceCornerCases(t, ResourceAccessPolicy(), qa.CornerCaseID("main/me/sales_*"),
+		// nothing to revoke, as there are no schemas in the state
+		qa.CornerCaseSkipCRUD("delete"))
+}
+
+func TestResourceAccessPolicyCreate(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			accessPolicySchemas,
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/unity-catalog/permissions/schema/main.sales_eu?",
+				Response: catalog.PermissionsList{
+					PrivilegeAssignments: []catalog.PrivilegeAssignment{
+						{
+							Principal:  "someone-else",
+							Privileges: []catalog.Privilege{"MODIFY"},
+						},
+					},
+				},
+			},
+			{
+				Method:   "PATCH",
+				Resource: "/api/2.1/unity-catalog/permissions/schema/main.sales_eu",
+				ExpectedRequest: catalog.UpdatePermissions{
+					Changes: []catalog.PermissionsChange{
+						{
+							Principal: "me",
+							Add:       []catalog.Privilege{"USE_SCHEMA"},
+						},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/unity-catalog/permissions/schema/main.sales_eu?",
+				Response: catalog.PermissionsList{
+					PrivilegeAssignments: []catalog.PrivilegeAssignment{
+						{
+							Principal:  "me",
+							Privileges: []catalog.Privilege{"USE_SCHEMA"},
+						},
+						{
+							Principal:  "someone-else",
+							Privileges: []catalog.Privilege{"MODIFY"},
+						},
+					},
+				},
+				ReuseRequest: true,
+			},
+		},
+		Resource: ResourceAccessPolicy(),
+		Create:   true,
+		HCL: `
+		principal = "me"
+		privileges = ["use_schema"]
+		catalog = "main"
+		schema_name_pattern = "sales_*"
+		`,
+	}.ApplyAndExpectData(t, map[string]any{
+		"id":         "main/me/sales_*",
+		"schemas":    []string{"main.sales_eu"},
+		"privileges": []string{"USE_SCHEMA"},
+	})
+}
+
+func TestResourceAccessPolicyReadNewSchema(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/unity-catalog/schemas?catalog_name=main",
+				Response: catalog.ListSchemasResponse{
+					Schemas: []catalog.SchemaInfo{
+						{
+							Name:     "sales_eu",
+							FullName: "main.sales_eu",
+						},
+						{
+							Name:     "sales_us",
+							FullName: "main.sales_us",
+						},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/unity-catalog/permissions/schema/main.sales_eu?",
+				Response: catalog.PermissionsList{
+					PrivilegeAssignments: []catalog.PrivilegeAssignment{
+						{
+							Principal:  "me",
+							Privileges: []catalog.Privilege{"USE_SCHEMA", "SELECT", "MODIFY"},
+						},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/unity-catalog/permissions/schema/main.sales_us?",
+				Response: catalog.PermissionsList{},
+			},
+		},
+		Resource: ResourceAccessPolicy(),
+		Read:     true,
+		New:      true,
+		ID:       "main/me/sales_*",
+		HCL: `
+		principal = "me"
+		privileges = ["USE_SCHEMA", "SELECT"]
+		catalog = "main"
+		schema_name_pattern = "sales_*"
+		`,
+	}.ApplyAndExpectData(t, map[string]any{
+		"schemas":    []string{"main.sales_eu", "main.sales_us"},
+		"privileges": []string{"MODIFY"},
+	})
+}
+
+func TestResourceAccessPolicyCreateByTags(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			accessPolicySchemas,
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/sql/statements/",
+				ExpectedRequest: sql.ExecuteStatementRequest{
+					Statement:   "SELECT schema_name, tag_name, tag_value FROM `main`.information_schema.schema_tags",
+					WarehouseId: "abc",
+				},
+				Response: sql.StatementResponse{
+					StatementId: "statement1",
+					Status: &sql.StatementStatus{
+						State: "SUCCEEDED",
+					},
+					Result: &sql.ResultData{
+						DataArray: [][]string{
+							{"hr", "pii", "true"},
+							{"sales_eu", "pii", "false"},
+						},
+					},
+				},
+				ReuseRequest: true,
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/unity-catalog/permissions/schema/main.hr?",
+				Response: catalog.PermissionsList{},
+			},
+			{
+				Method:   "PATCH",
+				Resource: "/api/2.1/unity-catalog/permissions/schema/main.hr",
+				ExpectedRequest: catalog.UpdatePermissions{
+					Changes: []catalog.PermissionsChange{
+						{
+							Principal: "auditors",
+							Add:       []catalog.Privilege{"USE_SCHEMA"},
+						},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/unity-catalog/permissions/schema/main.hr?",
+				Response: catalog.PermissionsList{
+					PrivilegeAssignments: []catalog.PrivilegeAssignment{
+						{
+							Principal:  "auditors",
+							Privileges: []catalog.Privilege{"USE_SCHEMA"},
+						},
+					},
+				},
+				ReuseRequest: true,
+			},
+		},
+		Resource: ResourceAccessPolicy(),
+		Create:   true,
+		HCL: `
+		principal = "auditors"
+		privileges = ["USE_SCHEMA"]
+		catalog = "main"
+		tags = {
+			pii = "true"
+		}
+		warehouse_id = "abc"
+		`,
+	}.ApplyAndExpectData(t, map[string]any{
+		"id":              "main/auditors//pii=true",
+		"schemas":         []string{"main.hr"},
+		"granted_schemas": []string{"main.hr"},
+	})
+}
+
+func TestResourceAccessPolicyUpdateRevokesFromUnmatched(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			accessPolicySchemas,
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/unity-catalog/permissions/schema/main.sales_eu?",
+				Response: catalog.PermissionsList{
+					PrivilegeAssignments: []catalog.PrivilegeAssignment{
+						{
+							Principal:  "me",
+							Privileges: []catalog.Privilege{"USE_SCHEMA"},
+						},
+					},
+				},
+			},
+			{
+				Method:   "PATCH",
+				Resource: "/api/2.1/unity-catalog/permissions/schema/main.sales_eu",
+				ExpectedRequest: catalog.UpdatePermissions{
+					Changes: []catalog.PermissionsChange{
+						{
+							Principal: "me",
+							Add:       []catalog.Privilege{"SELECT"},
+						},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/unity-catalog/permissions/schema/main.sales_eu?",
+				Response: catalog.PermissionsList{
+					PrivilegeAssignments: []catalog.PrivilegeAssignment{
+						{
+							Principal:  "me",
+							Privileges: []catalog.Privilege{"USE_SCHEMA", "SELECT"},
+						},
+					},
+				},
+				ReuseRequest: true,
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/unity-catalog/permissions/schema/main.sales_us?",
+				Response: catalog.PermissionsList{
+					PrivilegeAssignments: []catalog.PrivilegeAssignment{
+						{
+							Principal:  "me",
+							Privileges: []catalog.Privilege{"USE_SCHEMA"},
+						},
+					},
+				},
+			},
+			{
+				Method:   "PATCH",
+				Resource: "/api/2.1/unity-catalog/permissions/schema/main.sales_us",
+				ExpectedRequest: catalog.UpdatePermissions{
+					Changes: []catalog.PermissionsChange{
+						{
+							Principal: "me",
+							Remove:    []catalog.Privilege{"USE_SCHEMA"},
+						},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/unity-catalog/permissions/schema/main.sales_us?",
+				Response: catalog.PermissionsList{},
+			},
+		},
+		Resource: ResourceAccessPolicy(),
+		Update:   true,
+		ID:       "main/me/sales_*",
+		InstanceState: map[string]string{
+			"principal":           "me",
+			"catalog":             "main",
+			"schema_name_pattern": "sales_*",
+			"privileges.#":        "1",
+			"privileges.0":        "USE_SCHEMA",
+			"schemas.#":           "2",
+			"schemas.0":           "main.sales_eu",
+			"schemas.1":           "main.sales_us",
+			"granted_schemas.#":   "2",
+			"granted_schemas.0":   "main.sales_eu",
+			"granted_schemas.1":   "main.sales_us",
+		},
+		HCL: `
+		principal = "me"
+		privileges = ["USE_SCHEMA", "SELECT"]
+		catalog = "main"
+		schema_name_pattern = "sales_*"
+		`,
+	}.ApplyAndExpectData(t, map[string]any{
+		"schemas":         []string{"main.sales_eu"},
+		"granted_schemas": []string{"main.sales_eu"},
+	})
+}
+
+func TestResourceAccessPolicyDelete(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/unity-catalog/permissions/schema/main.sales_eu?",
+				Response: catalog.PermissionsList{
+					PrivilegeAssignments: []catalog.PrivilegeAssignment{
+						{
+							Principal:  "me",
+							Privileges: []catalog.Privilege{"USE_SCHEMA", "SELECT"},
+						},
+					},
+				},
+			},
+			{
+				Method:   "PATCH",
+				Resource: "/api/2.1/unity-catalog/permissions/schema/main.sales_eu",
+				ExpectedRequest: catalog.UpdatePermissions{
+					Changes: []catalog.PermissionsChange{
+						{
+							Principal: "me",
+							Remove:    []catalog.Privilege{"USE_SCHEMA", "SELECT"},
+						},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/unity-catalog/permissions/schema/main.sales_eu?",
+				Response: catalog.PermissionsList{},
+			},
+		},
+		Resource: ResourceAccessPolicy(),
+		Delete:   true,
+		ID:       "main/me/sales_*",
+		InstanceState: map[string]string{
+			"principal":           "me",
+			"catalog":             "main",
+			"schema_name_pattern": "sales_*",
+			"privileges.#":        "2",
+			"privileges.0":        "USE_SCHEMA",
+			"privileges.1":        "SELECT",
+			"schemas.#":           "0",
+			"granted_schemas.#":   "1",
+			"granted_schemas.0":   "main.sales_eu",
+		},
+	}.ApplyNoError(t)
+}
+
+func TestAccessPolicyParseID(t *testing.T) {
+	var policy AccessPolicy
+	require.NoError(t, policy.parseID("main/auditors//pii=true&tier=gold"))
+	assert.Equal(t, AccessPolicy{
+		Catalog:   "main",
+		Principal: "auditors",
+		Tags:      map[string]string{"pii": "true", "tier": "gold"},
+	}, policy)
+	assert.Equal(t, "main/auditors//pii=true&tier=gold", policy.id())
+
+	policy = AccessPolicy{}
+	require.NoError(t, policy.parseID("main/me/sales_*"))
+	assert.Equal(t, "main/me/sales_*", policy.id())
+
+	assert.EqualError(t, policy.parseID("main"), "ID must be three or four elements split by `/`: main")
+}
+
+var accessPolicyTags = qa.HTTPFixture{
+	Method:   "POST",
+	Resource: "/api/2.0/sql/statements/",
+	Response: sql.StatementResponse{
+		StatementId: "statement1",
+		Status: &sql.StatementStatus{
+			State: "SUCCEEDED",
+		},
+		Result: &sql.ResultData{
+			DataArray: [][]string{
+				{"sales_eu", "pii", "true"},
+			},
+		},
+	},
+	ReuseRequest: true,
+}
+
+func TestResourceAccessPolicyReadThenUpdateRevokesFromUntagged(t *testing.T) {
+	hcl := `
+	principal = "auditors"
+	privileges = ["USE_SCHEMA"]
+	catalog = "main"
+	tags = {
+		pii = "true"
+	}
+	warehouse_id = "abc"
+	`
+	withUseSchema := qa.HTTPFixture{
+		Method:   "GET",
+		Resource: "/api/2.1/unity-catalog/permissions/schema/main.sales_eu?",
+		Response: catalog.PermissionsList{
+			PrivilegeAssignments: []catalog.PrivilegeAssignment{
+				{
+					Principal:  "auditors",
+					Privileges: []catalog.Privilege{"USE_SCHEMA"},
+				},
+			},
+		},
+		ReuseRequest: true,
+	}
+	// the tag was removed from main.hr after the last apply
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			accessPolicySchemas,
+			accessPolicyTags,
+			withUseSchema,
+		},
+		Resource: ResourceAccessPolicy(),
+		Read:     true,
+		New:      true,
+		ID:       "main/auditors//pii=true",
+		InstanceState: map[string]string{
+			"principal":         "auditors",
+			"catalog":           "main",
+			"warehouse_id":      "abc",
+			"tags.%":            "1",
+			"tags.pii":          "true",
+			"privileges.#":      "1",
+			"privileges.0":      "USE_SCHEMA",
+			"schemas.#":         "2",
+			"schemas.0":         "main.hr",
+			"schemas.1":         "main.sales_eu",
+			"granted_schemas.#": "2",
+			"granted_schemas.0": "main.hr",
+			"granted_schemas.1": "main.sales_eu",
+		},
+		HCL: hcl,
+	}.Apply(t)
+	require.NoError(t, err)
+	assert.Equal(t, []any{"main.sales_eu"}, d.Get("schemas").(*schema.Set).List())
+	assert.Len(t, d.Get("granted_schemas").(*schema.Set).List(), 2)
+
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			accessPolicySchemas,
+			accessPolicyTags,
+			withUseSchema,
+			{
+				Method:   "PATCH",
+				Resource: "/api/2.1/unity-catalog/permissions/schema/main.sales_eu",
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/unity-catalog/permissions/schema/main.hr?",
+				Response: catalog.PermissionsList{
+					PrivilegeAssignments: []catalog.PrivilegeAssignment{
+						{
+							Principal:  "auditors",
+							Privileges: []catalog.Privilege{"USE_SCHEMA"},
+						},
+					},
+				},
+			},
+			{
+				Method:   "PATCH",
+				Resource: "/api/2.1/unity-catalog/permissions/schema/main.hr",
+				ExpectedRequest: catalog.UpdatePermissions{
+					Changes: []catalog.PermissionsChange{
+						{
+							Principal: "auditors",
+							Remove:    []catalog.Privilege{"USE_SCHEMA"},
+						},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/unity-catalog/permissions/schema/main.hr?",
+				Response: catalog.PermissionsList{},
+			},
+		},
+		Resource:      ResourceAccessPolicy(),
+		Update:        true,
+		ID:            d.Id(),
+		InstanceState: d.State().Attributes,
+		HCL:           hcl,
+	}.ApplyAndExpectData(t, map[string]any{
+		"schemas":         []string{"main.sales_eu"},
+		"granted_schemas": []string{"main.sales_eu"},
+	})
+}
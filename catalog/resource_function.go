@@ -0,0 +1,187 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/databricks/databricks-sdk-go/service/catalog"
+	"github.com/databricks/terraform-provider-databricks/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// functions can't be altered, so every configurable field except owner requires re-creation
+func setFunctionFieldsForceNew(m map[string]*schema.Schema) {
+	for k, v := range m {
+		if k == "owner" || (!v.Optional && !v.Required) {
+			continue
+		}
+		v.ForceNew = true
+		if r, ok := v.Elem.(*schema.Resource); ok {
+			setFunctionFieldsForceNew(r.Schema)
+		}
+	}
+}
+
+func customizeFunctionParametersSchema(m map[string]*schema.Schema, field string) {
+	for _, k := range []string{"position", "type_text", "type_json", "type_precision", "type_scale",
+		"parameter_mode", "parameter_type"} {
+		common.CustomizeSchemaPath(m, field, "parameters", k).SetOptional().SetComputed()
+	}
+}
+
+// fillFunctionParameterDefaults assigns positions in the order of declaration and derives
+// type_text from type_name, when it's not specified.
+func fillFunctionParameterDefaults(params *catalog.FunctionParameterInfos) {
+	if params == nil {
+		return
+	}
+	for i := range params.Parameters {
+		p := &params.Parameters[i]
+		p.Position = i
+		if p.TypeText == "" {
+			p.TypeText = strings.ToLower(p.TypeName.String())
+		}
+	}
+}
+
+// newCreateFunction converts the configuration into a create request, filling the fields
+// that are mandatory for the API, but have the only meaningful value in most of the cases.
+func newCreateFunction(fi catalog.FunctionInfo) catalog.CreateFunction {
+	fillFunctionParameterDefaults(fi.InputParams)
+	fillFunctionParameterDefaults(fi.ReturnParams)
+	if fi.FullDataType == "" {
+		if fi.DataType == catalog.ColumnTypeNameTableType && fi.ReturnParams != nil {
+			columns := []string{}
+			for _, p := range fi.ReturnParams.Parameters {
+				columns = append(columns, fmt.Sprintf("%s %s", p.Name, p.TypeText))
+			}
+			fi.FullDataType = fmt.Sprintf("TABLE(%s)", strings.Join(columns, ", "))
+		} else {
+			fi.FullDataType = strings.ToLower(fi.DataType.String())
+		}
+	}
+	if fi.ParameterStyle == "" {
+		fi.ParameterStyle = catalog.FunctionInfoParameterStyleS
+	}
+	if fi.SecurityType == "" {
+		fi.SecurityType = catalog.FunctionInfoSecurityTypeDefiner
+	}
+	if fi.SpecificName == "" {
+		fi.SpecificName = fi.Name
+	}
+	if fi.SqlDataAccess == "" {
+		if fi.RoutineBody == catalog.FunctionInfoRoutineBodyExternal {
+			fi.SqlDataAccess = catalog.FunctionInfoSqlDataAccessNoSql
+		} else {
+			fi.SqlDataAccess = catalog.FunctionInfoSqlDataAccessContainsSql
+		}
+	}
+	cf := catalog.CreateFunction{
+		CatalogName:         fi.CatalogName,
+		SchemaName:          fi.SchemaName,
+		Name:                fi.Name,
+		Comment:             fi.Comment,
+		DataType:            fi.DataType,
+		FullDataType:        fi.FullDataType,
+		ExternalLanguage:    fi.ExternalLanguage,
+		ExternalName:        fi.ExternalName,
+		IsDeterministic:     fi.IsDeterministic,
+		IsNullCall:          fi.IsNullCall,
+		ParameterStyle:      catalog.CreateFunctionParameterStyle(fi.ParameterStyle),
+		Properties:          fi.Properties,
+		ReturnParams:        fi.ReturnParams,
+		RoutineBody:         catalog.CreateFunctionRoutineBody(fi.RoutineBody),
+		RoutineDefinition:   fi.RoutineDefinition,
+		RoutineDependencies: fi.RoutineDependencies,
+		SecurityType:        catalog.CreateFunctionSecurityType(fi.SecurityType),
+		SpecificName:        fi.SpecificName,
+		SqlDataAccess:       catalog.CreateFunctionSqlDataAccess(fi.SqlDataAccess),
+		SqlPath:             fi.SqlPath,
+	}
+	if fi.InputParams != nil {
+		cf.InputParams = *fi.InputParams
+	}
+	return cf
+}
+
+func ResourceFunction() common.Resource {
+	s := common.StructToSchema(catalog.FunctionInfo{},
+		func(m map[string]*schema.Schema) map[string]*schema.Schema {
+			for _, field := range []string{"name", "catalog_name", "schema_name"} {
+				common.CustomizeSchemaPath(m, field).SetRequired().SetCustomSuppressDiff(common.EqualFoldDiffSuppress)
+			}
+			for _, field := range []string{"data_type", "routine_body", "routine_definition"} {
+				common.CustomizeSchemaPath(m, field).SetRequired()
+			}
+			for _, field := range []string{"browse_only", "created_at", "created_by", "full_name",
+				"function_id", "metastore_id", "updated_at", "updated_by"} {
+				common.CustomizeSchemaPath(m, field).SetReadOnly()
+			}
+			for _, field := range []string{"full_data_type", "owner", "parameter_style", "security_type",
+				"specific_name", "sql_data_access", "sql_path", "properties"} {
+				common.CustomizeSchemaPath(m, field).SetComputed()
+			}
+			common.CustomizeSchemaPath(m, "is_deterministic").SetDefault(true)
+			customizeFunctionParametersSchema(m, "input_params")
+			customizeFunctionParametersSchema(m, "return_params")
+			setFunctionFieldsForceNew(m)
+			return m
+		})
+	return common.Resource{
+		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			w, err := c.WorkspaceClient()
+			if err != nil {
+				return err
+			}
+			var fi catalog.FunctionInfo
+			common.DataToStructPointer(d, s, &fi)
+			function, err := w.Functions.Create(ctx, catalog.CreateFunctionRequest{
+				FunctionInfo: newCreateFunction(fi),
+			})
+			if err != nil {
+				return err
+			}
+			d.SetId(function.FullName)
+			// Don't update owner if it is not provided
+			if fi.Owner == "" {
+				return nil
+			}
+			_, err = w.Functions.Update(ctx, catalog.UpdateFunction{
+				Name:  d.Id(),
+				Owner: fi.Owner,
+			})
+			return err
+		},
+		Read: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			w, err := c.WorkspaceClient()
+			if err != nil {
+				return err
+			}
+			function, err := w.Functions.GetByName(ctx, d.Id())
+			if err != nil {
+				return err
+			}
+			return common.StructToData(*function, s, d)
+		},
+		Update: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			w, err := c.WorkspaceClient()
+			if err != nil {
+				return err
+			}
+			_, err = w.Functions.Update(ctx, catalog.UpdateFunction{
+				Name:  d.Id(),
+				Owner: d.Get("owner").(string),
+			})
+			return err
+		},
+		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			w, err := c.WorkspaceClient()
+			if err != nil {
+				return err
+			}
+			return w.Functions.DeleteByName(ctx, d.Id())
+		},
+		Schema: s,
+	}
+}
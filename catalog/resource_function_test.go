@@ -0,0 +1,284 @@
+package catalog
+
+import (
+	"testing"
+
+	"github.com/databricks/databricks-sdk-go/service/catalog"
+	"github.com/databricks/terraform-provider-databricks/qa"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFunctionCornerCases(t *testing.T) {
+	qa.ResourceCornerCases(t, ResourceFunction())
+}
+
+var sqlFunctionInfo = catalog.FunctionInfo{
+	CatalogName:     "main",
+	SchemaName:      "default",
+	Name:            "add_one",
+	FullName:        "main.default.add_one",
+	DataType:        "INT",
+	FullDataType:    "int",
+	IsDeterministic: true,
+	InputParams: &catalog.FunctionParameterInfos{
+		Parameters: []catalog.FunctionParameterInfo{
+			{
+				Name:     "x",
+				TypeName: "INT",
+				TypeText: "int",
+				TypeJson: `{"name":"x","type":"integer","nullable":true,"metadata":{}}`,
+			},
+		},
+	},
+	ParameterStyle:    "S",
+	RoutineBody:       "SQL",
+	RoutineDefinition: "x + 1",
+	SecurityType:      "DEFINER",
+	SpecificName:      "add_one",
+	SqlDataAccess:     "CONTAINS_SQL",
+	Owner:             "me",
+}
+
+func TestResourceFunctionCreate(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.1/unity-catalog/functions",
+				ExpectedRequest: catalog.CreateFunctionRequest{
+					FunctionInfo: catalog.CreateFunction{
+						CatalogName:     "main",
+						SchemaName:      "default",
+						Name:            "add_one",
+						DataType:        "INT",
+						FullDataType:    "int",
+						IsDeterministic: true,
+						InputParams: catalog.FunctionParameterInfos{
+							Parameters: []catalog.FunctionParameterInfo{
+								{
+									Name:     "x",
+									Position: 0,
+									TypeName: "INT",
+									TypeText: "int",
+								},
+							},
+						},
+						ParameterStyle:    "S",
+						RoutineBody:       "SQL",
+						RoutineDefinition: "x + 1",
+						SecurityType:      "DEFINER",
+						SpecificName:      "add_one",
+						SqlDataAccess:     "CONTAINS_SQL",
+					},
+				},
+				Response: sqlFunctionInfo,
+			},
+			{
+				Method:       "GET",
+				Resource:     "/api/2.1/unity-catalog/functions/main.default.add_one?",
+				Response:     sqlFunctionInfo,
+				ReuseRequest: true,
+			},
+		},
+		Resource: ResourceFunction(),
+		Create:   true,
+		HCL: `
+		catalog_name = "main"
+		schema_name = "default"
+		name = "add_one"
+		data_type = "INT"
+		input_params {
+			parameters {
+				name = "x"
+				type_name = "INT"
+			}
+		}
+		routine_body = "SQL"
+		routine_definition = "x + 1"
+		`,
+	}.ApplyAndExpectData(t, map[string]any{
+		"id":                                    "main.default.add_one",
+		"full_data_type":                        "int",
+		"owner":                                 "me",
+		"is_deterministic":                      true,
+		"input_params.0.parameters.0.type_text": "int",
+		"input_params.0.parameters.0.position":  0,
+	})
+}
+
+func TestResourceFunctionCreatePythonWithOwner(t *testing.T) {
+	pythonFunctionInfo := catalog.FunctionInfo{
+		CatalogName:       "main",
+		SchemaName:        "default",
+		Name:              "greet",
+		FullName:          "main.default.greet",
+		DataType:          "STRING",
+		FullDataType:      "string",
+		ExternalLanguage:  "Python",
+		ParameterStyle:    "S",
+		RoutineBody:       "EXTERNAL",
+		RoutineDefinition: "return 'Hello, ' + name",
+		SecurityType:      "DEFINER",
+		SpecificName:      "greet",
+		SqlDataAccess:     "NO_SQL",
+		InputParams: &catalog.FunctionParameterInfos{
+			Parameters: []catalog.FunctionParameterInfo{
+				{
+					Name:     "name",
+					TypeName: "STRING",
+					TypeText: "string",
+				},
+			},
+		},
+		Owner: "data-engineers",
+	}
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.1/unity-catalog/functions",
+				ExpectedRequest: catalog.CreateFunctionRequest{
+					FunctionInfo: catalog.CreateFunction{
+						CatalogName:      "main",
+						SchemaName:       "default",
+						Name:             "greet",
+						DataType:         "STRING",
+						FullDataType:     "string",
+						ExternalLanguage: "Python",
+						InputParams: catalog.FunctionParameterInfos{
+							Parameters: []catalog.FunctionParameterInfo{
+								{
+									Name:     "name",
+									TypeName: "STRING",
+									TypeText: "string",
+								},
+							},
+						},
+						ParameterStyle:    "S",
+						RoutineBody:       "EXTERNAL",
+						RoutineDefinition: "return 'Hello, ' + name",
+						SecurityType:      "DEFINER",
+						SpecificName:      "greet",
+						SqlDataAccess:     "NO_SQL",
+					},
+				},
+				Response: pythonFunctionInfo,
+			},
+			{
+				Method:   "PATCH",
+				Resource: "/api/2.1/unity-catalog/functions/main.default.greet",
+				ExpectedRequest: catalog.UpdateFunction{
+					Owner: "data-engineers",
+				},
+				Response: pythonFunctionInfo,
+			},
+			{
+				Method:       "GET",
+				Resource:     "/api/2.1/unity-catalog/functions/main.default.greet?",
+				Response:     pythonFunctionInfo,
+				ReuseRequest: true,
+			},
+		},
+		Resource: ResourceFunction(),
+		Create:   true,
+		HCL: `
+		catalog_name = "main"
+		schema_name = "default"
+		name = "greet"
+		data_type = "STRING"
+		is_deterministic = false
+		external_language = "Python"
+		input_params {
+			parameters {
+				name = "name"
+				type_name = "STRING"
+			}
+		}
+		routine_body = "EXTERNAL"
+		routine_definition = "return 'Hello, ' + name"
+		owner = "data-engineers"
+		`,
+	}.ApplyAndExpectData(t, map[string]any{
+		"id":              "main.default.greet",
+		"sql_data_access": "NO_SQL",
+		"owner":           "data-engineers",
+	})
+}
+
+func TestResourceFunctionUpdateOwner(t *testing.T) {
+	updated := sqlFunctionInfo
+	updated.Owner = "someone-else"
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "PATCH",
+				Resource: "/api/2.1/unity-catalog/functions/main.default.add_one",
+				ExpectedRequest: catalog.UpdateFunction{
+					Owner: "someone-else",
+				},
+				Response: updated,
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/unity-catalog/functions/main.default.add_one?",
+				Response: updated,
+			},
+		},
+		Resource: ResourceFunction(),
+		Update:   true,
+		ID:       "main.default.add_one",
+		InstanceState: map[string]string{
+			"catalog_name":       "main",
+			"schema_name":        "default",
+			"name":               "add_one",
+			"data_type":          "INT",
+			"routine_body":       "SQL",
+			"routine_definition": "x + 1",
+			"is_deterministic":   "true",
+			"owner":              "me",
+		},
+		HCL: `
+		catalog_name = "main"
+		schema_name = "default"
+		name = "add_one"
+		data_type = "INT"
+		routine_body = "SQL"
+		routine_definition = "x + 1"
+		owner = "someone-else"
+		`,
+	}.ApplyAndExpectData(t, map[string]any{
+		"owner": "someone-else",
+	})
+}
+
+func TestResourceFunctionDelete(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "DELETE",
+				Resource: "/api/2.1/unity-catalog/functions/main.default.add_one?",
+			},
+		},
+		Resource: ResourceFunction(),
+		Delete:   true,
+		ID:       "main.default.add_one",
+	}.ApplyNoError(t)
+}
+
+func TestNewCreateFunctionTableFunction(t *testing.T) {
+	cf := newCreateFunction(catalog.FunctionInfo{
+		Name:     "top_customers",
+		DataType: catalog.ColumnTypeNameTableType,
+		ReturnParams: &catalog.FunctionParameterInfos{
+			Parameters: []catalog.FunctionParameterInfo{
+				{Name: "id", TypeName: "BIGINT"},
+				{Name: "total", TypeName: "DECIMAL", TypeText: "decimal(10,2)"},
+			},
+		},
+		RoutineBody: "SQL",
+	})
+	assert.Equal(t, "TABLE(id bigint, total decimal(10,2))", cf.FullDataType)
+	assert.Equal(t, 1, cf.ReturnParams.Parameters[1].Position)
+	assert.Equal(t, catalog.CreateFunctionSqlDataAccessContainsSql, cf.SqlDataAccess)
+	assert.Equal(t, "top_customers", cf.SpecificName)
+}
@@ -23,18 +23,67 @@ import (
 var MaxSqlExecWaitTimeout = 50
 
 type SqlColumnInfo struct {
-	Name     string         `json:"name"`
-	Type     string         `json:"type_text,omitempty" tf:"alias:type,computed"`
-	Identity IdentityColumn `json:"identity,omitempty"`
-	Comment  string         `json:"comment,omitempty"`
-	Nullable bool           `json:"nullable,omitempty" tf:"default:true"`
-	TypeJson string         `json:"type_json,omitempty" tf:"computed"`
+	Name                string         `json:"name"`
+	Type                string         `json:"type_text,omitempty" tf:"alias:type,computed"`
+	Identity            IdentityColumn `json:"identity,omitempty"`
+	Comment             string         `json:"comment,omitempty"`
+	Nullable            bool           `json:"nullable,omitempty" tf:"default:true"`
+	Default             string         `json:"default,omitempty" tf:"computed"`
+	GeneratedExpression string         `json:"generated_expression,omitempty" tf:"computed"`
+	Mask                *SqlColumnMask `json:"mask,omitempty"`
+	TypeJson            string         `json:"type_json,omitempty" tf:"computed"`
+}
+
+// SqlColumnMask is a SQL UDF, that replaces values of the column when it's queried
+type SqlColumnMask struct {
+	FunctionName     string   `json:"function_name"`
+	UsingColumnNames []string `json:"using_column_names,omitempty"`
+}
+
+// SqlRowFilter is a SQL UDF, that decides if a row of the table can be read by the current user
+type SqlRowFilter struct {
+	FunctionName     string   `json:"function_name"`
+	InputColumnNames []string `json:"input_column_names"`
 }
 
 type TypeJson struct {
 	Metadata map[string]any `json:"metadata,omitempty"`
 }
 
+// Delta keeps column defaults and generation expressions in the metadata of the column
+const columnDefaultMetadataKey = "CURRENT_DEFAULT"
+const columnGenerationExpressionMetadataKey = "delta.generationExpression"
+
+// Delta keeps CHECK constraints as table properties with this prefix
+const checkConstraintPropertyPrefix = "delta.constraints."
+
+const typeWideningProperty = "delta.enableTypeWidening"
+
+type SqlCheckConstraint struct {
+	Name       string `json:"name"`
+	Expression string `json:"expression"`
+}
+
+// SqlTableConstraint is an informational primary or foreign key constraint. Such constraints are not enforced,
+// but with RELY they can be used by the query optimizer.
+type SqlTableConstraint struct {
+	Name       string                   `json:"name"`
+	PrimaryKey *SqlPrimaryKeyConstraint `json:"primary_key,omitempty"`
+	ForeignKey *SqlForeignKeyConstraint `json:"foreign_key,omitempty"`
+}
+
+type SqlPrimaryKeyConstraint struct {
+	Columns []string `json:"columns"`
+	Rely    bool     `json:"rely,omitempty"`
+}
+
+type SqlForeignKeyConstraint struct {
+	Columns       []string `json:"columns"`
+	ParentTable   string   `json:"parent_table"`
+	ParentColumns []string `json:"parent_columns,omitempty" tf:"computed"`
+	Rely          bool     `json:"rely,omitempty"`
+}
+
 type IdentityColumn string
 
 const IdentityColumnNone IdentityColumn = ""
@@ -42,20 +91,23 @@ const IdentityColumnAlways IdentityColumn = "always"
 const IdentityColumnDefault IdentityColumn = "default"
 
 type SqlTableInfo struct {
-	Name                  string            `json:"name"`
-	CatalogName           string            `json:"catalog_name" tf:"force_new"`
-	SchemaName            string            `json:"schema_name" tf:"force_new"`
-	TableType             string            `json:"table_type" tf:"force_new"`
-	DataSourceFormat      string            `json:"data_source_format,omitempty" tf:"force_new"`
-	ColumnInfos           []SqlColumnInfo   `json:"columns,omitempty" tf:"alias:column,computed"`
-	Partitions            []string          `json:"partitions,omitempty" tf:"force_new"`
-	ClusterKeys           []string          `json:"cluster_keys,omitempty"`
-	StorageLocation       string            `json:"storage_location,omitempty" tf:"suppress_diff"`
-	StorageCredentialName string            `json:"storage_credential_name,omitempty" tf:"force_new"`
-	ViewDefinition        string            `json:"view_definition,omitempty"`
-	Comment               string            `json:"comment,omitempty"`
-	Properties            map[string]string `json:"properties,omitempty"`
-	Options               map[string]string `json:"options,omitempty" tf:"force_new"`
+	Name                  string               `json:"name"`
+	CatalogName           string               `json:"catalog_name" tf:"force_new"`
+	SchemaName            string               `json:"schema_name" tf:"force_new"`
+	TableType             string               `json:"table_type" tf:"force_new"`
+	DataSourceFormat      string               `json:"data_source_format,omitempty" tf:"force_new"`
+	ColumnInfos           []SqlColumnInfo      `json:"columns,omitempty" tf:"alias:column,computed"`
+	Partitions            []string             `json:"partitions,omitempty" tf:"force_new"`
+	ClusterKeys           []string             `json:"cluster_keys,omitempty"`
+	StorageLocation       string               `json:"storage_location,omitempty" tf:"suppress_diff"`
+	StorageCredentialName string               `json:"storage_credential_name,omitempty" tf:"force_new"`
+	ViewDefinition        string               `json:"view_definition,omitempty"`
+	Comment               string               `json:"comment,omitempty"`
+	Properties            map[string]string    `json:"properties,omitempty"`
+	Options               map[string]string    `json:"options,omitempty" tf:"force_new"`
+	CheckConstraints      []SqlCheckConstraint `json:"check_constraints,omitempty" tf:"alias:check_constraint"`
+	Constraints           []SqlTableConstraint `json:"constraints,omitempty" tf:"alias:constraint"`
+	RowFilter             *SqlRowFilter        `json:"row_filter,omitempty"`
 	// EffectiveProperties includes both properties and options. Options are prefixed with `option.`.
 	EffectiveProperties map[string]string `json:"effective_properties" tf:"computed"`
 	ClusterID           string            `json:"cluster_id,omitempty" tf:"computed"`
@@ -89,6 +141,14 @@ func (ti SqlTableInfo) CustomizeSchema(s *common.CustomizableSchema) *common.Cus
 	s.SchemaPath("column", "type").SetCustomSuppressDiff(func(k, old, new string, d *schema.ResourceData) bool {
 		return getColumnType(old) == getColumnType(new)
 	})
+	s.SchemaPath("column", "default").SetCustomSuppressDiff(common.SuppressDiffWhitespaceChange)
+	s.SchemaPath("column", "generated_expression").SetCustomSuppressDiff(common.SuppressDiffWhitespaceChange)
+	s.SchemaPath("check_constraint", "name").SetCustomSuppressDiff(common.EqualFoldDiffSuppress)
+	s.SchemaPath("check_constraint", "expression").SetCustomSuppressDiff(common.SuppressDiffWhitespaceChange)
+	s.SchemaPath("constraint", "name").SetCustomSuppressDiff(common.EqualFoldDiffSuppress)
+	s.SchemaPath("column", "mask", "function_name").SetCustomSuppressDiff(common.EqualFoldDiffSuppress)
+	s.SchemaPath("row_filter", "function_name").SetCustomSuppressDiff(common.EqualFoldDiffSuppress)
+	s.SchemaPath("constraint", "foreign_key", "parent_table").SetCustomSuppressDiff(common.EqualFoldDiffSuppress)
 	return s
 }
 
@@ -101,11 +161,58 @@ func NewSqlTablesAPI(ctx context.Context, m any) SqlTablesAPI {
 	return SqlTablesAPI{m.(*common.DatabricksClient), context.WithValue(ctx, common.Api, common.API_2_1)}
 }
 
+// sqlTableKeyConstraint is a primary or foreign key, as returned by the API. It's declared here,
+// as the RELY option is not part of the shape of the Go SDK yet.
+type sqlTableKeyConstraint struct {
+	Name          string   `json:"name"`
+	ChildColumns  []string `json:"child_columns"`
+	ParentTable   string   `json:"parent_table,omitempty"`
+	ParentColumns []string `json:"parent_columns,omitempty"`
+	Rely          bool     `json:"rely,omitempty"`
+}
+
+type sqlTableConstraint struct {
+	PrimaryKeyConstraint *sqlTableKeyConstraint `json:"primary_key_constraint,omitempty"`
+	ForeignKeyConstraint *sqlTableKeyConstraint `json:"foreign_key_constraint,omitempty"`
+}
+
+// sqlTableInfoWithConstraints is used to read primary and foreign keys, as the API returns them
+// in a different shape, than the one of the `constraint` blocks
+type sqlTableInfoWithConstraints struct {
+	SqlTableInfo
+	TableConstraints []sqlTableConstraint `json:"table_constraints,omitempty"`
+}
+
 func (a SqlTablesAPI) getTable(name string) (ti SqlTableInfo, err error) {
-	err = a.client.Get(a.context, "/unity-catalog/tables/"+name, nil, &ti)
+	var info sqlTableInfoWithConstraints
+	err = a.client.Get(a.context, "/unity-catalog/tables/"+name, nil, &info)
+	ti = info.SqlTableInfo
 	// Copy returned properties & options to read-only attributes
 	ti.EffectiveProperties = ti.Properties
 	ti.Properties = nil
+	ti.Constraints = nil
+	for _, tc := range info.TableConstraints {
+		if pk := tc.PrimaryKeyConstraint; pk != nil {
+			ti.Constraints = append(ti.Constraints, SqlTableConstraint{
+				Name: pk.Name,
+				PrimaryKey: &SqlPrimaryKeyConstraint{
+					Columns: pk.ChildColumns,
+					Rely:    pk.Rely,
+				},
+			})
+		}
+		if fk := tc.ForeignKeyConstraint; fk != nil {
+			ti.Constraints = append(ti.Constraints, SqlTableConstraint{
+				Name: fk.Name,
+				ForeignKey: &SqlForeignKeyConstraint{
+					Columns:       fk.ChildColumns,
+					ParentTable:   fk.ParentTable,
+					ParentColumns: fk.ParentColumns,
+					Rely:          fk.Rely,
+				},
+			})
+		}
+	}
 	return
 }
 
@@ -143,6 +250,111 @@ func reconstructIdentity(c *SqlColumnInfo) (IdentityColumn, error) {
 	return IdentityColumnAlways, nil
 }
 
+// reconstructColumnExpressions restores default value and generation expression of the column from its metadata
+func reconstructColumnExpressions(c *SqlColumnInfo) error {
+	if c.TypeJson == "" {
+		return nil
+	}
+	var typeJson TypeJson
+	err := json.Unmarshal([]byte(c.TypeJson), &typeJson)
+	if err != nil {
+		return err
+	}
+	if v, ok := typeJson.Metadata[columnDefaultMetadataKey].(string); ok {
+		c.Default = v
+	}
+	if v, ok := typeJson.Metadata[columnGenerationExpressionMetadataKey].(string); ok {
+		c.GeneratedExpression = v
+	}
+	return nil
+}
+
+// reconstructCheckConstraints restores CHECK constraints from the effective table properties
+func (ti *SqlTableInfo) reconstructCheckConstraints() {
+	ti.CheckConstraints = nil
+	for k, v := range ti.EffectiveProperties {
+		if !strings.HasPrefix(k, checkConstraintPropertyPrefix) {
+			continue
+		}
+		ti.CheckConstraints = append(ti.CheckConstraints, SqlCheckConstraint{
+			Name:       strings.TrimPrefix(k, checkConstraintPropertyPrefix),
+			Expression: v,
+		})
+	}
+	// so that the order of constraints is stable between reads
+	slices.SortFunc(ti.CheckConstraints, func(a, b SqlCheckConstraint) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+}
+
+// blockNames returns the lower-cased names of the given blocks of the resource state
+func blockNames(raw any) map[string]bool {
+	names := make(map[string]bool)
+	blocks, _ := raw.([]any)
+	for _, v := range blocks {
+		if m, ok := v.(map[string]any); ok {
+			names[strings.ToLower(m["name"].(string))] = true
+		}
+	}
+	return names
+}
+
+// maskedColumns returns the names of the columns with a mask in the given columns of the resource state
+func maskedColumns(raw any) map[string]bool {
+	names := make(map[string]bool)
+	columns, _ := raw.([]any)
+	for _, v := range columns {
+		m, ok := v.(map[string]any)
+		if !ok {
+			continue
+		}
+		if mask, ok := m["mask"].([]any); ok && len(mask) > 0 {
+			names[m["name"].(string)] = true
+		}
+	}
+	return names
+}
+
+// keepManaged removes the CHECK constraints, primary and foreign keys, row filter and column masks, that
+// are not in the given state of the resource, as they were added outside of Terraform and must be neither
+// tracked nor dropped
+func (ti *SqlTableInfo) keepManaged(state func(key string) any) {
+	if rowFilter, _ := state("row_filter").([]any); len(rowFilter) == 0 {
+		ti.RowFilter = nil
+	}
+	masked := maskedColumns(state("column"))
+	for i := range ti.ColumnInfos {
+		if !masked[ti.ColumnInfos[i].Name] {
+			ti.ColumnInfos[i].Mask = nil
+		}
+	}
+	checkConstraints := blockNames(state("check_constraint"))
+	ti.CheckConstraints = slices.DeleteFunc(ti.CheckConstraints, func(cc SqlCheckConstraint) bool {
+		return !checkConstraints[strings.ToLower(cc.Name)]
+	})
+	constraints := blockNames(state("constraint"))
+	ti.Constraints = slices.DeleteFunc(ti.Constraints, func(tc SqlTableConstraint) bool {
+		return !constraints[strings.ToLower(tc.Name)]
+	})
+}
+
+// reconstruct restores the attributes, that are not returned by the API as-is
+func (ti *SqlTableInfo) reconstruct() (err error) {
+	for i := range ti.ColumnInfos {
+		c := &ti.ColumnInfos[i]
+		c.Identity, err = reconstructIdentity(c)
+		if err != nil {
+			return err
+		}
+		err = reconstructColumnExpressions(c)
+		if err != nil {
+			return err
+		}
+	}
+	ti.reconstructCheckConstraints()
+	return nil
+}
+
 func (ti *SqlTableInfo) initCluster(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) (err error) {
 	defaultClusterName := "terraform-sql-table"
 	clustersAPI := clusters.NewClustersAPI(ctx, c)
@@ -227,11 +439,53 @@ func (ti *SqlTableInfo) serializeColumnInfo(col SqlColumnInfo) string {
 		notNull = " NOT NULL"
 	}
 
+	expression := ""
+	if col.GeneratedExpression != "" {
+		expression = fmt.Sprintf(" GENERATED ALWAYS AS (%s)", col.GeneratedExpression)
+	} else if col.Default != "" {
+		expression = fmt.Sprintf(" DEFAULT %s", col.Default)
+	}
+
 	comment := ""
 	if col.Comment != "" {
 		comment = fmt.Sprintf(" COMMENT '%s'", parseComment(col.Comment))
 	}
-	return fmt.Sprintf("%s %s%s%s", col.getWrappedColumnName(), colType, notNull, comment) // id INT NOT NULL COMMENT 'something'
+
+	mask := ""
+	if col.Mask != nil {
+		mask = " " + col.Mask.serialize()
+	}
+	return fmt.Sprintf("%s %s%s%s%s%s", col.getWrappedColumnName(), colType, notNull, expression, comment, mask) // id INT NOT NULL DEFAULT 0 COMMENT 'something' MASK `main`.`default`.`mask_id`
+}
+
+func (m SqlColumnMask) serialize() string {
+	statement := fmt.Sprintf("MASK %s", wrapFullName(m.FunctionName))
+	if len(m.UsingColumnNames) > 0 {
+		statement += fmt.Sprintf(" USING COLUMNS (%s)", wrapColumnNames(m.UsingColumnNames))
+	}
+	return statement // MASK `main`.`default`.`mask_ssn` USING COLUMNS (`country`)
+}
+
+func (m *SqlColumnMask) sameMask(other *SqlColumnMask) bool {
+	if m == nil || other == nil {
+		return m == other
+	}
+	return strings.EqualFold(m.FunctionName, other.FunctionName) && equalFoldSlices(m.UsingColumnNames, other.UsingColumnNames)
+}
+
+func (rf SqlRowFilter) serialize() string {
+	columns := ""
+	if len(rf.InputColumnNames) > 0 {
+		columns = wrapColumnNames(rf.InputColumnNames)
+	}
+	return fmt.Sprintf("ROW FILTER %s ON (%s)", wrapFullName(rf.FunctionName), columns) // ROW FILTER `main`.`default`.`us_only` ON (`region`)
+}
+
+func (rf *SqlRowFilter) sameRowFilter(other *SqlRowFilter) bool {
+	if rf == nil || other == nil {
+		return rf == other
+	}
+	return strings.EqualFold(rf.FunctionName, other.FunctionName) && equalFoldSlices(rf.InputColumnNames, other.InputColumnNames)
 }
 
 func (ti *SqlTableInfo) serializeColumnInfos() string {
@@ -239,7 +493,62 @@ func (ti *SqlTableInfo) serializeColumnInfos() string {
 	for i, col := range ti.ColumnInfos {
 		columnFragments[i] = ti.serializeColumnInfo(col)
 	}
-	return strings.Join(columnFragments[:], ", ") // id INT NOT NULL, name STRING, age INT
+	for _, tc := range ti.Constraints {
+		columnFragments = append(columnFragments, tc.serialize())
+	}
+	return strings.Join(columnFragments[:], ", ") // id INT NOT NULL, name STRING, age INT, CONSTRAINT `pk` PRIMARY KEY (`id`)
+}
+
+func wrapColumnNames(columns []string) string {
+	return "`" + strings.Join(columns, "`, `") + "`"
+}
+
+// Wrapping each part of the full name with backticks to avoid special character messing things up.
+func wrapFullName(name string) string {
+	return "`" + strings.Join(strings.Split(name, "."), "`.`") + "`"
+}
+
+func (tc SqlTableConstraint) serialize() string {
+	var statement, rely string
+	if tc.PrimaryKey != nil {
+		statement = fmt.Sprintf("PRIMARY KEY (%s)", wrapColumnNames(tc.PrimaryKey.Columns))
+		if tc.PrimaryKey.Rely {
+			rely = " RELY"
+		}
+	}
+	if tc.ForeignKey != nil {
+		statement = fmt.Sprintf("FOREIGN KEY (%s) REFERENCES %s", wrapColumnNames(tc.ForeignKey.Columns),
+			wrapFullName(tc.ForeignKey.ParentTable))
+		if len(tc.ForeignKey.ParentColumns) > 0 {
+			statement += fmt.Sprintf(" (%s)", wrapColumnNames(tc.ForeignKey.ParentColumns))
+		}
+		if tc.ForeignKey.Rely {
+			rely = " RELY"
+		}
+	}
+	return fmt.Sprintf("CONSTRAINT `%s` %s%s", tc.Name, statement, rely) // CONSTRAINT `pk` PRIMARY KEY (`id`) RELY
+}
+
+func equalFoldSlices(a, b []string) bool {
+	return slices.EqualFunc(a, b, strings.EqualFold)
+}
+
+// sameConstraint compares constraints, ignoring the parent columns of foreign keys if they aren't specified
+func (tc SqlTableConstraint) sameConstraint(other SqlTableConstraint) bool {
+	if (tc.PrimaryKey == nil) != (other.PrimaryKey == nil) || (tc.ForeignKey == nil) != (other.ForeignKey == nil) {
+		return false
+	}
+	if tc.PrimaryKey != nil {
+		return equalFoldSlices(tc.PrimaryKey.Columns, other.PrimaryKey.Columns) &&
+			tc.PrimaryKey.Rely == other.PrimaryKey.Rely
+	}
+	if tc.ForeignKey != nil {
+		return equalFoldSlices(tc.ForeignKey.Columns, other.ForeignKey.Columns) &&
+			strings.EqualFold(tc.ForeignKey.ParentTable, other.ForeignKey.ParentTable) &&
+			(len(tc.ForeignKey.ParentColumns) == 0 || equalFoldSlices(tc.ForeignKey.ParentColumns, other.ForeignKey.ParentColumns)) &&
+			tc.ForeignKey.Rely == other.ForeignKey.Rely
+	}
+	return true
 }
 
 func (ti *SqlTableInfo) serializeProperties() string {
@@ -315,6 +624,10 @@ func (ti *SqlTableInfo) buildTableCreateStatement() string {
 		statements = append(statements, fmt.Sprintf("\nTBLPROPERTIES (%s)", ti.serializeProperties())) // TBLPROPERTIES ('foo'='bar')
 	}
 
+	if !isView && ti.RowFilter != nil {
+		statements = append(statements, fmt.Sprintf("\nWITH %s", ti.RowFilter.serialize())) // WITH ROW FILTER `main`.`default`.`us_only` ON (`region`)
+	}
+
 	if len(ti.Options) > 0 {
 		statements = append(statements, fmt.Sprintf("\nOPTIONS (%s)", ti.serializeOptions())) // OPTIONS ('foo'='bar')
 	}
@@ -345,12 +658,31 @@ func (ti *SqlTableInfo) getWrappedClusterKeys() string {
 func (ti *SqlTableInfo) getStatementsForColumnDiffs(oldti *SqlTableInfo, statements []string, typestring string) []string {
 	if len(ti.ColumnInfos) != len(oldti.ColumnInfos) {
 		statements = ti.addOrRemoveColumnStatements(oldti, statements, typestring)
+	} else if sameColumnNames(oldti.ColumnInfos, ti.ColumnInfos) {
+		statements = ti.alterReorderedColumnStatements(oldti, statements, typestring)
 	} else {
 		statements = ti.alterExistingColumnStatements(oldti, statements, typestring)
 	}
 	return statements
 }
 
+// sameColumnNames returns true if both lists have the same column names, regardless of their order
+func sameColumnNames(oldCols []SqlColumnInfo, newCols []SqlColumnInfo) bool {
+	if len(oldCols) != len(newCols) {
+		return false
+	}
+	names := map[string]bool{}
+	for _, ci := range oldCols {
+		names[ci.Name] = true
+	}
+	for _, ci := range newCols {
+		if !names[ci.Name] {
+			return false
+		}
+	}
+	return true
+}
+
 func (ti *SqlTableInfo) addOrRemoveColumnStatements(oldti *SqlTableInfo, statements []string, typestring string) []string {
 	nameToOldColumn := make(map[string]SqlColumnInfo)
 	nameToNewColumn := make(map[string]SqlColumnInfo)
@@ -397,17 +729,139 @@ func (ti *SqlTableInfo) alterExistingColumnStatements(oldti *SqlTableInfo, state
 		if ci.Name != oldCi.Name {
 			statements = append(statements, fmt.Sprintf("ALTER %s %s RENAME COLUMN %s to %s", typestring, ti.SQLFullName(), oldCi.getWrappedColumnName(), ci.getWrappedColumnName()))
 		}
-		if ci.Comment != oldCi.Comment {
-			statements = append(statements, fmt.Sprintf("ALTER %s %s ALTER COLUMN %s COMMENT '%s'", typestring, ti.SQLFullName(), ci.getWrappedColumnName(), parseComment(ci.Comment)))
+		statements = ti.alterColumnStatements(oldCi, ci, statements, typestring)
+	}
+	return statements
+}
+
+// alterReorderedColumnStatements matches columns by name and moves the ones, that changed their position
+func (ti *SqlTableInfo) alterReorderedColumnStatements(oldti *SqlTableInfo, statements []string, typestring string) []string {
+	nameToOldColumn := make(map[string]SqlColumnInfo)
+	order := make([]string, 0, len(oldti.ColumnInfos))
+	for _, ci := range oldti.ColumnInfos {
+		nameToOldColumn[ci.Name] = ci
+		order = append(order, ci.Name)
+	}
+	for _, ci := range ti.ColumnInfos {
+		statements = ti.alterColumnStatements(nameToOldColumn[ci.Name], ci, statements, typestring)
+	}
+	for i, ci := range ti.ColumnInfos {
+		if order[i] == ci.Name {
+			continue
 		}
-		if ci.Nullable != oldCi.Nullable {
-			var keyWord string
-			if ci.Nullable {
-				keyWord = "DROP"
-			} else {
-				keyWord = "SET"
+		if i == 0 {
+			statements = append(statements, fmt.Sprintf("ALTER %s %s ALTER COLUMN %s FIRST", typestring, ti.SQLFullName(), ci.getWrappedColumnName()))
+		} else {
+			statements = append(statements, fmt.Sprintf("ALTER %s %s ALTER COLUMN %s AFTER %s", typestring, ti.SQLFullName(), ci.getWrappedColumnName(), ti.ColumnInfos[i-1].getWrappedColumnName()))
+		}
+		// keep track of the current order of columns, so that only displaced columns are moved
+		order = slices.Delete(order, slices.Index(order, ci.Name), slices.Index(order, ci.Name)+1)
+		order = slices.Insert(order, i, ci.Name)
+	}
+	return statements
+}
+
+func (ti *SqlTableInfo) alterColumnStatements(oldCi SqlColumnInfo, ci SqlColumnInfo, statements []string, typestring string) []string {
+	if ci.Type != "" && getColumnType(ci.Type) != getColumnType(oldCi.Type) {
+		statements = append(statements, fmt.Sprintf("ALTER %s %s ALTER COLUMN %s TYPE %s", typestring, ti.SQLFullName(), ci.getWrappedColumnName(), ci.Type))
+	}
+	if ci.Comment != oldCi.Comment {
+		statements = append(statements, fmt.Sprintf("ALTER %s %s ALTER COLUMN %s COMMENT '%s'", typestring, ti.SQLFullName(), ci.getWrappedColumnName(), parseComment(ci.Comment)))
+	}
+	if ci.Nullable != oldCi.Nullable {
+		var keyWord string
+		if ci.Nullable {
+			keyWord = "DROP"
+		} else {
+			keyWord = "SET"
+		}
+		statements = append(statements, fmt.Sprintf("ALTER %s %s ALTER COLUMN %s %s NOT NULL", typestring, ti.SQLFullName(), ci.getWrappedColumnName(), keyWord))
+	}
+	if !ci.Mask.sameMask(oldCi.Mask) {
+		if ci.Mask == nil {
+			statements = append(statements, fmt.Sprintf("ALTER %s %s ALTER COLUMN %s DROP MASK", typestring, ti.SQLFullName(), ci.getWrappedColumnName()))
+		} else {
+			statements = append(statements, fmt.Sprintf("ALTER %s %s ALTER COLUMN %s SET %s", typestring, ti.SQLFullName(), ci.getWrappedColumnName(), ci.Mask.serialize()))
+		}
+	}
+	if !sameExpression(ci.Default, oldCi.Default) {
+		if ci.Default == "" {
+			statements = append(statements, fmt.Sprintf("ALTER %s %s ALTER COLUMN %s DROP DEFAULT", typestring, ti.SQLFullName(), ci.getWrappedColumnName()))
+		} else {
+			statements = append(statements, fmt.Sprintf("ALTER %s %s ALTER COLUMN %s SET DEFAULT %s", typestring, ti.SQLFullName(), ci.getWrappedColumnName(), ci.Default))
+		}
+	}
+	return statements
+}
+
+// sameExpression compares SQL expressions ignoring the differences in whitespace
+func sameExpression(a, b string) bool {
+	return strings.Join(strings.Fields(a), " ") == strings.Join(strings.Fields(b), " ")
+}
+
+func (ti *SqlTableInfo) addCheckConstraintStatement(cc SqlCheckConstraint) string {
+	return fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT `%s` CHECK (%s)", ti.SQLFullName(), cc.Name, cc.Expression)
+}
+
+func (ti *SqlTableInfo) getStatementsForConstraintDiffs(oldti *SqlTableInfo, statements []string) []string {
+	nameToOldConstraint := make(map[string]SqlTableConstraint)
+	nameToNewConstraint := make(map[string]SqlTableConstraint)
+	for _, tc := range oldti.Constraints {
+		nameToOldConstraint[strings.ToLower(tc.Name)] = tc
+	}
+	for _, tc := range ti.Constraints {
+		nameToNewConstraint[strings.ToLower(tc.Name)] = tc
+	}
+	// foreign keys are dropped before and added after primary keys, as they may reference them
+	drop := func(isForeignKey bool) {
+		for _, oldTc := range oldti.Constraints {
+			if (oldTc.ForeignKey != nil) != isForeignKey {
+				continue
+			}
+			newTc, exists := nameToNewConstraint[strings.ToLower(oldTc.Name)]
+			if !exists || !newTc.sameConstraint(oldTc) {
+				statements = append(statements, fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT IF EXISTS `%s`", ti.SQLFullName(), oldTc.Name))
+			}
+		}
+	}
+	add := func(isForeignKey bool) {
+		for _, newTc := range ti.Constraints {
+			if (newTc.ForeignKey != nil) != isForeignKey {
+				continue
+			}
+			oldTc, exists := nameToOldConstraint[strings.ToLower(newTc.Name)]
+			if !exists || !newTc.sameConstraint(oldTc) {
+				statements = append(statements, fmt.Sprintf("ALTER TABLE %s ADD %s", ti.SQLFullName(), newTc.serialize()))
 			}
-			statements = append(statements, fmt.Sprintf("ALTER %s %s ALTER COLUMN %s %s NOT NULL", typestring, ti.SQLFullName(), ci.getWrappedColumnName(), keyWord))
+		}
+	}
+	drop(true)
+	drop(false)
+	add(false)
+	add(true)
+	return statements
+}
+
+func (ti *SqlTableInfo) getStatementsForCheckConstraintDiffs(oldti *SqlTableInfo, statements []string) []string {
+	nameToOldConstraint := make(map[string]SqlCheckConstraint)
+	nameToNewConstraint := make(map[string]SqlCheckConstraint)
+	for _, cc := range oldti.CheckConstraints {
+		nameToOldConstraint[strings.ToLower(cc.Name)] = cc
+	}
+	for _, cc := range ti.CheckConstraints {
+		nameToNewConstraint[strings.ToLower(cc.Name)] = cc
+	}
+	// constraints can't be altered, so changed ones are dropped and added again
+	for _, oldCc := range oldti.CheckConstraints {
+		newCc, exists := nameToNewConstraint[strings.ToLower(oldCc.Name)]
+		if !exists || !sameExpression(newCc.Expression, oldCc.Expression) {
+			statements = append(statements, fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT IF EXISTS `%s`", ti.SQLFullName(), oldCc.Name))
+		}
+	}
+	for _, newCc := range ti.CheckConstraints {
+		oldCc, exists := nameToOldConstraint[strings.ToLower(newCc.Name)]
+		if !exists || !sameExpression(newCc.Expression, oldCc.Expression) {
+			statements = append(statements, ti.addCheckConstraintStatement(newCc))
 		}
 	}
 	return statements
@@ -455,6 +909,19 @@ func (ti *SqlTableInfo) diff(oldti *SqlTableInfo) ([]string, error) {
 
 	statements = ti.getStatementsForColumnDiffs(oldti, statements, typestring)
 
+	if ti.TableType != "VIEW" && !ti.RowFilter.sameRowFilter(oldti.RowFilter) {
+		if ti.RowFilter == nil {
+			statements = append(statements, fmt.Sprintf("ALTER TABLE %s DROP ROW FILTER", ti.SQLFullName()))
+		} else {
+			statements = append(statements, fmt.Sprintf("ALTER TABLE %s SET %s", ti.SQLFullName(), ti.RowFilter.serialize()))
+		}
+	}
+
+	if ti.TableType != "VIEW" {
+		statements = ti.getStatementsForConstraintDiffs(oldti, statements)
+		statements = ti.getStatementsForCheckConstraintDiffs(oldti, statements)
+	}
+
 	return statements, nil
 }
 
@@ -473,7 +940,27 @@ func (ti *SqlTableInfo) updateTable(oldti *SqlTableInfo) error {
 }
 
 func (ti *SqlTableInfo) createTable() error {
-	return ti.applySql(ti.buildTableCreateStatement())
+	err := ti.applySql(ti.buildTableCreateStatement())
+	if err != nil {
+		return err
+	}
+	if len(ti.ColumnInfos) == 0 {
+		// without columns, primary and foreign keys are not part of the CREATE statement
+		for _, tc := range ti.Constraints {
+			err = ti.applySql(fmt.Sprintf("ALTER TABLE %s ADD %s", ti.SQLFullName(), tc.serialize()))
+			if err != nil {
+				return err
+			}
+		}
+	}
+	// CHECK constraints can only be added to an existing table
+	for _, cc := range ti.CheckConstraints {
+		err = ti.applySql(ti.addCheckConstraintStatement(cc))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (ti *SqlTableInfo) deleteTable() error {
@@ -514,7 +1001,7 @@ func columnChangesCustomizeDiff(d *schema.ResourceDiff, newTable *SqlTableInfo)
 	newColumnInfos := newTable.ColumnInfos
 
 	if len(oldCols) == len(newColumnInfos) {
-		err := assertNoColumnTypeDiff(oldCols, newColumnInfos)
+		err := assertAllowedColumnTypeDiff(oldCols, newTable)
 		if err != nil {
 			return err
 		}
@@ -546,15 +1033,85 @@ func getColumnType(columnType string) string {
 	return caseInsensitiveColumnType
 }
 
-func assertNoColumnTypeDiff(oldCols []interface{}, newColumnInfos []SqlColumnInfo) error {
-	for i, oldCol := range oldCols {
+var integralTypeDigits = map[string]int{
+	"tinyint":  3,
+	"smallint": 5,
+	"int":      10,
+	"bigint":   20,
+}
+
+func parseDecimalType(columnType string) (precision, scale int, ok bool) {
+	_, err := fmt.Sscanf(strings.ReplaceAll(columnType, " ", ""), "decimal(%d,%d)", &precision, &scale)
+	return precision, scale, err == nil
+}
+
+// isTypeWidening checks if the type change is supported by Delta type widening, see
+// https://docs.databricks.com/en/delta/type-widening.html
+func isTypeWidening(from, to string) bool {
+	from, to = getColumnType(from), getColumnType(to)
+	fromDigits, fromIntegral := integralTypeDigits[from]
+	if toDigits, ok := integralTypeDigits[to]; ok {
+		return fromIntegral && fromDigits < toDigits
+	}
+	if to == "double" {
+		return from == "float" || (fromIntegral && from != "bigint")
+	}
+	if to == "timestamp_ntz" {
+		return from == "date"
+	}
+	toPrecision, toScale, ok := parseDecimalType(to)
+	if !ok {
+		return false
+	}
+	if fromIntegral {
+		return toPrecision-toScale >= fromDigits
+	}
+	fromPrecision, fromScale, ok := parseDecimalType(from)
+	if !ok {
+		return false
+	}
+	return toPrecision >= fromPrecision && toScale >= fromScale && toPrecision-toScale >= fromPrecision-fromScale
+}
+
+func (ti *SqlTableInfo) typeWideningEnabled() bool {
+	return strings.EqualFold(ti.Properties[typeWideningProperty], "true") ||
+		strings.EqualFold(ti.EffectiveProperties[typeWideningProperty], "true")
+}
+
+// assertAllowedColumnTypeDiff matches columns by name, if they were reordered, or by position otherwise,
+// and checks that the type changes are supported by Delta type widening.
+func assertAllowedColumnTypeDiff(oldCols []interface{}, newTable *SqlTableInfo) error {
+	newColumnInfos := newTable.ColumnInfos
+	nameToOldCol := make(map[string]map[string]interface{})
+	oldColumnInfos := make([]SqlColumnInfo, 0, len(oldCols))
+	for _, oldCol := range oldCols {
 		oldColMap := oldCol.(map[string]interface{})
-		if getColumnType(oldColMap["type"].(string)) != getColumnType(newColumnInfos[i].Type) {
-			return fmt.Errorf("changing the 'type' of an existing column is not supported")
+		nameToOldCol[oldColMap["name"].(string)] = oldColMap
+		oldColumnInfos = append(oldColumnInfos, SqlColumnInfo{Name: oldColMap["name"].(string)})
+	}
+	reordered := sameColumnNames(oldColumnInfos, newColumnInfos)
+	for i, newCol := range newColumnInfos {
+		oldColMap := oldCols[i].(map[string]interface{})
+		if reordered {
+			oldColMap = nameToOldCol[newCol.Name]
+		}
+		oldType := oldColMap["type"].(string)
+		if getColumnType(oldType) != getColumnType(newCol.Type) {
+			if newTable.TableType == "VIEW" || !isTypeWidening(oldType, newCol.Type) {
+				return fmt.Errorf("changing the 'type' of column '%s' from %s to %s is not supported, "+
+					"only type widening of tables is allowed", newCol.Name, oldType, newCol.Type)
+			}
+			if !newTable.typeWideningEnabled() {
+				return fmt.Errorf("changing the 'type' of column '%s' from %s to %s requires '%s' table property to be set to 'true'",
+					newCol.Name, oldType, newCol.Type, typeWideningProperty)
+			}
 		}
-		if oldColMap["identity"].(string) != string(newColumnInfos[i].Identity) {
+		if oldColMap["identity"].(string) != string(newCol.Identity) {
 			return fmt.Errorf("changing the 'identity' type of an existing column is not supported")
 		}
+		if !sameExpression(oldColMap["generated_expression"].(string), newCol.GeneratedExpression) {
+			return fmt.Errorf("changing the 'generated_expression' of an existing column is not supported")
+		}
 	}
 	return nil
 }
@@ -585,6 +1142,15 @@ func ResourceSqlTable() common.Resource {
 	return common.Resource{
 		Schema: tableSchema,
 		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff) error {
+			if d.HasChange("constraint") {
+				var newTableStruct SqlTableInfo
+				common.DiffToStructPointer(d, tableSchema, &newTableStruct)
+				for _, tc := range newTableStruct.Constraints {
+					if (tc.PrimaryKey == nil) == (tc.ForeignKey == nil) {
+						return fmt.Errorf("constraint '%s' must have exactly one of primary_key or foreign_key", tc.Name)
+					}
+				}
+			}
 			if d.HasChange("column") {
 				var newTableStruct SqlTableInfo
 				common.DiffToStructPointer(d, tableSchema, &newTableStruct)
@@ -655,13 +1221,11 @@ func ResourceSqlTable() common.Resource {
 			if err != nil {
 				return err
 			}
-			for i := range ti.ColumnInfos {
-				c := &ti.ColumnInfos[i]
-				c.Identity, err = reconstructIdentity(c)
-				if err != nil {
-					return err
-				}
+			err = ti.reconstruct()
+			if err != nil {
+				return err
 			}
+			ti.keepManaged(d.Get)
 			return common.StructToData(ti, tableSchema, d)
 		},
 		Update: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
@@ -678,6 +1242,16 @@ func ResourceSqlTable() common.Resource {
 			if err != nil {
 				return err
 			}
+			err = oldti.reconstruct()
+			if err != nil {
+				return err
+			}
+			// existing constraints, filters and masks, that were added to the configuration, are taken over
+			// without dropping them
+			oldti.keepManaged(func(key string) any {
+				old, new := d.GetChange(key)
+				return append(old.([]any), new.([]any)...)
+			})
 			err = newti.updateTable(&oldti)
 			if err != nil {
 				return err
@@ -906,6 +906,12 @@ func getColumnsInstanceState(columns []SqlColumnInfo) map[string]string {
 		res[typeKey] = ci.Type
 		res[commentKey] = ci.Comment
 		res[nullableKey] = strconv.FormatBool(ci.Nullable)
+		if ci.Default != "" {
+			res[fmt.Sprintf("column.%d.default", i)] = ci.Default
+		}
+		if ci.GeneratedExpression != "" {
+			res[fmt.Sprintf("column.%d.generated_expression", i)] = ci.GeneratedExpression
+		}
 	}
 	return res
 }
@@ -913,12 +919,20 @@ func getColumnsInstanceState(columns []SqlColumnInfo) map[string]string {
 type resourceSqlTableUpdateColumnTestMetaData struct {
 	oldColumns       []SqlColumnInfo
 	newColumns       []SqlColumnInfo
+	properties       map[string]string
 	allowedCommands  []string
 	expectedErrorMsg string
 }
 
 func resourceSqlTableUpdateColumnHelper(t *testing.T, testMetaData resourceSqlTableUpdateColumnTestMetaData) {
 	newColumnsTemplate := GetSqlColumnInfoHCL(testMetaData.newColumns)
+	if len(testMetaData.properties) > 0 {
+		newColumnsTemplate += "\n\t\tproperties = {"
+		for k, v := range testMetaData.properties {
+			newColumnsTemplate += fmt.Sprintf("\n\t\t\t%q = %q", k, v)
+		}
+		newColumnsTemplate += "\n\t\t}\n"
+	}
 	instanceStateMap := map[string]string{
 		"name":               "bar",
 		"catalog_name":       "main",
@@ -1050,7 +1064,7 @@ func TestResourceSqlTableUpdateTable_ColumnsTypeThrowsError(t *testing.T) {
 				},
 			},
 			allowedCommands:  []string{},
-			expectedErrorMsg: "changing the 'type' of an existing column is not supported",
+			expectedErrorMsg: "changing the 'type' of column 'one' from string to int is not supported, only type widening of tables is allowed",
 		},
 	)
 }
@@ -1294,6 +1308,869 @@ func TestResourceSqlTableUpdateTable_DropMultipleColumns(t *testing.T) {
 	)
 }
 
+func TestResourceSqlTableUpdateTable_ColumnsTypeWidening(t *testing.T) {
+	resourceSqlTableUpdateColumnHelper(t,
+		resourceSqlTableUpdateColumnTestMetaData{
+			oldColumns: []SqlColumnInfo{
+				{
+					Name:     "one",
+					Type:     "int",
+					Nullable: true,
+				},
+				{
+					Name:     "two",
+					Type:     "decimal(10,2)",
+					Nullable: true,
+				},
+			},
+			newColumns: []SqlColumnInfo{
+				{
+					Name:     "one",
+					Type:     "bigint",
+					Nullable: true,
+				},
+				{
+					Name:     "two",
+					Type:     "decimal(12,4)",
+					Nullable: true,
+				},
+			},
+			properties: map[string]string{
+				"delta.enableTypeWidening": "true",
+			},
+			allowedCommands: []string{
+				"ALTER TABLE `main`.`foo`.`bar` SET TBLPROPERTIES ('delta.enableTypeWidening'='true')",
+				"ALTER TABLE `main`.`foo`.`bar` ALTER COLUMN `one` TYPE bigint",
+				"ALTER TABLE `main`.`foo`.`bar` ALTER COLUMN `two` TYPE decimal(12,4)",
+			},
+			expectedErrorMsg: "",
+		},
+	)
+}
+
+func TestResourceSqlTableUpdateTable_ColumnsTypeWideningNotEnabledThrowsError(t *testing.T) {
+	resourceSqlTableUpdateColumnHelper(t,
+		resourceSqlTableUpdateColumnTestMetaData{
+			oldColumns: []SqlColumnInfo{
+				{
+					Name:     "one",
+					Type:     "int",
+					Nullable: true,
+				},
+			},
+			newColumns: []SqlColumnInfo{
+				{
+					Name:     "one",
+					Type:     "bigint",
+					Nullable: true,
+				},
+			},
+			allowedCommands:  []string{},
+			expectedErrorMsg: "changing the 'type' of column 'one' from int to bigint requires 'delta.enableTypeWidening' table property to be set to 'true'",
+		},
+	)
+}
+
+func TestResourceSqlTableUpdateTable_ReorderColumns(t *testing.T) {
+	resourceSqlTableUpdateColumnHelper(t,
+		resourceSqlTableUpdateColumnTestMetaData{
+			oldColumns: []SqlColumnInfo{
+				{
+					Name:     "one",
+					Type:     "string",
+					Nullable: true,
+				},
+				{
+					Name:     "two",
+					Type:     "int",
+					Nullable: true,
+				},
+				{
+					Name:     "three",
+					Type:     "string",
+					Nullable: true,
+				},
+			},
+			newColumns: []SqlColumnInfo{
+				{
+					Name:     "three",
+					Type:     "string",
+					Comment:  "moved first",
+					Nullable: true,
+				},
+				{
+					Name:     "one",
+					Type:     "string",
+					Nullable: true,
+				},
+				{
+					Name:     "two",
+					Type:     "int",
+					Nullable: true,
+				},
+			},
+			allowedCommands: []string{
+				"ALTER TABLE `main`.`foo`.`bar` ALTER COLUMN `three` COMMENT 'moved first'",
+				"ALTER TABLE `main`.`foo`.`bar` ALTER COLUMN `three` FIRST",
+			},
+			expectedErrorMsg: "",
+		},
+	)
+}
+
+func TestResourceSqlTableUpdateTable_ColumnDefaults(t *testing.T) {
+	resourceSqlTableUpdateColumnHelper(t,
+		resourceSqlTableUpdateColumnTestMetaData{
+			oldColumns: []SqlColumnInfo{
+				{
+					Name:     "one",
+					Type:     "string",
+					Nullable: true,
+					Default:  "'a'",
+				},
+				{
+					Name:     "two",
+					Type:     "int",
+					Nullable: true,
+				},
+			},
+			newColumns: []SqlColumnInfo{
+				{
+					Name:     "one",
+					Type:     "string",
+					Nullable: true,
+				},
+				{
+					Name:     "two",
+					Type:     "int",
+					Nullable: true,
+					Default:  "42",
+				},
+			},
+			// the default of `one` is kept, as it isn't specified in the configuration
+			allowedCommands: []string{
+				"ALTER TABLE `main`.`foo`.`bar` ALTER COLUMN `two` SET DEFAULT 42",
+			},
+			expectedErrorMsg: "",
+		},
+	)
+}
+
+func TestResourceSqlTableUpdateTable_GeneratedExpressionThrowsError(t *testing.T) {
+	resourceSqlTableUpdateColumnHelper(t,
+		resourceSqlTableUpdateColumnTestMetaData{
+			oldColumns: []SqlColumnInfo{
+				{
+					Name:                "one",
+					Type:                "date",
+					Nullable:            true,
+					GeneratedExpression: "CAST(ts AS DATE)",
+				},
+			},
+			newColumns: []SqlColumnInfo{
+				{
+					Name:                "one",
+					Type:                "date",
+					Nullable:            true,
+					GeneratedExpression: "CAST(created AS DATE)",
+				},
+			},
+			allowedCommands:  []string{},
+			expectedErrorMsg: "changing the 'generated_expression' of an existing column is not supported",
+		},
+	)
+}
+
+func TestIsTypeWidening(t *testing.T) {
+	for _, tc := range []struct {
+		from, to string
+		widening bool
+	}{
+		{"byte", "int", true},
+		{"smallint", "integer", true},
+		{"int", "bigint", true},
+		{"bigint", "int", false},
+		{"float", "double", true},
+		{"int", "double", true},
+		{"bigint", "double", false},
+		{"int", "decimal(10,0)", true},
+		{"int", "decimal(10,2)", false},
+		{"bigint", "decimal(20, 0)", true},
+		{"decimal(10,2)", "decimal(12,4)", true},
+		{"decimal", "decimal(12,2)", true},
+		{"decimal(10,2)", "decimal(10,4)", false},
+		{"date", "timestamp_ntz", true},
+		{"date", "timestamp", false},
+		{"string", "int", false},
+	} {
+		assert.Equal(t, tc.widening, isTypeWidening(tc.from, tc.to), "%s -> %s", tc.from, tc.to)
+	}
+}
+
+func TestResourceSqlTableCreateStatement_DefaultAndGeneratedColumns(t *testing.T) {
+	ti := &SqlTableInfo{
+		Name:             "bar",
+		CatalogName:      "main",
+		SchemaName:       "foo",
+		TableType:        "MANAGED",
+		DataSourceFormat: "DELTA",
+		ColumnInfos: []SqlColumnInfo{
+			{
+				Name:     "ts",
+				Type:     "timestamp",
+				Nullable: true,
+				Default:  "current_timestamp()",
+			},
+			{
+				Name:                "day",
+				Type:                "date",
+				Nullable:            true,
+				GeneratedExpression: "CAST(ts AS DATE)",
+			},
+		},
+	}
+	stmt := ti.buildTableCreateStatement()
+	assert.Contains(t, stmt, "(`ts` timestamp DEFAULT current_timestamp(), `day` date GENERATED ALWAYS AS (CAST(ts AS DATE)))")
+}
+
+func TestResourceSqlTableDiff_CheckConstraints(t *testing.T) {
+	oldti := &SqlTableInfo{
+		Name:        "bar",
+		CatalogName: "main",
+		SchemaName:  "foo",
+		TableType:   "MANAGED",
+		CheckConstraints: []SqlCheckConstraint{
+			{Name: "positive_id", Expression: "id > 0"},
+			{Name: "valid_name", Expression: "name IS NOT NULL"},
+			{Name: "gone", Expression: "true"},
+		},
+	}
+	ti := &SqlTableInfo{
+		Name:        "bar",
+		CatalogName: "main",
+		SchemaName:  "foo",
+		TableType:   "MANAGED",
+		CheckConstraints: []SqlCheckConstraint{
+			{Name: "positive_id", Expression: "id  >  0"},
+			{Name: "valid_name", Expression: "length(name) > 0"},
+			{Name: "new_one", Expression: "id < 100"},
+		},
+	}
+	statements, err := ti.diff(oldti)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"ALTER TABLE `main`.`foo`.`bar` DROP CONSTRAINT IF EXISTS `valid_name`",
+		"ALTER TABLE `main`.`foo`.`bar` DROP CONSTRAINT IF EXISTS `gone`",
+		"ALTER TABLE `main`.`foo`.`bar` ADD CONSTRAINT `valid_name` CHECK (length(name) > 0)",
+		"ALTER TABLE `main`.`foo`.`bar` ADD CONSTRAINT `new_one` CHECK (id < 100)",
+	}, statements)
+}
+
+func TestResourceSqlTableReadTable_DefaultsAndCheckConstraints(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/unity-catalog/tables/main.foo.bar",
+				Response: SqlTableInfo{
+					Name:             "bar",
+					CatalogName:      "main",
+					SchemaName:       "foo",
+					TableType:        "MANAGED",
+					DataSourceFormat: "DELTA",
+					Properties: map[string]string{
+						"delta.constraints.positive_id": "id > 0",
+						"delta.constraints.age_check":   "age < 200",
+					},
+					ColumnInfos: []SqlColumnInfo{
+						{
+							Name:     "id",
+							Type:     "bigint",
+							TypeJson: `{"type":"long","nullable":true,"metadata":{"CURRENT_DEFAULT":"0","EXISTS_DEFAULT":"0"}}`,
+						},
+						{
+							Name:     "day",
+							Type:     "date",
+							TypeJson: `{"type":"date","nullable":true,"metadata":{"delta.generationExpression":"CAST(ts AS DATE)"}}`,
+						},
+					},
+				},
+			},
+		},
+		ID:   "main.foo.bar",
+		Read: true,
+		New:  true,
+		HCL: `
+		name         = "bar"
+		catalog_name = "main"
+		schema_name  = "foo"
+		table_type   = "MANAGED"
+		check_constraint {
+			name       = "positive_id"
+			expression = "id > 0"
+		}
+		`,
+		Resource: ResourceSqlTable(),
+	}.ApplyAndExpectData(t, map[string]any{
+		"column.0.default":              "0",
+		"column.1.generated_expression": "CAST(ts AS DATE)",
+		// age_check was added outside of Terraform
+		"check_constraint.#":            1,
+		"check_constraint.0.name":       "positive_id",
+		"check_constraint.0.expression": "id > 0",
+	})
+}
+
+func TestResourceSqlTableUpdateTable_KeepsUnmanagedCheckConstraints(t *testing.T) {
+	getTable := qa.HTTPFixture{
+		Method:       "GET",
+		Resource:     "/api/2.1/unity-catalog/tables/main.foo.bar",
+		ReuseRequest: true,
+		Response: SqlTableInfo{
+			Name:             "bar",
+			CatalogName:      "main",
+			SchemaName:       "foo",
+			TableType:        "MANAGED",
+			DataSourceFormat: "DELTA",
+			Comment:          "old",
+			Properties: map[string]string{
+				"delta.constraints.positive_id": "id > 0",
+				"delta.constraints.age_check":   "age < 200",
+			},
+			ColumnInfos: []SqlColumnInfo{
+				{
+					Name:     "id",
+					Type:     "bigint",
+					Nullable: true,
+					TypeJson: `{"type":"long","nullable":true,"metadata":{"CURRENT_DEFAULT":"0","EXISTS_DEFAULT":"0"}}`,
+				},
+			},
+		},
+	}
+	hcl := `
+		name         = "bar"
+		catalog_name = "main"
+		schema_name  = "foo"
+		table_type   = "MANAGED"
+		warehouse_id = "existingwarehouse"
+		comment      = "%s"
+		column {
+			name = "id"
+			type = "bigint"
+		}
+		check_constraint {
+			name       = "positive_id"
+			expression = "id > 0"
+		}
+		`
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{getTable},
+		ID:       "main.foo.bar",
+		Read:     true,
+		New:      true,
+		HCL:      fmt.Sprintf(hcl, "old"),
+		Resource: ResourceSqlTable(),
+	}.Apply(t)
+	assert.NoError(t, err)
+	assert.Equal(t, "0", d.Get("column.0.default"))
+	// neither the default of the column nor the unmanaged constraint are dropped
+	qa.ResourceFixture{
+		CommandMock: func(commandStr string) common.CommandResults {
+			assert.Fail(t, "unexpected command", commandStr)
+			return common.CommandResults{}
+		},
+		Fixtures: []qa.HTTPFixture{
+			getTable,
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/sql/statements/",
+				ExpectedRequest: sql.ExecuteStatementRequest{
+					Statement:     "COMMENT ON TABLE `main`.`foo`.`bar` IS 'new'",
+					WaitTimeout:   "50s",
+					WarehouseId:   "existingwarehouse",
+					OnWaitTimeout: sql.ExecuteStatementRequestOnWaitTimeoutCancel,
+				},
+				Response: sql.StatementResponse{
+					StatementId: "statement1",
+					Status: &sql.StatementStatus{
+						State: "SUCCEEDED",
+					},
+				},
+			},
+		},
+		ID:            "main.foo.bar",
+		Update:        true,
+		InstanceState: d.State().Attributes,
+		HCL:           fmt.Sprintf(hcl, "new"),
+		Resource:      ResourceSqlTable(),
+	}.ApplyNoError(t)
+}
+
+func TestResourceSqlTableCreateStatement_Constraints(t *testing.T) {
+	ti := &SqlTableInfo{
+		Name:             "orders",
+		CatalogName:      "main",
+		SchemaName:       "sales",
+		TableType:        "MANAGED",
+		DataSourceFormat: "DELTA",
+		ColumnInfos: []SqlColumnInfo{
+			{
+				Name: "id",
+				Type: "bigint",
+			},
+			{
+				Name:     "customer_id",
+				Type:     "bigint",
+				Nullable: true,
+			},
+		},
+		Constraints: []SqlTableConstraint{
+			{
+				Name: "orders_pk",
+				PrimaryKey: &SqlPrimaryKeyConstraint{
+					Columns: []string{"id"},
+					Rely:    true,
+				},
+			},
+			{
+				Name: "orders_customers_fk",
+				ForeignKey: &SqlForeignKeyConstraint{
+					Columns:       []string{"customer_id"},
+					ParentTable:   "main.sales.customers",
+					ParentColumns: []string{"id"},
+				},
+			},
+		},
+	}
+	stmt := ti.buildTableCreateStatement()
+	assert.Contains(t, stmt, "(`id` bigint NOT NULL, `customer_id` bigint, "+
+		"CONSTRAINT `orders_pk` PRIMARY KEY (`id`) RELY, "+
+		"CONSTRAINT `orders_customers_fk` FOREIGN KEY (`customer_id`) REFERENCES `main`.`sales`.`customers` (`id`))")
+}
+
+func TestResourceSqlTableDiff_Constraints(t *testing.T) {
+	oldti := &SqlTableInfo{
+		Name:        "orders",
+		CatalogName: "main",
+		SchemaName:  "sales",
+		TableType:   "MANAGED",
+		Constraints: []SqlTableConstraint{
+			{
+				Name:       "orders_pk",
+				PrimaryKey: &SqlPrimaryKeyConstraint{Columns: []string{"id"}},
+			},
+			{
+				Name: "orders_customers_fk",
+				ForeignKey: &SqlForeignKeyConstraint{
+					Columns:       []string{"customer_id"},
+					ParentTable:   "main.sales.customers",
+					ParentColumns: []string{"id"},
+				},
+			},
+			{
+				Name: "orders_products_fk",
+				ForeignKey: &SqlForeignKeyConstraint{
+					Columns:       []string{"product_id"},
+					ParentTable:   "main.sales.products",
+					ParentColumns: []string{"id"},
+				},
+			},
+		},
+	}
+	ti := &SqlTableInfo{
+		Name:        "orders",
+		CatalogName: "main",
+		SchemaName:  "sales",
+		TableType:   "MANAGED",
+		Constraints: []SqlTableConstraint{
+			{
+				Name:       "orders_pk",
+				PrimaryKey: &SqlPrimaryKeyConstraint{Columns: []string{"id"}, Rely: true},
+			},
+			{
+				// parent columns default to the primary key of the parent table
+				Name: "ORDERS_CUSTOMERS_FK",
+				ForeignKey: &SqlForeignKeyConstraint{
+					Columns:     []string{"customer_id"},
+					ParentTable: "Main.Sales.Customers",
+				},
+			},
+		},
+	}
+	statements, err := ti.diff(oldti)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"ALTER TABLE `main`.`sales`.`orders` DROP CONSTRAINT IF EXISTS `orders_products_fk`",
+		"ALTER TABLE `main`.`sales`.`orders` DROP CONSTRAINT IF EXISTS `orders_pk`",
+		"ALTER TABLE `main`.`sales`.`orders` ADD CONSTRAINT `orders_pk` PRIMARY KEY (`id`) RELY",
+	}, statements)
+}
+
+var ordersWithConstraints = qa.HTTPFixture{
+	Method:       "GET",
+	Resource:     "/api/2.1/unity-catalog/tables/main.sales.orders",
+	ReuseRequest: true,
+	Response: map[string]any{
+		"name":               "orders",
+		"catalog_name":       "main",
+		"schema_name":        "sales",
+		"table_type":         "MANAGED",
+		"data_source_format": "DELTA",
+		"table_constraints": []any{
+			map[string]any{
+				"primary_key_constraint": map[string]any{
+					"name":          "orders_pk",
+					"child_columns": []string{"id"},
+					"rely":          true,
+				},
+			},
+			map[string]any{
+				"foreign_key_constraint": map[string]any{
+					"name":           "orders_customers_fk",
+					"child_columns":  []string{"customer_id"},
+					"parent_table":   "main.sales.customers",
+					"parent_columns": []string{"id"},
+				},
+			},
+			map[string]any{
+				// added outside of Terraform
+				"foreign_key_constraint": map[string]any{
+					"name":           "orders_regions_fk",
+					"child_columns":  []string{"region"},
+					"parent_table":   "main.sales.regions",
+					"parent_columns": []string{"name"},
+				},
+			},
+		},
+	},
+}
+
+const ordersWithConstraintsHCL = `
+	name         = "orders"
+	catalog_name = "main"
+	schema_name  = "sales"
+	table_type   = "MANAGED"
+	warehouse_id = "existingwarehouse"
+	constraint {
+		name = "orders_pk"
+		primary_key {
+			columns = ["id"]
+			rely    = true
+		}
+	}
+	constraint {
+		name = "orders_customers_fk"
+		foreign_key {
+			columns      = ["customer_id"]
+			parent_table = "main.sales.customers"
+			rely         = true
+		}
+	}
+	`
+
+func TestResourceSqlTableReadTable_Constraints(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{ordersWithConstraints},
+		ID:       "main.sales.orders",
+		Read:     true,
+		New:      true,
+		HCL:      ordersWithConstraintsHCL,
+		Resource: ResourceSqlTable(),
+	}.ApplyAndExpectData(t, map[string]any{
+		"constraint.#":                              2,
+		"constraint.0.primary_key.0.columns":        []any{"id"},
+		"constraint.0.primary_key.0.rely":           true,
+		"constraint.1.foreign_key.0.parent_table":   "main.sales.customers",
+		"constraint.1.foreign_key.0.parent_columns": []any{"id"},
+		// RELY was removed outside of Terraform
+		"constraint.1.foreign_key.0.rely": false,
+	})
+}
+
+func TestResourceSqlTableUpdateTable_ConstraintsRelyDrift(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{ordersWithConstraints},
+		ID:       "main.sales.orders",
+		Read:     true,
+		New:      true,
+		HCL:      ordersWithConstraintsHCL,
+		Resource: ResourceSqlTable(),
+	}.Apply(t)
+	assert.NoError(t, err)
+	statement := func(statement string) qa.HTTPFixture {
+		return qa.HTTPFixture{
+			Method:   "POST",
+			Resource: "/api/2.0/sql/statements/",
+			ExpectedRequest: sql.ExecuteStatementRequest{
+				Statement:     statement,
+				WaitTimeout:   "50s",
+				WarehouseId:   "existingwarehouse",
+				OnWaitTimeout: sql.ExecuteStatementRequestOnWaitTimeoutCancel,
+			},
+			Response: sql.StatementResponse{
+				StatementId: "statement1",
+				Status: &sql.StatementStatus{
+					State: "SUCCEEDED",
+				},
+			},
+		}
+	}
+	// the foreign key is added again with RELY, while the unmanaged one isn't dropped
+	qa.ResourceFixture{
+		CommandMock: func(commandStr string) common.CommandResults {
+			assert.Fail(t, "unexpected command", commandStr)
+			return common.CommandResults{}
+		},
+		Fixtures: []qa.HTTPFixture{
+			ordersWithConstraints,
+			statement("ALTER TABLE `main`.`sales`.`orders` DROP CONSTRAINT IF EXISTS `orders_customers_fk`"),
+			statement("ALTER TABLE `main`.`sales`.`orders` ADD CONSTRAINT `orders_customers_fk` FOREIGN KEY (`customer_id`) REFERENCES `main`.`sales`.`customers` (`id`) RELY"),
+		},
+		ID:            "main.sales.orders",
+		Update:        true,
+		InstanceState: d.State().Attributes,
+		HCL:           ordersWithConstraintsHCL,
+		Resource:      ResourceSqlTable(),
+	}.ApplyNoError(t)
+}
+
+func TestResourceSqlTableConstraintWithoutKeyThrowsError(t *testing.T) {
+	qa.ResourceFixture{
+		HCL: `
+		name         = "orders"
+		catalog_name = "main"
+		schema_name  = "sales"
+		table_type   = "MANAGED"
+		constraint {
+			name = "orders_pk"
+		}
+		`,
+		Resource: ResourceSqlTable(),
+		Create:   true,
+	}.ExpectError(t, "constraint 'orders_pk' must have exactly one of primary_key or foreign_key")
+}
+
+func TestResourceSqlTableCreateStatement_MaskAndRowFilter(t *testing.T) {
+	ti := &SqlTableInfo{
+		Name:             "customers",
+		CatalogName:      "main",
+		SchemaName:       "sales",
+		TableType:        "MANAGED",
+		DataSourceFormat: "DELTA",
+		ColumnInfos: []SqlColumnInfo{
+			{
+				Name:     "ssn",
+				Type:     "string",
+				Nullable: true,
+				Mask: &SqlColumnMask{
+					FunctionName:     "main.governance.mask_ssn",
+					UsingColumnNames: []string{"region"},
+				},
+			},
+			{
+				Name:     "region",
+				Type:     "string",
+				Nullable: true,
+			},
+		},
+		RowFilter: &SqlRowFilter{
+			FunctionName:     "main.governance.region_filter",
+			InputColumnNames: []string{"region"},
+		},
+	}
+	stmt := ti.buildTableCreateStatement()
+	assert.Contains(t, stmt, "(`ssn` string MASK `main`.`governance`.`mask_ssn` USING COLUMNS (`region`), `region` string)")
+	assert.Contains(t, stmt, "\nWITH ROW FILTER `main`.`governance`.`region_filter` ON (`region`)")
+}
+
+func TestResourceSqlTableDiff_MaskAndRowFilter(t *testing.T) {
+	oldti := &SqlTableInfo{
+		Name:        "customers",
+		CatalogName: "main",
+		SchemaName:  "sales",
+		TableType:   "MANAGED",
+		ColumnInfos: []SqlColumnInfo{
+			{
+				Name: "ssn",
+				Type: "string",
+				Mask: &SqlColumnMask{FunctionName: "main.governance.mask_ssn"},
+			},
+			{
+				Name: "email",
+				Type: "string",
+			},
+		},
+		RowFilter: &SqlRowFilter{
+			FunctionName:     "main.governance.region_filter",
+			InputColumnNames: []string{"region"},
+		},
+	}
+	ti := &SqlTableInfo{
+		Name:        "customers",
+		CatalogName: "main",
+		SchemaName:  "sales",
+		TableType:   "MANAGED",
+		ColumnInfos: []SqlColumnInfo{
+			{
+				Name: "ssn",
+				Type: "string",
+			},
+			{
+				Name: "email",
+				Type: "string",
+				Mask: &SqlColumnMask{FunctionName: "main.governance.mask_email"},
+			},
+		},
+	}
+	statements, err := ti.diff(oldti)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"ALTER TABLE `main`.`sales`.`customers` ALTER COLUMN `ssn` DROP MASK",
+		"ALTER TABLE `main`.`sales`.`customers` ALTER COLUMN `email` SET MASK `main`.`governance`.`mask_email`",
+		"ALTER TABLE `main`.`sales`.`customers` DROP ROW FILTER",
+	}, statements)
+
+	ti.RowFilter = &SqlRowFilter{
+		FunctionName:     "MAIN.GOVERNANCE.REGION_FILTER",
+		InputColumnNames: []string{"country"},
+	}
+	statements, err = ti.diff(oldti)
+	assert.NoError(t, err)
+	assert.Contains(t, statements, "ALTER TABLE `main`.`sales`.`customers` SET ROW FILTER `MAIN`.`GOVERNANCE`.`REGION_FILTER` ON (`country`)")
+}
+
+var customersWithMaskAndRowFilter = qa.HTTPFixture{
+	Method:       "GET",
+	Resource:     "/api/2.1/unity-catalog/tables/main.sales.customers",
+	ReuseRequest: true,
+	Response: catalog.TableInfo{
+		Name:             "customers",
+		CatalogName:      "main",
+		SchemaName:       "sales",
+		TableType:        "MANAGED",
+		DataSourceFormat: "DELTA",
+		Comment:          "old",
+		Columns: []catalog.ColumnInfo{
+			{
+				Name:     "ssn",
+				TypeText: "string",
+				Nullable: true,
+				Mask: &catalog.ColumnMask{
+					FunctionName:     "main.governance.mask_ssn",
+					UsingColumnNames: []string{"region"},
+				},
+			},
+			{
+				Name:     "region",
+				TypeText: "string",
+				Nullable: true,
+			},
+		},
+		RowFilter: &catalog.TableRowFilter{
+			FunctionName:     "main.governance.region_filter",
+			InputColumnNames: []string{"region"},
+		},
+	},
+}
+
+func TestResourceSqlTableReadTable_MaskAndRowFilter(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{customersWithMaskAndRowFilter},
+		ID:       "main.sales.customers",
+		Read:     true,
+		New:      true,
+		HCL: `
+		name         = "customers"
+		catalog_name = "main"
+		schema_name  = "sales"
+		table_type   = "MANAGED"
+		column {
+			name = "ssn"
+			type = "string"
+			mask {
+				function_name = "main.governance.mask_ssn"
+			}
+		}
+		column {
+			name = "region"
+			type = "string"
+		}
+		row_filter {
+			function_name      = "main.governance.region_filter"
+			input_column_names = ["region"]
+		}
+		`,
+		Resource: ResourceSqlTable(),
+	}.ApplyAndExpectData(t, map[string]any{
+		"column.0.mask.0.function_name":      "main.governance.mask_ssn",
+		"column.0.mask.0.using_column_names": []any{"region"},
+		"row_filter.0.function_name":         "main.governance.region_filter",
+		"row_filter.0.input_column_names":    []any{"region"},
+	})
+}
+
+func TestResourceSqlTableUpdateTable_KeepsUnmanagedMaskAndRowFilter(t *testing.T) {
+	hcl := `
+		name         = "customers"
+		catalog_name = "main"
+		schema_name  = "sales"
+		table_type   = "MANAGED"
+		warehouse_id = "existingwarehouse"
+		comment      = "%s"
+		column {
+			name = "ssn"
+			type = "string"
+		}
+		column {
+			name = "region"
+			type = "string"
+		}
+		`
+	// the filter and the mask were applied outside of Terraform
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{customersWithMaskAndRowFilter},
+		ID:       "main.sales.customers",
+		Read:     true,
+		New:      true,
+		HCL:      fmt.Sprintf(hcl, "old"),
+		Resource: ResourceSqlTable(),
+	}.Apply(t)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, d.Get("row_filter.#"))
+	assert.Equal(t, 0, d.Get("column.0.mask.#"))
+	// neither DROP ROW FILTER nor DROP MASK are executed
+	qa.ResourceFixture{
+		CommandMock: func(commandStr string) common.CommandResults {
+			assert.Fail(t, "unexpected command", commandStr)
+			return common.CommandResults{}
+		},
+		Fixtures: []qa.HTTPFixture{
+			customersWithMaskAndRowFilter,
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/sql/statements/",
+				ExpectedRequest: sql.ExecuteStatementRequest{
+					Statement:     "COMMENT ON TABLE `main`.`sales`.`customers` IS 'new'",
+					WaitTimeout:   "50s",
+					WarehouseId:   "existingwarehouse",
+					OnWaitTimeout: sql.ExecuteStatementRequestOnWaitTimeoutCancel,
+				},
+				Response: sql.StatementResponse{
+					StatementId: "statement1",
+					Status: &sql.StatementStatus{
+						State: "SUCCEEDED",
+					},
+				},
+			},
+		},
+		ID:            "main.sales.customers",
+		Update:        true,
+		InstanceState: d.State().Attributes,
+		HCL:           fmt.Sprintf(hcl, "new"),
+		Resource:      ResourceSqlTable(),
+	}.ApplyNoError(t)
+}
+
 func TestResourceSqlTableCreateTable_ExistingSQLWarehouse(t *testing.T) {
 	_, err := qa.ResourceFixture{
 		CommandMock: func(commandStr string) common.CommandResults {
@@ -0,0 +1,345 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/databricks/databricks-sdk-go/service/sql"
+	"github.com/databricks/terraform-provider-databricks/clusters"
+	"github.com/databricks/terraform-provider-databricks/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// SecurableTags manages Unity Catalog tags of a single securable or a column of a table
+type SecurableTags struct {
+	Catalog     string            `json:"catalog,omitempty" tf:"force_new"`
+	Schema      string            `json:"schema,omitempty" tf:"force_new"`
+	Table       string            `json:"table,omitempty" tf:"force_new"`
+	Column      string            `json:"column,omitempty" tf:"force_new"`
+	Volume      string            `json:"volume,omitempty" tf:"force_new"`
+	Tags        map[string]string `json:"tags"`
+	ClusterID   string            `json:"cluster_id,omitempty"`
+	WarehouseID string            `json:"warehouse_id,omitempty"`
+}
+
+var securableTagsTypes = []string{"catalog", "schema", "table", "volume"}
+
+func (st SecurableTags) securable() (string, string) {
+	switch {
+	case st.Catalog != "":
+		return "catalog", st.Catalog
+	case st.Schema != "":
+		return "schema", st.Schema
+	case st.Table != "":
+		return "table", st.Table
+	default:
+		return "volume", st.Volume
+	}
+}
+
+func (st SecurableTags) id() string {
+	securableType, name := st.securable()
+	if st.Column != "" {
+		return fmt.Sprintf("column/%s/%s", name, st.Column)
+	}
+	return fmt.Sprintf("%s/%s", securableType, name)
+}
+
+// parseID restores the securable from the ID, like `schema/main.sales` or `column/main.sales.orders/email`
+func (st *SecurableTags) parseID(id string) error {
+	split := strings.SplitN(id, "/", 3)
+	if len(split) < 2 || split[1] == "" {
+		return fmt.Errorf("ID must be `<type>/<full name>` or `column/<table>/<column>`: %s", id)
+	}
+	switch split[0] {
+	case "catalog":
+		st.Catalog = split[1]
+	case "schema":
+		st.Schema = split[1]
+	case "table":
+		st.Table = split[1]
+	case "volume":
+		st.Volume = split[1]
+	case "column":
+		if len(split) != 3 {
+			return fmt.Errorf("ID must be `column/<table>/<column>`: %s", id)
+		}
+		st.Table, st.Column = split[1], split[2]
+		return nil
+	default:
+		return fmt.Errorf("unsupported securable type in ID: %s", id)
+	}
+	if len(split) == 3 {
+		return fmt.Errorf("ID must be `<type>/<full name>`: %s", id)
+	}
+	return nil
+}
+
+// sqlStringLiteral quotes the value as a Spark SQL string literal
+func sqlStringLiteral(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `'`, `\'`)
+	return "'" + value + "'"
+}
+
+func (st SecurableTags) alterPrefix() string {
+	securableType, name := st.securable()
+	if st.Column != "" {
+		return fmt.Sprintf("ALTER TABLE %s ALTER COLUMN `%s`", wrapFullName(name), st.Column)
+	}
+	return fmt.Sprintf("ALTER %s %s", strings.ToUpper(securableType), wrapFullName(name))
+}
+
+func (st SecurableTags) setTagsStatement(tags map[string]string) string {
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	pairs := []string{}
+	for _, k := range keys {
+		pairs = append(pairs, fmt.Sprintf("%s = %s", sqlStringLiteral(k), sqlStringLiteral(tags[k])))
+	}
+	return fmt.Sprintf("%s SET TAGS (%s)", st.alterPrefix(), strings.Join(pairs, ", "))
+}
+
+func (st SecurableTags) unsetTagsStatement(keys []string) string {
+	sort.Strings(keys)
+	quoted := []string{}
+	for _, k := range keys {
+		quoted = append(quoted, sqlStringLiteral(k))
+	}
+	return fmt.Sprintf("%s UNSET TAGS (%s)", st.alterPrefix(), strings.Join(quoted, ", "))
+}
+
+// readTagsQuery selects tags of the securable from the information schema of its catalog
+func (st SecurableTags) readTagsQuery() string {
+	securableType, name := st.securable()
+	parts := strings.Split(name, ".")
+	table := securableType + "_tags"
+	conditions := []string{fmt.Sprintf("catalog_name = %s", sqlStringLiteral(parts[0]))}
+	if len(parts) > 1 {
+		conditions = append(conditions, fmt.Sprintf("schema_name = %s", sqlStringLiteral(parts[1])))
+	}
+	if len(parts) > 2 {
+		conditions = append(conditions, fmt.Sprintf("%s_name = %s", securableType, sqlStringLiteral(parts[2])))
+	}
+	if st.Column != "" {
+		table = "column_tags"
+		conditions = append(conditions, fmt.Sprintf("column_name = %s", sqlStringLiteral(st.Column)))
+	}
+	return fmt.Sprintf("SELECT tag_name, tag_value FROM `%s`.information_schema.%s WHERE %s",
+		strings.ReplaceAll(parts[0], "`", "``"), table, strings.Join(conditions, " AND "))
+}
+
+type tagsExecutor struct {
+	ctx         context.Context
+	c           *common.DatabricksClient
+	clusterID   string
+	warehouseID string
+}
+
+// newTagsExecutor starts a terminated cluster only if start is set, so that refreshing the state doesn't
+// start clusters. Otherwise it returns no executor, if the cluster isn't running.
+func newTagsExecutor(ctx context.Context, c *common.DatabricksClient, st SecurableTags, start bool) (*tagsExecutor, error) {
+	if st.ClusterID != "" {
+		clustersAPI := clusters.NewClustersAPI(ctx, c)
+		if start {
+			_, err := clustersAPI.StartAndGetInfo(st.ClusterID)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			ci, err := clustersAPI.Get(st.ClusterID)
+			if err != nil {
+				return nil, err
+			}
+			if !ci.IsRunningOrResizing() {
+				return nil, nil
+			}
+		}
+	}
+	return &tagsExecutor{
+		ctx:         ctx,
+		c:           c,
+		clusterID:   st.ClusterID,
+		warehouseID: st.WarehouseID,
+	}, nil
+}
+
+// query executes the statement and returns rows of string columns
+func (e *tagsExecutor) query(statement string) ([][]string, error) {
+	log.Printf("[INFO] Executing Sql: %s", statement)
+	if e.clusterID != "" {
+		r := e.c.CommandExecutor(e.ctx).Execute(e.clusterID, "sql", statement)
+		if r.Failed() {
+			return nil, fmt.Errorf("cannot execute %s: %s", statement, r.Error())
+		}
+		rows := [][]string{}
+		var name, value string
+		for r.Scan(&name, &value) {
+			rows = append(rows, []string{name, value})
+		}
+		return rows, nil
+	}
+	w, err := e.c.WorkspaceClient()
+	if err != nil {
+		return nil, err
+	}
+	res, err := w.StatementExecution.ExecuteAndWait(e.ctx, sql.ExecuteStatementRequest{
+		Statement:   statement,
+		WarehouseId: e.warehouseID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	rows := [][]string{}
+	chunk := res.Result
+	for chunk != nil {
+		rows = append(rows, chunk.DataArray...)
+		if chunk.NextChunkIndex == 0 {
+			break
+		}
+		chunk, err = w.StatementExecution.GetStatementResultChunkNByStatementIdAndChunkIndex(
+			e.ctx, res.StatementId, chunk.NextChunkIndex)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return rows, nil
+}
+
+func (e *tagsExecutor) readTags(st SecurableTags) (map[string]string, error) {
+	rows, err := e.query(st.readTagsQuery())
+	if err != nil {
+		return nil, err
+	}
+	tags := map[string]string{}
+	for _, row := range rows {
+		if len(row) != 2 {
+			continue
+		}
+		tags[row[0]] = row[1]
+	}
+	return tags, nil
+}
+
+// applyTags sets new and changed tags, and unsets the ones that are not desired anymore
+func (e *tagsExecutor) applyTags(st SecurableTags, old, new map[string]string) error {
+	unset := []string{}
+	for k := range old {
+		if _, ok := new[k]; !ok {
+			unset = append(unset, k)
+		}
+	}
+	if len(unset) > 0 {
+		_, err := e.query(st.unsetTagsStatement(unset))
+		if err != nil {
+			return err
+		}
+	}
+	set := map[string]string{}
+	for k, v := range new {
+		if ov, ok := old[k]; !ok || ov != v {
+			set[k] = v
+		}
+	}
+	if len(set) > 0 {
+		_, err := e.query(st.setTagsStatement(set))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func tagsFromState(v any) map[string]string {
+	tags := map[string]string{}
+	for k, v := range v.(map[string]any) {
+		tags[k] = v.(string)
+	}
+	return tags
+}
+
+func ResourceTags() common.Resource {
+	s := common.StructToSchema(SecurableTags{},
+		func(m map[string]*schema.Schema) map[string]*schema.Schema {
+			for _, field := range securableTagsTypes {
+				common.CustomizeSchemaPath(m, field).SetExactlyOneOf(securableTagsTypes)
+			}
+			common.CustomizeSchemaPath(m, "column").SetRequiredWith([]string{"table"})
+			common.CustomizeSchemaPath(m, "cluster_id").SetExactlyOneOf([]string{"cluster_id", "warehouse_id"})
+			common.CustomizeSchemaPath(m, "warehouse_id").SetExactlyOneOf([]string{"cluster_id", "warehouse_id"})
+			return m
+		})
+	return common.Resource{
+		Schema: s,
+		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			var st SecurableTags
+			common.DataToStructPointer(d, s, &st)
+			e, err := newTagsExecutor(ctx, c, st, true)
+			if err != nil {
+				return err
+			}
+			err = e.applyTags(st, map[string]string{}, st.Tags)
+			if err != nil {
+				return err
+			}
+			d.SetId(st.id())
+			return nil
+		},
+		Read: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			var st SecurableTags
+			common.DataToStructPointer(d, s, &st)
+			if st.ClusterID == "" && st.WarehouseID == "" {
+				// tags can only be read with a compute, that isn't known for imported resources, so only
+				// the securable is restored, and the tags are read after the next apply sets the compute
+				err := st.parseID(d.Id())
+				if err != nil {
+					return err
+				}
+				return common.StructToData(st, s, d)
+			}
+			e, err := newTagsExecutor(ctx, c, st, false)
+			if err != nil {
+				return err
+			}
+			if e == nil {
+				// interactive clusters auto-terminate, so tags from the state are kept until the cluster is running
+				log.Printf("[WARN] Cannot refresh tags of %s, as cluster %s isn't running: use warehouse_id "+
+					"instead of cluster_id to detect drift", d.Id(), st.ClusterID)
+				return nil
+			}
+			st.Tags, err = e.readTags(st)
+			if err != nil {
+				return err
+			}
+			return common.StructToData(st, s, d)
+		},
+		Update: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			var st SecurableTags
+			common.DataToStructPointer(d, s, &st)
+			if !d.HasChange("tags") {
+				return nil
+			}
+			e, err := newTagsExecutor(ctx, c, st, true)
+			if err != nil {
+				return err
+			}
+			old, _ := d.GetChange("tags")
+			return e.applyTags(st, tagsFromState(old), st.Tags)
+		},
+		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			var st SecurableTags
+			common.DataToStructPointer(d, s, &st)
+			e, err := newTagsExecutor(ctx, c, st, true)
+			if err != nil {
+				return err
+			}
+			return e.applyTags(st, st.Tags, map[string]string{})
+		},
+	}
+}
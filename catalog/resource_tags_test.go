@@ -0,0 +1,267 @@
+package catalog
+
+import (
+	"testing"
+
+	"github.com/databricks/databricks-sdk-go/service/sql"
+	"github.com/databricks/terraform-provider-databricks/clusters"
+	"github.com/databricks/terraform-provider-databricks/common"
+	"github.com/databricks/terraform-provider-databricks/qa"
+	"github.com/stretchr/testify/assert"
+)
+
+func tagsStatementFixture(statement string, rows [][]string) qa.HTTPFixture {
+	return qa.HTTPFixture{
+		Method:   "POST",
+		Resource: "/api/2.0/sql/statements/",
+		ExpectedRequest: sql.ExecuteStatementRequest{
+			Statement:   statement,
+			WarehouseId: "abc",
+		},
+		Response: sql.StatementResponse{
+			StatementId: "statement1",
+			Status: &sql.StatementStatus{
+				State: "SUCCEEDED",
+			},
+			Result: &sql.ResultData{
+				DataArray: rows,
+			},
+		},
+	}
+}
+
+func TestTagsCornerCases(t *testing.T) {
+	qa.ResourceCornerCases(t, ResourceTags(),
+		// without compute, the securable is restored from the ID
+		qa.CornerCaseExpectError("ID must be `<type>/<full name>` or `column/<table>/<column>`"),
+		// nothing to unset
+		qa.CornerCaseSkipCRUD("delete"))
+}
+
+func TestTagsParseID(t *testing.T) {
+	var st SecurableTags
+	assert.NoError(t, st.parseID("column/main.sales.orders/email"))
+	assert.Equal(t, SecurableTags{Table: "main.sales.orders", Column: "email"}, st)
+	st = SecurableTags{}
+	assert.NoError(t, st.parseID("volume/main.sales.files"))
+	assert.Equal(t, SecurableTags{Volume: "main.sales.files"}, st)
+	assert.EqualError(t, st.parseID("function/main.sales.f"), "unsupported securable type in ID: function/main.sales.f")
+	assert.EqualError(t, st.parseID("column/main.sales.orders"), "ID must be `column/<table>/<column>`: column/main.sales.orders")
+	assert.EqualError(t, st.parseID("schema/main.sales/x"), "ID must be `<type>/<full name>`: schema/main.sales/x")
+}
+
+func TestResourceTagsImport(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceTags(),
+		Read:     true,
+		New:      true,
+		ID:       "column/main.sales.orders/email",
+	}.ApplyAndExpectData(t, map[string]any{
+		"table":  "main.sales.orders",
+		"column": "email",
+		"tags.%": "0",
+	})
+}
+
+func TestTagsStatements(t *testing.T) {
+	st := SecurableTags{Table: "main.sales.orders", Column: "email"}
+	assert.Equal(t, "ALTER TABLE `main`.`sales`.`orders` ALTER COLUMN `email` SET TAGS ('owner\\'s' = 'a\\\\b', 'pii' = 'true')",
+		st.setTagsStatement(map[string]string{"pii": "true", "owner's": `a\b`}))
+	assert.Equal(t, "SELECT tag_name, tag_value FROM `main`.information_schema.column_tags WHERE catalog_name = 'main' "+
+		"AND schema_name = 'sales' AND table_name = 'orders' AND column_name = 'email'", st.readTagsQuery())
+	assert.Equal(t, "column/main.sales.orders/email", st.id())
+
+	st = SecurableTags{Volume: "main.sales.files"}
+	assert.Equal(t, "ALTER VOLUME `main`.`sales`.`files` UNSET TAGS ('a', 'b')", st.unsetTagsStatement([]string{"b", "a"}))
+	assert.Equal(t, "SELECT tag_name, tag_value FROM `main`.information_schema.volume_tags WHERE catalog_name = 'main' "+
+		"AND schema_name = 'sales' AND volume_name = 'files'", st.readTagsQuery())
+
+	st = SecurableTags{Catalog: "main"}
+	assert.Equal(t, "SELECT tag_name, tag_value FROM `main`.information_schema.catalog_tags WHERE catalog_name = 'main'",
+		st.readTagsQuery())
+}
+
+func TestResourceTagsCreate(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			tagsStatementFixture("ALTER SCHEMA `main`.`sales` SET TAGS ('domain' = 'finance', 'pii' = 'true')", nil),
+			tagsStatementFixture("SELECT tag_name, tag_value FROM `main`.information_schema.schema_tags "+
+				"WHERE catalog_name = 'main' AND schema_name = 'sales'", [][]string{
+				{"domain", "finance"},
+				{"pii", "true"},
+			}),
+		},
+		Resource: ResourceTags(),
+		Create:   true,
+		HCL: `
+		schema = "main.sales"
+		tags = {
+			pii = "true"
+			domain = "finance"
+		}
+		warehouse_id = "abc"
+		`,
+	}.ApplyAndExpectData(t, map[string]any{
+		"id":          "schema/main.sales",
+		"tags.%":      "2",
+		"tags.domain": "finance",
+	})
+}
+
+func TestResourceTagsReadDrift(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			tagsStatementFixture("SELECT tag_name, tag_value FROM `main`.information_schema.catalog_tags "+
+				"WHERE catalog_name = 'main'", [][]string{
+				{"pii", "false"},
+				{"manual", "yes"},
+			}),
+		},
+		Resource: ResourceTags(),
+		Read:     true,
+		New:      true,
+		ID:       "catalog/main",
+		HCL: `
+		catalog = "main"
+		tags = {
+			pii = "true"
+		}
+		warehouse_id = "abc"
+		`,
+	}.ApplyAndExpectData(t, map[string]any{
+		"tags.%":      "2",
+		"tags.pii":    "false",
+		"tags.manual": "yes",
+	})
+}
+
+func TestResourceTagsReadOnCluster(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:       "GET",
+				Resource:     "/api/2.0/clusters/get?cluster_id=abc",
+				ReuseRequest: true,
+				Response: clusters.ClusterInfo{
+					State: "RUNNING",
+				},
+			},
+		},
+		CommandMock: func(commandStr string) common.CommandResults {
+			assert.Equal(t, "SELECT tag_name, tag_value FROM `main`.information_schema.table_tags "+
+				"WHERE catalog_name = 'main' AND schema_name = 'sales' AND table_name = 'orders'", commandStr)
+			return common.CommandResults{
+				ResultType: "table",
+				Data: []any{
+					[]any{"pii", "true"},
+				},
+			}
+		},
+		Resource: ResourceTags(),
+		Read:     true,
+		New:      true,
+		ID:       "table/main.sales.orders",
+		HCL: `
+		table = "main.sales.orders"
+		tags = {
+			pii = "true"
+		}
+		cluster_id = "abc"
+		`,
+	}.ApplyAndExpectData(t, map[string]any{
+		"tags.%":   "1",
+		"tags.pii": "true",
+	})
+}
+
+func TestResourceTagsReadOnTerminatedCluster(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/clusters/get?cluster_id=abc",
+				Response: clusters.ClusterInfo{
+					State: "TERMINATED",
+				},
+			},
+		},
+		Resource: ResourceTags(),
+		Read:     true,
+		New:      true,
+		ID:       "table/main.sales.orders",
+		HCL: `
+		table = "main.sales.orders"
+		tags = {
+			pii = "true"
+		}
+		cluster_id = "abc"
+		`,
+		InstanceState: map[string]string{
+			"table":      "main.sales.orders",
+			"cluster_id": "abc",
+			"tags.%":     "1",
+			"tags.pii":   "true",
+		},
+	}.ApplyAndExpectData(t, map[string]any{
+		// tags are kept from the state and no statements are executed
+		"tags.%":   "1",
+		"tags.pii": "true",
+	})
+}
+
+func TestResourceTagsUpdate(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			tagsStatementFixture("ALTER TABLE `main`.`sales`.`orders` ALTER COLUMN `email` UNSET TAGS ('manual')", nil),
+			tagsStatementFixture("ALTER TABLE `main`.`sales`.`orders` ALTER COLUMN `email` SET TAGS ('pii' = 'true')", nil),
+			tagsStatementFixture("SELECT tag_name, tag_value FROM `main`.information_schema.column_tags "+
+				"WHERE catalog_name = 'main' AND schema_name = 'sales' AND table_name = 'orders' AND column_name = 'email'",
+				[][]string{
+					{"pii", "true"},
+					{"domain", "finance"},
+				}),
+		},
+		Resource: ResourceTags(),
+		Update:   true,
+		ID:       "column/main.sales.orders/email",
+		InstanceState: map[string]string{
+			"table":        "main.sales.orders",
+			"column":       "email",
+			"warehouse_id": "abc",
+			"tags.%":       "3",
+			"tags.pii":     "false",
+			"tags.manual":  "yes",
+			"tags.domain":  "finance",
+		},
+		HCL: `
+		table = "main.sales.orders"
+		column = "email"
+		tags = {
+			pii = "true"
+			domain = "finance"
+		}
+		warehouse_id = "abc"
+		`,
+	}.ApplyAndExpectData(t, map[string]any{
+		"tags.%":   "2",
+		"tags.pii": "true",
+	})
+}
+
+func TestResourceTagsDelete(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			tagsStatementFixture("ALTER VOLUME `main`.`sales`.`files` UNSET TAGS ('domain', 'pii')", nil),
+		},
+		Resource: ResourceTags(),
+		Delete:   true,
+		ID:       "volume/main.sales.files",
+		InstanceState: map[string]string{
+			"volume":       "main.sales.files",
+			"warehouse_id": "abc",
+			"tags.%":       "2",
+			"tags.pii":     "true",
+			"tags.domain":  "finance",
+		},
+	}.ApplyNoError(t)
+}
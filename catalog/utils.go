@@ -33,15 +33,22 @@ func GetSqlColumnInfoHCL(columnInfos []SqlColumnInfo) string {
 	columnsTemplate := ""
 
 	for _, ci := range columnInfos {
+		expressions := ""
+		if ci.Default != "" {
+			expressions += fmt.Sprintf("\n\t\t\t\tdefault   = %q", ci.Default)
+		}
+		if ci.GeneratedExpression != "" {
+			expressions += fmt.Sprintf("\n\t\t\t\tgenerated_expression = %q", ci.GeneratedExpression)
+		}
 		ciTemplate := fmt.Sprintf(
 			`
 			column {
 				name      = "%s"
 				type      = "%s"
 				nullable  = %t
-				comment   = "%s"
+				comment   = "%s"%s
 			}
-			`, ci.Name, ci.Type, ci.Nullable, ci.Comment,
+			`, ci.Name, ci.Type, ci.Nullable, ci.Comment, expressions,
 		)
 		columnsTemplate += ciTemplate
 	}
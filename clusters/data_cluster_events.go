@@ -0,0 +1,68 @@
+package clusters
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/databricks/databricks-sdk-go"
+	"github.com/databricks/databricks-sdk-go/listing"
+	"github.com/databricks/databricks-sdk-go/service/compute"
+	"github.com/databricks/terraform-provider-databricks/common"
+)
+
+type clusterEventsData struct {
+	Id                string                     `json:"id,omitempty" tf:"computed"`
+	ClusterId         string                     `json:"cluster_id"`
+	StartTime         string                     `json:"start_time,omitempty"`
+	EndTime           string                     `json:"end_time,omitempty"`
+	EventTypes        []compute.EventType        `json:"event_types,omitempty"`
+	Order             compute.GetEventsOrder     `json:"order,omitempty" tf:"default:DESC"`
+	MaxItems          int                        `json:"max_items,omitempty" tf:"default:50"`
+	State             compute.State              `json:"state,omitempty" tf:"computed"`
+	TerminationReason *compute.TerminationReason `json:"termination_reason,omitempty" tf:"computed"`
+	Events            []compute.ClusterEvent     `json:"events,omitempty" tf:"computed"`
+}
+
+func parseEventsTime(name, value string) (int64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return 0, fmt.Errorf("%s must be in RFC3339 format: %w", name, err)
+	}
+	return t.UnixMilli(), nil
+}
+
+func DataSourceClusterEvents() common.Resource {
+	return common.WorkspaceData(func(ctx context.Context, data *clusterEventsData, w *databricks.WorkspaceClient) error {
+		startTime, err := parseEventsTime("start_time", data.StartTime)
+		if err != nil {
+			return err
+		}
+		endTime, err := parseEventsTime("end_time", data.EndTime)
+		if err != nil {
+			return err
+		}
+		clusterInfo, err := w.Clusters.GetByClusterId(ctx, data.ClusterId)
+		if err != nil {
+			return err
+		}
+		data.State = clusterInfo.State
+		data.TerminationReason = clusterInfo.TerminationReason
+		events, err := listing.ToSliceN(ctx, w.Clusters.Events(ctx, compute.GetEvents{
+			ClusterId:  data.ClusterId,
+			StartTime:  startTime,
+			EndTime:    endTime,
+			EventTypes: data.EventTypes,
+			Order:      data.Order,
+		}), data.MaxItems)
+		if err != nil {
+			return err
+		}
+		data.Events = events
+		data.Id = data.ClusterId
+		return nil
+	})
+}
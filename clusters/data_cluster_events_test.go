@@ -0,0 +1,91 @@
+package clusters
+
+import (
+	"testing"
+
+	"github.com/databricks/databricks-sdk-go/service/compute"
+	"github.com/databricks/terraform-provider-databricks/qa"
+)
+
+func TestClusterEventsDataSource(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/clusters/get?cluster_id=abc",
+				Response: compute.ClusterDetails{
+					ClusterId: "abc",
+					State:     compute.StateTerminated,
+					TerminationReason: &compute.TerminationReason{
+						Code: compute.TerminationReasonCodeInitScriptFailure,
+						Type: compute.TerminationReasonTypeClientError,
+					},
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.1/clusters/events",
+				ExpectedRequest: compute.GetEvents{
+					ClusterId:  "abc",
+					StartTime:  1704067200000,
+					EventTypes: []compute.EventType{compute.EventTypeTerminating, compute.EventTypeResizing},
+					Order:      compute.GetEventsOrderDesc,
+				},
+				Response: compute.GetEventsResponse{
+					Events: []compute.ClusterEvent{
+						{
+							ClusterId: "abc",
+							Timestamp: 1704067300000,
+							Type:      compute.EventTypeTerminating,
+							Details: &compute.EventDetails{
+								Reason: &compute.TerminationReason{
+									Code: compute.TerminationReasonCodeInitScriptFailure,
+								},
+							},
+						},
+						{
+							ClusterId: "abc",
+							Timestamp: 1704067250000,
+							Type:      compute.EventTypeResizing,
+							Details: &compute.EventDetails{
+								Cause:            compute.EventDetailsCauseAutoscale,
+								TargetNumWorkers: 4,
+							},
+						},
+					},
+					TotalCount: 2,
+				},
+			},
+		},
+		Resource:    DataSourceClusterEvents(),
+		NonWritable: true,
+		Read:        true,
+		ID:          "_",
+		HCL: `
+		cluster_id  = "abc"
+		start_time  = "2024-01-01T00:00:00Z"
+		event_types = ["TERMINATING", "RESIZING"]
+		`,
+	}.ApplyAndExpectData(t, map[string]any{
+		"id":                               "abc",
+		"state":                            "TERMINATED",
+		"termination_reason.0.code":        "INIT_SCRIPT_FAILURE",
+		"events.#":                         2,
+		"events.0.details.0.reason.0.code": "INIT_SCRIPT_FAILURE",
+		"events.1.details.0.cause":         "AUTOSCALE",
+	})
+}
+
+func TestClusterEventsDataSource_InvalidTime(t *testing.T) {
+	qa.ResourceFixture{
+		Resource:    DataSourceClusterEvents(),
+		NonWritable: true,
+		Read:        true,
+		ID:          "_",
+		HCL: `
+		cluster_id = "abc"
+		end_time   = "yesterday"
+		`,
+	}.ExpectError(t, "end_time must be in RFC3339 format: "+
+		"parsing time \"yesterday\" as \"2006-01-02T15:04:05Z07:00\": cannot parse \"yesterday\" as \"2006\"")
+}
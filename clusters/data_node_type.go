@@ -3,13 +3,52 @@ package clusters
 import (
 	"context"
 	"log"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
 
 	"github.com/databricks/databricks-sdk-go/service/compute"
 	"github.com/databricks/terraform-provider-databricks/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	"github.com/databricks/databricks-sdk-go"
 )
 
+const (
+	nodeTypePreferSmallest         = "smallest"
+	nodeTypePreferFewestCores      = "fewest_cores"
+	nodeTypePreferNewestGeneration = "newest_generation"
+)
+
+// NodeTypeCandidate describes a node type matching the search criteria of databricks_node_type
+type NodeTypeCandidate struct {
+	NodeTypeId          string  `json:"node_type_id"`
+	InstanceFamily      string  `json:"instance_family"`
+	Generation          int     `json:"generation"`
+	Category            string  `json:"category,omitempty"`
+	NumCores            float64 `json:"num_cores"`
+	MemoryMb            int     `json:"memory_mb"`
+	NumGpus             int     `json:"num_gpus,omitempty"`
+	LocalNvmeDisks      int     `json:"local_nvme_disks,omitempty"`
+	PhotonWorkerCapable bool    `json:"photon_worker_capable,omitempty"`
+	PhotonDriverCapable bool    `json:"photon_driver_capable,omitempty"`
+	IsGraviton          bool    `json:"is_graviton,omitempty"`
+}
+
+type nodeTypeData struct {
+	compute.NodeTypeRequest
+	InstanceFamilies        []string            `json:"instance_families,omitempty"`
+	ExcludeInstanceFamilies []string            `json:"exclude_instance_families,omitempty"`
+	LocalNvmeDisk           bool                `json:"local_nvme_disk,omitempty"`
+	Prefer                  string              `json:"prefer,omitempty" tf:"default:smallest"`
+	MaxCandidates           int                 `json:"max_candidates,omitempty" tf:"default:10"`
+	Candidates              []NodeTypeCandidate `json:"candidates,omitempty" tf:"computed"`
+}
+
 func defaultSmallestNodeType(w *databricks.WorkspaceClient, request compute.NodeTypeRequest) string {
 	if w.Config.IsAzure() {
 		return "Standard_D3_v2"
@@ -39,11 +78,165 @@ func (a ClustersAPI) GetSmallestNodeType(request compute.NodeTypeRequest) string
 	return smallestNodeType(a.context, request, w)
 }
 
+var azureNodeTypeSize = regexp.MustCompile(`^([A-Za-z]+)\d+(-\d+)?`)
+var nodeTypeGeneration = regexp.MustCompile(`\d+`)
+
+// instanceFamily returns the cloud-specific instance family of a node type,
+// e.g. `m5d` for `m5d.xlarge`, `Dds_v5` for `Standard_D4ds_v5` or `n2` for `n2-standard-4`.
+func instanceFamily(nodeTypeId string) string {
+	if strings.HasPrefix(nodeTypeId, "Standard_") {
+		return azureNodeTypeSize.ReplaceAllString(strings.TrimPrefix(nodeTypeId, "Standard_"), "$1")
+	}
+	if family, _, ok := strings.Cut(nodeTypeId, "."); ok {
+		return family
+	}
+	family, _, _ := strings.Cut(nodeTypeId, "-")
+	return family
+}
+
+// instanceGeneration returns the generation of an instance family, e.g. 5 for `m5d` and `Dds_v5`
+func instanceGeneration(family string) int {
+	if _, version, ok := strings.Cut(family, "_v"); ok {
+		generation, _ := strconv.Atoi(version)
+		return generation
+	}
+	if family != "" && unicode.IsUpper(rune(family[0])) {
+		// Azure families without version suffix are first generation
+		return 1
+	}
+	generation, _ := strconv.Atoi(nodeTypeGeneration.FindString(family))
+	return generation
+}
+
+func matchesAnyFamily(family string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(strings.ToLower(pattern), strings.ToLower(family)); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (data *nodeTypeData) matches(nt compute.NodeType, family string) bool {
+	// reuse the filtering logic of Go SDK by checking every node type individually
+	single := compute.ListNodeTypesResponse{NodeTypes: []compute.NodeType{nt}}
+	if _, err := single.Smallest(data.NodeTypeRequest); err != nil {
+		return false
+	}
+	if len(data.InstanceFamilies) > 0 && !matchesAnyFamily(family, data.InstanceFamilies) {
+		return false
+	}
+	if matchesAnyFamily(family, data.ExcludeInstanceFamilies) {
+		return false
+	}
+	if data.LocalNvmeDisk && (nt.NodeInstanceType == nil || nt.NodeInstanceType.LocalNvmeDisks < 1) {
+		return false
+	}
+	return true
+}
+
+// rankNodeTypes returns node types matching the request, with the preferred node type first.
+// The default ordering is the same as the one used by Go SDK to find the smallest node type.
+func rankNodeTypes(nodeTypes []compute.NodeType, data *nodeTypeData) []NodeTypeCandidate {
+	candidates := []NodeTypeCandidate{}
+	deprecated := map[string]bool{}
+	localDisks := map[string][4]int{}
+	for _, nt := range nodeTypes {
+		family := instanceFamily(nt.NodeTypeId)
+		if !data.matches(nt, family) {
+			continue
+		}
+		candidate := NodeTypeCandidate{
+			NodeTypeId:          nt.NodeTypeId,
+			InstanceFamily:      family,
+			Generation:          instanceGeneration(family),
+			Category:            nt.Category,
+			NumCores:            nt.NumCores,
+			MemoryMb:            nt.MemoryMb,
+			NumGpus:             nt.NumGpus,
+			PhotonWorkerCapable: nt.PhotonWorkerCapable,
+			PhotonDriverCapable: nt.PhotonDriverCapable,
+			IsGraviton:          nt.IsGraviton,
+		}
+		if nt.NodeInstanceType != nil {
+			candidate.LocalNvmeDisks = nt.NodeInstanceType.LocalNvmeDisks
+			localDisks[nt.NodeTypeId] = [4]int{
+				nt.NodeInstanceType.LocalDisks,
+				nt.NodeInstanceType.LocalDiskSizeGb,
+				nt.NodeInstanceType.LocalNvmeDisks,
+				nt.NodeInstanceType.LocalNvmeDiskSizeGb,
+			}
+		}
+		deprecated[nt.NodeTypeId] = nt.IsDeprecated
+		candidates = append(candidates, candidate)
+	}
+	smaller := func(a, b NodeTypeCandidate) bool {
+		if deprecated[a.NodeTypeId] != deprecated[b.NodeTypeId] {
+			return !deprecated[a.NodeTypeId]
+		}
+		if a.NumCores != b.NumCores {
+			return a.NumCores < b.NumCores
+		}
+		if a.MemoryMb != b.MemoryMb {
+			return a.MemoryMb < b.MemoryMb
+		}
+		aDisks, bDisks := localDisks[a.NodeTypeId], localDisks[b.NodeTypeId]
+		for i := range aDisks {
+			if aDisks[i] != bDisks[i] {
+				return aDisks[i] < bDisks[i]
+			}
+		}
+		if a.NumGpus != b.NumGpus {
+			return a.NumGpus < b.NumGpus
+		}
+		return a.NodeTypeId < b.NodeTypeId
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		switch data.Prefer {
+		case nodeTypePreferNewestGeneration:
+			if a.Generation != b.Generation {
+				return a.Generation > b.Generation
+			}
+		case nodeTypePreferFewestCores:
+			if a.NumCores != b.NumCores {
+				return a.NumCores < b.NumCores
+			}
+			if a.MemoryMb != b.MemoryMb {
+				return a.MemoryMb < b.MemoryMb
+			}
+			// among nodes of the same size, newer generations are usually cheaper per unit of compute
+			if a.Generation != b.Generation {
+				return a.Generation > b.Generation
+			}
+		}
+		return smaller(a, b)
+	})
+	return candidates
+}
+
 // DataSourceNodeType returns smallest node depedning on the cloud
 func DataSourceNodeType() common.Resource {
-	return common.WorkspaceData(func(ctx context.Context, data *compute.NodeTypeRequest, w *databricks.WorkspaceClient) error {
-		data.Id = smallestNodeType(ctx, *data, w)
+	return common.WorkspaceDataWithCustomizeFunc(func(ctx context.Context, data *nodeTypeData, w *databricks.WorkspaceClient) error {
+		data.Id = defaultSmallestNodeType(w, data.NodeTypeRequest)
+		nodeTypes, err := w.Clusters.ListNodeTypes(ctx)
+		if err != nil {
+			log.Printf("[WARN] cannot list node types, using %s: %s", data.Id, err)
+			return nil
+		}
+		candidates := rankNodeTypes(nodeTypes.NodeTypes, data)
+		if len(candidates) > 0 {
+			data.Id = candidates[0].NodeTypeId
+		}
+		if data.MaxCandidates > 0 && len(candidates) > data.MaxCandidates {
+			candidates = candidates[:data.MaxCandidates]
+		}
+		data.Candidates = candidates
 		log.Printf("[DEBUG] smallest node: %s", data.Id)
 		return nil
+	}, func(s map[string]*schema.Schema) map[string]*schema.Schema {
+		common.CustomizeSchemaPath(s, "prefer").SetValidateFunc(validation.StringInSlice([]string{
+			nodeTypePreferSmallest, nodeTypePreferFewestCores, nodeTypePreferNewestGeneration}, false))
+		return s
 	})
 }
@@ -360,3 +360,189 @@ func TestNodeTypeFleetEmptyList(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "md-fleet.xlarge", d.Id())
 }
+
+func TestInstanceFamilyAndGeneration(t *testing.T) {
+	for nodeTypeId, expected := range map[string]struct {
+		family     string
+		generation int
+	}{
+		"m5d.xlarge":        {"m5d", 5},
+		"i3.xlarge":         {"i3", 3},
+		"md-fleet.xlarge":   {"md-fleet", 0},
+		"Standard_D4ds_v5":  {"Dds_v5", 5},
+		"Standard_DS3_v2":   {"DS_v2", 2},
+		"Standard_F4s":      {"Fs", 1},
+		"Standard_E4-2s_v3": {"Es_v3", 3},
+		"n2-standard-4":     {"n2", 2},
+		"e2-highmem-2":      {"e2", 2},
+	} {
+		family := instanceFamily(nodeTypeId)
+		assert.Equal(t, expected.family, family, nodeTypeId)
+		assert.Equal(t, expected.generation, instanceGeneration(family), nodeTypeId)
+	}
+}
+
+func TestNodeTypePreferNewestGeneration(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.1/clusters/list-node-types",
+				Response: compute.ListNodeTypesResponse{
+					NodeTypes: []compute.NodeType{
+						{
+							NodeTypeId:          "m4.xlarge",
+							MemoryMb:            16384,
+							NumCores:            4,
+							PhotonWorkerCapable: true,
+						},
+						{
+							NodeTypeId:          "m5d.2xlarge",
+							MemoryMb:            32768,
+							NumCores:            8,
+							PhotonWorkerCapable: true,
+							NodeInstanceType: &compute.NodeInstanceType{
+								LocalNvmeDisks:      1,
+								LocalNvmeDiskSizeGb: 300,
+							},
+						},
+						{
+							NodeTypeId:          "m5d.xlarge",
+							MemoryMb:            16384,
+							NumCores:            4,
+							PhotonWorkerCapable: true,
+							NodeInstanceType: &compute.NodeInstanceType{
+								LocalNvmeDisks:      1,
+								LocalNvmeDiskSizeGb: 150,
+							},
+						},
+						{
+							NodeTypeId:          "r6id.xlarge",
+							MemoryMb:            32768,
+							NumCores:            4,
+							PhotonWorkerCapable: true,
+							NodeInstanceType: &compute.NodeInstanceType{
+								LocalNvmeDisks:      1,
+								LocalNvmeDiskSizeGb: 237,
+							},
+						},
+						{
+							NodeTypeId: "c7id.xlarge",
+							MemoryMb:   8192,
+							NumCores:   4,
+							NodeInstanceType: &compute.NodeInstanceType{
+								LocalNvmeDisks:      1,
+								LocalNvmeDiskSizeGb: 237,
+							},
+						},
+					},
+				},
+			},
+		},
+		Read:        true,
+		Resource:    DataSourceNodeType(),
+		NonWritable: true,
+		HCL: `
+		photon_worker_capable     = true
+		local_nvme_disk           = true
+		exclude_instance_families = ["r*"]
+		prefer                    = "newest_generation"
+		`,
+		ID: ".",
+	}.ApplyAndExpectData(t, map[string]any{
+		"id":                           "m5d.xlarge",
+		"candidates.#":                 2,
+		"candidates.0.instance_family": "m5d",
+		"candidates.0.generation":      5,
+		"candidates.1.node_type_id":    "m5d.2xlarge",
+	})
+}
+
+func TestNodeTypeInstanceFamiliesAllowList(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.1/clusters/list-node-types",
+				Response: compute.ListNodeTypesResponse{
+					NodeTypes: []compute.NodeType{
+						{
+							NodeTypeId: "Standard_D4ds_v5",
+							MemoryMb:   16384,
+							NumCores:   4,
+						},
+						{
+							NodeTypeId: "Standard_E4ds_v4",
+							MemoryMb:   32768,
+							NumCores:   4,
+						},
+						{
+							NodeTypeId: "Standard_E8ds_v4",
+							MemoryMb:   65536,
+							NumCores:   8,
+						},
+					},
+				},
+			},
+		},
+		Read:        true,
+		Resource:    DataSourceNodeType(),
+		NonWritable: true,
+		HCL: `
+		instance_families = ["eds_v4"]
+		prefer            = "fewest_cores"
+		`,
+		ID: ".",
+	}.ApplyAndExpectData(t, map[string]any{
+		"id":           "Standard_E4ds_v4",
+		"candidates.#": 2,
+	})
+}
+
+func TestNodeTypePreferFewestCoresLeastMemory(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.1/clusters/list-node-types",
+				Response: compute.ListNodeTypesResponse{
+					NodeTypes: []compute.NodeType{
+						{
+							NodeTypeId: "r5d.xlarge",
+							MemoryMb:   32768,
+							NumCores:   4,
+						},
+						{
+							NodeTypeId: "m5d.xlarge",
+							MemoryMb:   16384,
+							NumCores:   4,
+						},
+						{
+							NodeTypeId: "m6gd.xlarge",
+							MemoryMb:   16384,
+							NumCores:   4,
+						},
+						{
+							NodeTypeId: "m6gd.2xlarge",
+							MemoryMb:   32768,
+							NumCores:   8,
+						},
+					},
+				},
+			},
+		},
+		Read:        true,
+		Resource:    DataSourceNodeType(),
+		NonWritable: true,
+		HCL:         `prefer = "fewest_cores"`,
+		ID:          ".",
+	}.ApplyAndExpectData(t, map[string]any{
+		"id":                        "m6gd.xlarge",
+		"candidates.1.node_type_id": "m5d.xlarge",
+		"candidates.2.node_type_id": "r5d.xlarge",
+		"candidates.3.node_type_id": "m6gd.2xlarge",
+	})
+}
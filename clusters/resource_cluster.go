@@ -17,6 +17,7 @@ import (
 	"github.com/databricks/databricks-sdk-go/service/compute"
 	"github.com/databricks/terraform-provider-databricks/common"
 	"github.com/databricks/terraform-provider-databricks/libraries"
+	"github.com/databricks/terraform-provider-databricks/policies"
 )
 
 const DefaultProvisionTimeout = 30 * time.Minute
@@ -25,6 +26,12 @@ const DbfsDeprecationWarning = "For init scripts use 'volumes', 'workspace' or c
 var clusterSchema = resourceClusterSchema()
 var clusterSchemaVersion = 4
 
+const (
+	restartPolicyImmediate = "immediate"
+	restartPolicyWhenIdle  = "when_idle"
+	restartPolicyDefer     = "defer"
+)
+
 const (
 	numWorkerErr                              = "NumWorkers could be 0 only for SingleNode clusters. See https://docs.databricks.com/clusters/single-node.html for more details"
 	unsupportedExceptCreateEditClusterSpecErr = "unsupported type %T, must be one of %scompute.CreateCluster, %scompute.ClusterSpec or %scompute.EditCluster. Please report this issue to the GitHub repo"
@@ -36,6 +43,7 @@ func ResourceCluster() common.Resource {
 		Read:          resourceClusterRead,
 		Update:        resourceClusterUpdate,
 		Delete:        resourceClusterDelete,
+		CustomizeDiff: resourceClusterCustomizeDiff,
 		Schema:        clusterSchema,
 		SchemaVersion: clusterSchemaVersion,
 		Timeouts:      resourceClusterTimeouts(),
@@ -49,6 +57,15 @@ func ResourceCluster() common.Resource {
 	}
 }
 
+func resourceClusterCustomizeDiff(ctx context.Context, d *schema.ResourceDiff) error {
+	if !d.Get("validate_policy").(bool) {
+		return nil
+	}
+	var cluster compute.ClusterSpec
+	common.DiffToStructPointer(d, clusterSchema, &cluster)
+	return policies.ValidateClusterPolicy(ctx, d, "", clusterSchema, policies.ClusterTypeAllPurpose, cluster)
+}
+
 func clusterSchemaV0() cty.Type {
 	return (&schema.Resource{
 		Schema: clusterSchema}).CoreConfigSchema().ImpliedType()
@@ -337,6 +354,39 @@ func (ClusterSpec) CustomizeSchemaResourceSpecific(s *common.CustomizableSchema)
 			return old == new
 		},
 	})
+	s.AddNewField("restart_policy", &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"mode": {
+					Type:     schema.TypeString,
+					Optional: true,
+					Default:  restartPolicyImmediate,
+					ValidateFunc: validation.StringInSlice([]string{
+						restartPolicyImmediate, restartPolicyWhenIdle, restartPolicyDefer}, false),
+				},
+				"idle_minutes": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      10,
+					ValidateFunc: validation.IntAtLeast(1),
+				},
+			},
+		},
+	})
+	s.AddNewField("validate_policy", &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+		DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+			if old == "" && new == "false" {
+				return true
+			}
+			return old == new
+		},
+	})
 	s.AddNewField("state", &schema.Schema{
 		Type:     schema.TypeString,
 		Computed: true,
@@ -549,10 +599,18 @@ func resourceClusterRead(ctx context.Context, d *schema.ResourceData, c *common.
 	}, clusterSchema, d)
 }
 
+// nonClusterConfigFields are handled by the provider itself and never sent to the clusters API
+var nonClusterConfigFields = map[string]bool{
+	"library":         true,
+	"is_pinned":       true,
+	"no_wait":         true,
+	"restart_policy":  true,
+	"validate_policy": true,
+}
+
 func hasClusterConfigChanged(d *schema.ResourceData) bool {
 	for k := range clusterSchema {
-		// TODO: create a map if we'll add more non-cluster config parameters in the future
-		if k == "library" || k == "is_pinned" || k == "no_wait" {
+		if nonClusterConfigFields[k] {
 			continue
 		}
 		if d.HasChange(k) {
@@ -562,6 +620,66 @@ func hasClusterConfigChanged(d *schema.ResourceData) bool {
 	return false
 }
 
+func getRestartPolicy(d *schema.ResourceData) (mode string, idle time.Duration) {
+	mode = restartPolicyImmediate
+	idle = 10 * time.Minute
+	if v, ok := d.GetOk("restart_policy.0.mode"); ok {
+		mode = v.(string)
+	}
+	if v, ok := d.GetOk("restart_policy.0.idle_minutes"); ok {
+		idle = time.Duration(v.(int)) * time.Minute
+	}
+	return
+}
+
+// isClusterBusy returns true if the cluster had user activity or workload-driven
+// autoscaling within the idle period, so that restarting it would interrupt work.
+func isClusterBusy(a ClustersAPI, clusterID string, idle time.Duration) (bool, error) {
+	ci, err := a.Get(clusterID)
+	if err != nil {
+		return false, err
+	}
+	if !ci.IsRunningOrResizing() {
+		return false, nil
+	}
+	since := time.Now().Add(-idle).UnixMilli()
+	if ci.LastActivityTime > since {
+		return true, nil
+	}
+	events, err := a.Events(EventsRequest{
+		ClusterID:  clusterID,
+		StartTime:  since,
+		Order:      SortDescending,
+		EventTypes: []ClusterEventType{EvTypeResizing, EvTypeUpsizeCompleted},
+	})
+	if err != nil {
+		return false, err
+	}
+	for _, event := range events {
+		if event.Details.ResizeCause != nil && *event.Details.ResizeCause == "AUTOSCALE" {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// waitForClusterIdle blocks until the cluster is idle or the update timeout is reached
+func waitForClusterIdle(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient, idle time.Duration) error {
+	clusterID := d.Id()
+	a := NewClustersAPI(ctx, c)
+	return retry.RetryContext(ctx, d.Timeout(schema.TimeoutUpdate), func() *retry.RetryError {
+		busy, err := isClusterBusy(a, clusterID, idle)
+		if err != nil {
+			return retry.NonRetryableError(err)
+		}
+		if busy {
+			log.Printf("[INFO] Cluster %s is still busy, waiting for it to become idle before restart", clusterID)
+			return retry.RetryableError(fmt.Errorf("cluster %s did not become idle for %s before timeout", clusterID, idle))
+		}
+		return nil
+	})
+}
+
 func resourceClusterUpdate(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 	w, err := c.WorkspaceClient()
 	if err != nil {
@@ -573,6 +691,8 @@ func resourceClusterUpdate(ctx context.Context, d *schema.ResourceData, c *commo
 	clusterId := d.Id()
 	cluster.ClusterId = clusterId
 	var clusterInfo *compute.ClusterDetails
+	// set, if the edit of a running cluster is postponed, so that other changes are still applied
+	var deferred error
 
 	if hasClusterConfigChanged(d) {
 		log.Printf("[DEBUG] Cluster state has changed!")
@@ -593,9 +713,7 @@ func resourceClusterUpdate(ctx context.Context, d *schema.ResourceData, c *commo
 		hasAutoscaleChanged := d.HasChange("autoscale")
 		hasOnlyResizeClusterConfigChanged := true
 		for k := range clusterSchema {
-			if k == "library" ||
-				k == "is_pinned" ||
-				k == "no_wait" ||
+			if nonClusterConfigFields[k] ||
 				k == "num_workers" ||
 				k == "autoscale" {
 				continue
@@ -647,7 +765,28 @@ func resourceClusterUpdate(ctx context.Context, d *schema.ResourceData, c *commo
 				ClusterId: clusterId,
 				Autoscale: cluster.Autoscale,
 			})
+		} else if mode, idle := getRestartPolicy(d); clusterInfo.IsRunningOrResizing() && mode == restartPolicyDefer {
+			// Editing a running cluster always restarts it, so the previous configuration is kept in the state,
+			// and the change stays in the plan until the first apply after the cluster terminates.
+			log.Printf("[WARN] Postponing edit of running cluster %s until it is terminated", clusterId)
+			for k := range clusterSchema {
+				if nonClusterConfigFields[k] || !d.HasChange(k) {
+					continue
+				}
+				old, _ := d.GetChange(k)
+				d.Set(k, old)
+			}
+			deferred = common.Warning{
+				Summary: fmt.Sprintf("Edit of cluster %s is postponed", clusterId),
+				Detail: "The cluster is running and restart_policy.mode is `defer`, so the change stays in the plan " +
+					"and is applied by the first apply after the cluster is terminated.",
+			}
 		} else {
+			if clusterInfo.IsRunningOrResizing() && mode == restartPolicyWhenIdle {
+				if err = waitForClusterIdle(ctx, d, c, idle); err != nil {
+					return err
+				}
+			}
 			SetForceSendFieldsForCluster(&cluster, d)
 
 			err = retry.RetryContext(ctx, 15*time.Minute, func() *retry.RetryError {
@@ -689,7 +828,7 @@ func resourceClusterUpdate(ctx context.Context, d *schema.ResourceData, c *commo
 	oldNumLibs, newNumLibs := d.GetChange("library.#")
 	if oldNumLibs == newNumLibs && oldNumLibs.(int) == 0 {
 		// don't add externally added libraries, if config has no `library {}` blocks
-		return nil
+		return deferred
 	}
 	libsClusterStatus, err := w.Libraries.ClusterStatusByClusterId(ctx, clusterId)
 	if err != nil {
@@ -727,7 +866,7 @@ func resourceClusterUpdate(ctx context.Context, d *schema.ResourceData, c *commo
 			}
 		}
 	}
-	return nil
+	return deferred
 }
 
 func resourceClusterDelete(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
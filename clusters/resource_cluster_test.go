@@ -1,9 +1,11 @@
 package clusters
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/databricks/databricks-sdk-go/service/compute"
 	"github.com/databricks/terraform-provider-databricks/common"
@@ -1020,6 +1022,215 @@ func TestResourceClusterUpdate_EditNumWorkersWhenClusterTerminated(t *testing.T)
 	}.ApplyNoError(t)
 }
 
+func TestResourceClusterUpdate_RestartPolicyDeferOnRunningCluster(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:       "GET",
+				Resource:     "/api/2.1/clusters/get?cluster_id=abc",
+				ReuseRequest: true,
+				Response: compute.ClusterDetails{
+					ClusterId:              "abc",
+					NumWorkers:             100,
+					ClusterName:            "Shared Cluster",
+					SparkVersion:           "7.1-scala12",
+					NodeTypeId:             "i3.xlarge",
+					AutoterminationMinutes: 15,
+					State:                  compute.StateRunning,
+				},
+			},
+			{
+				// changes, that don't restart the cluster, are still applied
+				Method:   "POST",
+				Resource: "/api/2.1/clusters/pin",
+				ExpectedRequest: compute.PinCluster{
+					ClusterId: "abc",
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.1/clusters/events",
+				ExpectedRequest: compute.GetEvents{
+					ClusterId:  "abc",
+					Limit:      1,
+					Order:      compute.GetEventsOrderDesc,
+					EventTypes: []compute.EventType{compute.EventTypePinned, compute.EventTypeUnpinned},
+				},
+				Response: compute.GetEventsResponse{
+					Events: []compute.ClusterEvent{
+						{
+							ClusterId: "abc",
+							Type:      compute.EventTypePinned,
+						},
+					},
+					TotalCount: 1,
+				},
+			},
+		},
+		ID:       "abc",
+		Update:   true,
+		Resource: ResourceCluster(),
+		State: map[string]any{
+			"autotermination_minutes": 15,
+			"cluster_name":            "Shared Cluster",
+			"spark_version":           "7.3-scala12",
+			"node_type_id":            "i3.xlarge",
+			"num_workers":             100,
+			"is_pinned":               true,
+			"restart_policy": []any{
+				map[string]any{
+					"mode": "defer",
+				},
+			},
+		},
+		InstanceState: map[string]string{
+			"autotermination_minutes": "15",
+			"cluster_name":            "Shared Cluster",
+			"spark_version":           "7.1-scala12",
+			"node_type_id":            "i3.xlarge",
+			"num_workers":             "100",
+		},
+	}.ApplyAndExpectData(t, map[string]any{
+		// the edit is postponed, so the change stays in the plan
+		"spark_version": "7.1-scala12",
+		"is_pinned":     true,
+	})
+}
+
+func TestResourceClusterUpdate_RestartPolicyWhenIdle(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:       "GET",
+				Resource:     "/api/2.1/clusters/get?cluster_id=abc",
+				ReuseRequest: true,
+				Response: compute.ClusterDetails{
+					ClusterId:              "abc",
+					NumWorkers:             100,
+					ClusterName:            "Shared Cluster",
+					SparkVersion:           "7.1-scala12",
+					NodeTypeId:             "i3.xlarge",
+					AutoterminationMinutes: 15,
+					State:                  compute.StateRunning,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/clusters/get?cluster_id=abc",
+				Response: ClusterInfo{
+					ClusterID:        "abc",
+					State:            ClusterStateRunning,
+					LastActivityTime: 1,
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/events",
+				Response: EventsResponse{
+					Events: []ClusterEvent{
+						{
+							ClusterID: "abc",
+							Type:      EvTypeResizing,
+							Details: EventDetails{
+								ResizeCause: func() *ResizeCause {
+									cause := ResizeCause("USER_REQUEST")
+									return &cause
+								}(),
+							},
+						},
+					},
+					TotalCount: 1,
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.1/clusters/events",
+				ExpectedRequest: compute.GetEvents{
+					ClusterId:  "abc",
+					Limit:      1,
+					Order:      compute.GetEventsOrderDesc,
+					EventTypes: []compute.EventType{compute.EventTypePinned, compute.EventTypeUnpinned},
+				},
+				Response: compute.GetEventsResponse{
+					Events:     []compute.ClusterEvent{},
+					TotalCount: 0,
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.1/clusters/edit",
+				ExpectedRequest: compute.ClusterDetails{
+					AutoterminationMinutes: 15,
+					ClusterId:              "abc",
+					NumWorkers:             100,
+					ClusterName:            "Shared Cluster",
+					SparkVersion:           "7.3-scala12",
+					NodeTypeId:             "i3.xlarge",
+				},
+			},
+		},
+		ID:       "abc",
+		Update:   true,
+		Resource: ResourceCluster(),
+		State: map[string]any{
+			"autotermination_minutes": 15,
+			"cluster_name":            "Shared Cluster",
+			"spark_version":           "7.3-scala12",
+			"node_type_id":            "i3.xlarge",
+			"num_workers":             100,
+			"restart_policy": []any{
+				map[string]any{
+					"mode": "when_idle",
+				},
+			},
+		},
+		InstanceState: map[string]string{
+			"autotermination_minutes": "15",
+			"cluster_name":            "Shared Cluster",
+			"spark_version":           "7.1-scala12",
+			"node_type_id":            "i3.xlarge",
+			"num_workers":             "100",
+		},
+	}.ApplyNoError(t)
+}
+
+func TestIsClusterBusy(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/clusters/get?cluster_id=abc",
+			Response: ClusterInfo{
+				ClusterID:        "abc",
+				State:            ClusterStateRunning,
+				LastActivityTime: 1,
+			},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/clusters/events",
+			Response: EventsResponse{
+				Events: []ClusterEvent{
+					{
+						ClusterID: "abc",
+						Type:      EvTypeResizing,
+						Details: EventDetails{
+							ResizeCause: func() *ResizeCause {
+								cause := ResizeCause("AUTOSCALE")
+								return &cause
+							}(),
+						},
+					},
+				},
+				TotalCount: 1,
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		busy, err := isClusterBusy(NewClustersAPI(ctx, client), "abc", 10*time.Minute)
+		require.NoError(t, err)
+		assert.True(t, busy)
+	})
+}
+
 func TestResourceClusterUpdate_ResizeAutoscale(t *testing.T) {
 	qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
@@ -2130,3 +2341,36 @@ func TestResourceClusterUpdate_LocalSsdCount(t *testing.T) {
 
 	assert.NoError(t, err)
 }
+
+func TestResourceClusterCreate_PolicyViolations(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/policies/clusters/get?policy_id=abc",
+				Response: compute.Policy{
+					PolicyId: "abc",
+					Name:     "Small clusters",
+					Definition: `{
+						"autotermination_minutes": {"type": "range", "maxValue": 30},
+						"node_type_id": {"type": "allowlist", "values": ["i3.xlarge"]},
+						"custom_tags.team": {"type": "unlimited"}
+					}`,
+				},
+			},
+		},
+		Create:   true,
+		Resource: ResourceCluster(),
+		HCL: `
+		cluster_name    = "Shared Autoscaling"
+		spark_version   = "7.1-scala12"
+		node_type_id    = "i3.2xlarge"
+		num_workers     = 1
+		policy_id       = "abc"
+		validate_policy = true
+		`,
+	}.ExpectError(t, "cluster policy Small clusters (abc) is violated:\n"+
+		"  autotermination_minutes: 60 is greater than the maximum of 30\n"+
+		"  custom_tags.team: is required by the policy\n"+
+		"  node_type_id: i3.2xlarge is not one of the allowed values: i3.xlarge")
+}
@@ -56,11 +56,40 @@ func recoverable(cb func(
 	}
 }
 
+// Warning is returned from Update or Delete to report a warning diagnostic without failing the operation,
+// e.g. to inform about the changes, that were made to other objects while deleting the resource
+type Warning struct {
+	Summary string
+	Detail  string
+}
+
+func (w Warning) Error() string {
+	return fmt.Sprintf("%s: %s", w.Summary, w.Detail)
+}
+
+func (w Warning) diagnostics() diag.Diagnostics {
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  w.Summary,
+		Detail:   w.Detail,
+	}}
+}
+
+type diffClientKey struct{}
+
+// DiffClient returns the client for diff customizations, that explicitly opt into
+// checks against the workspace at plan time. Authentication may not be possible at
+// this stage, so such checks must treat any error as a reason to skip the check.
+func DiffClient(ctx context.Context) (*DatabricksClient, bool) {
+	c, ok := ctx.Value(diffClientKey{}).(*DatabricksClient)
+	return c, ok && c != nil
+}
+
 func (r Resource) saferCustomizeDiff() schema.CustomizeDiffFunc {
 	if r.CustomizeDiff == nil {
 		return nil
 	}
-	return func(ctx context.Context, rd *schema.ResourceDiff, _ any) (err error) {
+	return func(ctx context.Context, rd *schema.ResourceDiff, m any) (err error) {
 		defer func() {
 			// this is deliberate decision to convert a panic into error,
 			// so that any unforeseen bug would we visible to end-user
@@ -73,7 +102,11 @@ func (r Resource) saferCustomizeDiff() schema.CustomizeDiffFunc {
 		}()
 		// we don't propagate instance of SDK client to the diff function, because
 		// authentication is not deterministic at this stage with the recent Terraform
-		// versions. Diff customization must be limited to hermetic checks only anyway.
+		// versions. Diff customization must be limited to hermetic checks only anyway,
+		// except for opt-in checks, that can retrieve the client with DiffClient.
+		if c, ok := m.(*DatabricksClient); ok {
+			ctx = context.WithValue(ctx, diffClientKey{}, c)
+		}
 		err = r.CustomizeDiff(ctx, rd)
 		if err != nil {
 			err = nicerError(ctx, err, "customize diff for")
@@ -90,7 +123,9 @@ func (r Resource) ToResource() *schema.Resource {
 		update = func(ctx context.Context, d *schema.ResourceData,
 			m any) diag.Diagnostics {
 			c := m.(*DatabricksClient)
-			if err := recoverable(r.Update)(ctx, d, c); err != nil {
+			err := recoverable(r.Update)(ctx, d, c)
+			warning, isWarning := err.(Warning)
+			if err != nil && !isWarning {
 				err = nicerError(ctx, err, "update")
 				return diag.FromErr(err)
 			}
@@ -98,6 +133,9 @@ func (r Resource) ToResource() *schema.Resource {
 				err = nicerError(ctx, err, "read")
 				return diag.FromErr(err)
 			}
+			if isWarning {
+				return warning.diagnostics()
+			}
 			return nil
 		}
 	} else {
@@ -172,6 +210,9 @@ func (r Resource) ToResource() *schema.Resource {
 	if r.Delete != nil {
 		resource.DeleteContext = func(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 			err := recoverable(r.Delete)(ctx, d, m.(*DatabricksClient))
+			if warning, ok := err.(Warning); ok {
+				return warning.diagnostics()
+			}
 			if apierr.IsMissing(err) {
 				log.Printf("[INFO] %s[id=%s] is removed on backend",
 					ResourceName.GetOrUnknown(ctx), d.Id())
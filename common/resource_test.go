@@ -87,6 +87,54 @@ func TestHTTP404TriggersResourceRemovalForReadAndDelete(t *testing.T) {
 	assert.Equal(t, "", d.Id())
 }
 
+func TestDeleteWarning(t *testing.T) {
+	r := Resource{
+		Read: func(ctx context.Context, d *schema.ResourceData, c *DatabricksClient) error {
+			return nil
+		},
+		Delete: func(ctx context.Context, d *schema.ResourceData, c *DatabricksClient) error {
+			return Warning{Summary: "moved objects", Detail: "a, b"}
+		},
+		Schema: map[string]*schema.Schema{
+			"foo": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+		},
+	}.ToResource()
+	d := r.TestResourceData()
+	d.SetId("a")
+	diags := r.DeleteContext(context.Background(), d, &DatabricksClient{})
+	assert.False(t, diags.HasError())
+	require.Len(t, diags, 1)
+	assert.Equal(t, "moved objects", diags[0].Summary)
+	assert.Equal(t, "a, b", diags[0].Detail)
+}
+
+func TestUpdateWarning(t *testing.T) {
+	r := Resource{
+		Update: func(ctx context.Context, d *schema.ResourceData, c *DatabricksClient) error {
+			return Warning{Summary: "postponed", Detail: "foo"}
+		},
+		Read: func(ctx context.Context, d *schema.ResourceData, c *DatabricksClient) error {
+			return d.Set("foo", 2)
+		},
+		Schema: map[string]*schema.Schema{
+			"foo": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+		},
+	}.ToResource()
+	d := r.TestResourceData()
+	d.SetId("a")
+	diags := r.UpdateContext(context.Background(), d, &DatabricksClient{})
+	assert.False(t, diags.HasError())
+	require.Len(t, diags, 1)
+	assert.Equal(t, "postponed", diags[0].Summary)
+	assert.Equal(t, 2, d.Get("foo"))
+}
+
 func TestUpdate(t *testing.T) {
 	r := Resource{
 		Update: func(ctx context.Context,
@@ -42,7 +42,7 @@ func (ic *importContext) allServicesAndListing() (string, string) {
 		}
 	}
 	// We need this to specify default listings of UC & Workspace objects...
-	for _, ir := range []string{"uc-schemas", "uc-models", "uc-tables", "uc-volumes",
+	for _, ir := range []string{"uc-schemas", "uc-models", "uc-tables", "uc-volumes", "uc-functions",
 		"notebooks", "directories", "wsfiles"} {
 		listing[ir] = struct{}{}
 	}
@@ -2721,6 +2721,24 @@ var resourcesMap map[string]importable = map[string]importable{
 					}, volume.UpdatedAt, fmt.Sprintf("volume '%s'", volume.FullName))
 				}
 			}
+			if ic.isServiceInListing("uc-functions") {
+				it := ic.workspaceClient.Functions.List(ic.Context,
+					catalog.ListFunctionsRequest{
+						CatalogName: catalogName,
+						SchemaName:  schemaName,
+					})
+				for it.HasNext(ic.Context) {
+					function, err := it.Next(ic.Context)
+					if err != nil {
+						return err // TODO: should we continue?
+					}
+					ic.EmitIfUpdatedAfterMillis(&resource{
+						Resource:  "databricks_function",
+						ID:        function.FullName,
+						DependsOn: dependsOn,
+					}, function.UpdatedAt, fmt.Sprintf("function '%s'", function.FullName))
+				}
+			}
 			if ic.isServiceInListing("uc-tables") {
 				// list tables
 				it := ic.workspaceClient.Tables.List(ic.Context, catalog.ListTablesRequest{
@@ -2802,6 +2820,34 @@ var resourcesMap map[string]importable = map[string]importable{
 				Match: "url", MatchType: MatchLongestPrefix},
 		},
 	},
+	"databricks_function": {
+		WorkspaceLevel: true,
+		Service:        "uc-functions",
+		Import: func(ic *importContext, r *resource) error {
+			functionFullName := r.ID
+			ic.emitUCGrantsWithOwner("function/"+functionFullName, r)
+			schemaFullName := r.Data.Get("catalog_name").(string) + "." + r.Data.Get("schema_name").(string)
+			ic.Emit(&resource{
+				Resource: "databricks_schema",
+				ID:       schemaFullName,
+			})
+			return nil
+		},
+		ShouldOmitField: func(ic *importContext, pathString string, as *schema.Schema, d *schema.ResourceData) bool {
+			// generated from other attributes by the API
+			if strings.HasSuffix(pathString, ".type_json") {
+				return true
+			}
+			return shouldOmitForUnityCatalog(ic, pathString, as, d)
+		},
+		Ignore: generateIgnoreObjectWithEmptyAttributeValue("databricks_function", "name"),
+		Depends: []reference{
+			{Path: "catalog_name", Resource: "databricks_catalog"},
+			{Path: "schema_name", Resource: "databricks_schema", Match: "name",
+				IsValidApproximation: createIsMatchingCatalogAndSchema("catalog_name", "schema_name"),
+				SkipDirectLookup:     true},
+		},
+	},
 	"databricks_sql_table": {
 		WorkspaceLevel: true,
 		Service:        "uc-tables",
@@ -2894,6 +2940,7 @@ var resourcesMap map[string]importable = map[string]importable{
 			{Path: "foreign_connection", Resource: "databricks_connection", Match: "name"},
 			{Path: "metastore", Resource: "databricks_metastore"},
 			{Path: "model", Resource: "databricks_registered_model"},
+			{Path: "function", Resource: "databricks_function"},
 			{Path: "external_location", Resource: "databricks_external_location", Match: "name"},
 			{Path: "storage_credential", Resource: "databricks_storage_credential"},
 			// TODO: add similar matchers for users/groups/SPs on account level...
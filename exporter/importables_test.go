@@ -1819,6 +1819,18 @@ func TestImportSchema(t *testing.T) {
 				},
 			},
 		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.1/unity-catalog/functions?catalog_name=ctest&schema_name=stest",
+			Response: catalog.ListFunctionsResponse{
+				Functions: []catalog.FunctionInfo{
+					{
+						Name:     "function1",
+						FullName: "ctest.stest.function1",
+					},
+				},
+			},
+		},
 		{
 			Method:   "GET",
 			Resource: "/api/2.1/unity-catalog/tables?catalog_name=ctest&schema_name=stest",
@@ -1839,8 +1851,8 @@ func TestImportSchema(t *testing.T) {
 		},
 	}, func(ctx context.Context, client *common.DatabricksClient) {
 		ic := importContextForTestWithClient(ctx, client)
-		ic.enableServices("uc-catalogs,uc-grants,uc-schemas,uc-volumes,uc-models,uc-tables")
-		ic.enableListing("uc-schemas,uc-volumes,uc-models,uc-tables")
+		ic.enableServices("uc-catalogs,uc-grants,uc-schemas,uc-volumes,uc-models,uc-tables,uc-functions")
+		ic.enableListing("uc-schemas,uc-volumes,uc-models,uc-tables,uc-functions")
 		ic.currentMetastore = currentMetastoreResponse
 		d := tfcatalog.ResourceSchema().ToResource().TestResourceData()
 		d.SetId("ctest.stest")
@@ -1851,8 +1863,9 @@ func TestImportSchema(t *testing.T) {
 			Data: d,
 		})
 		assert.NoError(t, err)
-		require.Equal(t, 5, len(ic.testEmits))
+		require.Equal(t, 6, len(ic.testEmits))
 		assert.True(t, ic.testEmits["databricks_grants[<unknown>] (id: schema/ctest.stest)"])
+		assert.True(t, ic.testEmits["databricks_function[<unknown>] (id: ctest.stest.function1)"])
 		assert.True(t, ic.testEmits["databricks_catalog[<unknown>] (id: ctest)"])
 		assert.True(t, ic.testEmits["databricks_registered_model[<unknown>] (id: ctest.stest.model1)"])
 		assert.True(t, ic.testEmits["databricks_volume[<unknown>] (id: ctest.stest.volume1)"])
@@ -0,0 +1,189 @@
+package exporter
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/databricks/terraform-provider-databricks/access"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// terraformState is the subset of Terraform state file, that is needed to read legacy table ACLs
+type terraformState struct {
+	Resources []struct {
+		Mode      string `json:"mode"`
+		Type      string `json:"type"`
+		Instances []struct {
+			Attributes access.SqlPermissions `json:"attributes"`
+		} `json:"instances"`
+	} `json:"resources"`
+}
+
+func sqlPermissionsFromState(content []byte) ([]access.SqlPermissions, error) {
+	var state terraformState
+	err := json.Unmarshal(content, &state)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse Terraform state: %w", err)
+	}
+	result := []access.SqlPermissions{}
+	for _, r := range state.Resources {
+		if r.Mode != "managed" || r.Type != "databricks_sql_permissions" {
+			continue
+		}
+		for _, i := range r.Instances {
+			result = append(result, i.Attributes)
+		}
+	}
+	return result, nil
+}
+
+// parseCatalogMapping parses mapping like `hive_metastore=main,sales=finance`
+func parseCatalogMapping(mapping string) (map[string]string, error) {
+	result := map[string]string{}
+	for _, pair := range strings.Split(mapping, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		split := strings.SplitN(pair, "=", 2)
+		if len(split) != 2 || split[0] == "" || split[1] == "" {
+			return nil, fmt.Errorf("catalog mapping must be in form of `database=catalog`: %s", pair)
+		}
+		result[strings.TrimSpace(split[0])] = strings.TrimSpace(split[1])
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("catalog mapping is required")
+	}
+	return result, nil
+}
+
+// generateUnityCatalogGrants produces databricks_grants resources, that are equivalent to the given
+// legacy table ACLs, and the list of privileges, that can't be translated.
+func generateUnityCatalogGrants(legacy []access.SqlPermissions,
+	catalogMapping map[string]string) ([]byte, []string, error) {
+	type securable struct {
+		securableType string
+		name          string
+	}
+	privileges := map[securable]map[string][]string{}
+	untranslatable := []string{}
+	for _, ta := range legacy {
+		grants, err := ta.ToUnityCatalog(catalogMapping)
+		if err != nil {
+			return nil, nil, err
+		}
+		untranslatable = append(untranslatable, grants.Untranslatable...)
+		if grants.SecurableType == "" {
+			continue
+		}
+		key := securable{grants.SecurableType, grants.Name}
+		if _, ok := privileges[key]; !ok {
+			privileges[key] = map[string][]string{}
+		}
+		// the same Unity Catalog securable may come from multiple legacy resources
+		for _, pa := range grants.PrivilegeAssignments {
+			for _, p := range pa.Privileges {
+				if !slices.Contains(privileges[key][pa.Principal], p) {
+					privileges[key][pa.Principal] = append(privileges[key][pa.Principal], p)
+				}
+			}
+		}
+	}
+	securables := make([]securable, 0, len(privileges))
+	for k := range privileges {
+		securables = append(securables, k)
+	}
+	sort.Slice(securables, func(i, j int) bool {
+		if securables[i].securableType != securables[j].securableType {
+			return securables[i].securableType < securables[j].securableType
+		}
+		return securables[i].name < securables[j].name
+	})
+	f := hclwrite.NewEmptyFile()
+	body := f.Body()
+	for _, u := range untranslatable {
+		body.AppendUnstructuredTokens(hclwrite.Tokens{{
+			Type:  hclsyntax.TokenComment,
+			Bytes: []byte(fmt.Sprintf("# TODO: %s\n", u)),
+		}})
+	}
+	if len(untranslatable) > 0 {
+		body.AppendNewline()
+	}
+	for _, s := range securables {
+		name := nameNormalizationRegex.ReplaceAllString(fmt.Sprintf("%s_%s", s.securableType, s.name), "_")
+		resourceBody := body.AppendNewBlock("resource", []string{"databricks_grants", name}).Body()
+		resourceBody.SetAttributeValue(s.securableType, cty.StringVal(s.name))
+		principals := make([]string, 0, len(privileges[s]))
+		for principal := range privileges[s] {
+			principals = append(principals, principal)
+		}
+		sort.Strings(principals)
+		for _, principal := range principals {
+			grantBody := resourceBody.AppendNewBlock("grant", nil).Body()
+			grantBody.SetAttributeValue("principal", cty.StringVal(principal))
+			values := []cty.Value{}
+			granted := privileges[s][principal]
+			sort.Strings(granted)
+			for _, p := range granted {
+				values = append(values, cty.StringVal(p))
+			}
+			grantBody.SetAttributeValue("privileges", cty.ListVal(values))
+		}
+		body.AppendNewline()
+	}
+	return f.Bytes(), untranslatable, nil
+}
+
+// RunSqlPermissionsMigration generates databricks_grants resources from databricks_sql_permissions
+// resources in the Terraform state.
+func RunSqlPermissionsMigration(args ...string) error {
+	flags := flag.NewFlagSet("migrate-sql-permissions", flag.ExitOnError)
+	var statePath, mapping, output string
+	flags.StringVar(&statePath, "state", "terraform.tfstate",
+		"Terraform state file with databricks_sql_permissions resources.")
+	flags.StringVar(&mapping, "catalog-mapping", "",
+		"Comma-separated mapping of Hive databases to Unity Catalog catalogs, like `hive_metastore=main,sales=finance`. "+
+			"The `hive_metastore` key is used for the catalog itself and for all databases without explicit mapping.")
+	flags.StringVar(&output, "output", "uc_grants.tf", "File to write databricks_grants resources to.")
+	newArgs := args
+	if len(args) > 1 && args[1] == "migrate-sql-permissions" {
+		newArgs = args[2:]
+	}
+	err := flags.Parse(newArgs)
+	if err != nil {
+		return err
+	}
+	catalogMapping, err := parseCatalogMapping(mapping)
+	if err != nil {
+		return err
+	}
+	content, err := os.ReadFile(statePath)
+	if err != nil {
+		return err
+	}
+	legacy, err := sqlPermissionsFromState(content)
+	if err != nil {
+		return err
+	}
+	hcl, untranslatable, err := generateUnityCatalogGrants(legacy, catalogMapping)
+	if err != nil {
+		return err
+	}
+	for _, u := range untranslatable {
+		log.Printf("[WARN] %s", u)
+	}
+	err = os.WriteFile(output, hcl, 0644)
+	if err != nil {
+		return err
+	}
+	log.Printf("[INFO] Written databricks_grants for %d databricks_sql_permissions to %s", len(legacy), output)
+	return nil
+}
@@ -0,0 +1,117 @@
+package exporter
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sqlPermissionsState = `{
+  "version": 4,
+  "resources": [
+    {
+      "mode": "managed",
+      "type": "databricks_sql_permissions",
+      "name": "foo_table",
+      "instances": [
+        {
+          "attributes": {
+            "id": "table/default.foo",
+            "table": "foo",
+            "database": "default",
+            "cluster_id": "abc",
+            "privilege_assignments": [
+              {"principal": "serge@example.com", "privileges": ["SELECT", "MODIFY"]},
+              {"principal": "special group", "privileges": ["SELECT", "MODIFY_CLASSPATH"]}
+            ]
+          }
+        }
+      ]
+    },
+    {
+      "mode": "managed",
+      "type": "databricks_sql_permissions",
+      "name": "sales",
+      "instances": [
+        {
+          "attributes": {
+            "id": "database/sales",
+            "database": "sales",
+            "privilege_assignments": [
+              {"principal": "users", "privileges": ["USAGE", "READ_METADATA"]}
+            ]
+          }
+        }
+      ]
+    },
+    {
+      "mode": "data",
+      "type": "databricks_sql_permissions",
+      "name": "ignored",
+      "instances": []
+    },
+    {
+      "mode": "managed",
+      "type": "databricks_cluster",
+      "name": "ignored",
+      "instances": [{"attributes": {"cluster_id": "abc"}}]
+    }
+  ]
+}`
+
+func TestRunSqlPermissionsMigration(t *testing.T) {
+	dir := t.TempDir()
+	statePath := filepath.Join(dir, "terraform.tfstate")
+	outputPath := filepath.Join(dir, "uc_grants.tf")
+	err := os.WriteFile(statePath, []byte(sqlPermissionsState), 0644)
+	require.NoError(t, err)
+
+	err = RunSqlPermissionsMigration("terraform-provider-databricks", "migrate-sql-permissions",
+		"-state", statePath, "-output", outputPath, "-catalog-mapping", "hive_metastore=main,sales=finance")
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(outputPath)
+	require.NoError(t, err)
+	assert.Equal(t, `# TODO: MODIFY_CLASSPATH of special group on table/default.foo has no equivalent in Unity Catalog
+# TODO: READ_METADATA of users on database/sales has no equivalent in Unity Catalog, as BROWSE can only be granted on the whole catalog
+# TODO: privileges of `+"`users`"+` on database/sales are granted to `+"`account users`"+`, that has all users of the account and not only the ones of the workspace
+
+resource "databricks_grants" "schema_finance_sales" {
+  schema = "finance.sales"
+  grant {
+    principal  = "account users"
+    privileges = ["USE_SCHEMA"]
+  }
+}
+
+resource "databricks_grants" "table_main_default_foo" {
+  table = "main.default.foo"
+  grant {
+    principal  = "serge@example.com"
+    privileges = ["MODIFY", "SELECT"]
+  }
+  grant {
+    principal  = "special group"
+    privileges = ["SELECT"]
+  }
+}
+
+`, string(content))
+}
+
+func TestRunSqlPermissionsMigration_Errors(t *testing.T) {
+	_, err := parseCatalogMapping("")
+	assert.EqualError(t, err, "catalog mapping is required")
+
+	_, err = parseCatalogMapping("main")
+	assert.EqualError(t, err, "catalog mapping must be in form of `database=catalog`: main")
+
+	_, err = sqlPermissionsFromState([]byte("{"))
+	assert.ErrorContains(t, err, "cannot parse Terraform state")
+
+	err = RunSqlPermissionsMigration("-catalog-mapping", "hive_metastore=main", "-state", "/non/existing")
+	assert.Error(t, err)
+}
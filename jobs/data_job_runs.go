@@ -0,0 +1,182 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/databricks/databricks-sdk-go"
+	"github.com/databricks/databricks-sdk-go/service/jobs"
+	"github.com/databricks/terraform-provider-databricks/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+type JobRunTaskInfo struct {
+	TaskKey        string                 `json:"task_key"`
+	RunId          int64                  `json:"run_id,omitempty"`
+	LifeCycleState jobs.RunLifeCycleState `json:"life_cycle_state,omitempty"`
+	ResultState    jobs.RunResultState    `json:"result_state,omitempty"`
+	StateMessage   string                 `json:"state_message,omitempty"`
+	StartTime      int64                  `json:"start_time,omitempty"`
+	EndTime        int64                  `json:"end_time,omitempty"`
+	AttemptNumber  int                    `json:"attempt_number,omitempty"`
+}
+
+type JobRunInfo struct {
+	RunId                int64                  `json:"run_id"`
+	JobId                int64                  `json:"job_id,omitempty"`
+	RunName              string                 `json:"run_name,omitempty"`
+	RunType              jobs.RunType           `json:"run_type,omitempty"`
+	Trigger              jobs.TriggerType       `json:"trigger,omitempty"`
+	LifeCycleState       jobs.RunLifeCycleState `json:"life_cycle_state,omitempty"`
+	ResultState          jobs.RunResultState    `json:"result_state,omitempty"`
+	StateMessage         string                 `json:"state_message,omitempty"`
+	StartTime            int64                  `json:"start_time,omitempty"`
+	EndTime              int64                  `json:"end_time,omitempty"`
+	Duration             int64                  `json:"duration,omitempty"`
+	RunPageUrl           string                 `json:"run_page_url,omitempty"`
+	JobParameters        map[string]string      `json:"job_parameters,omitempty"`
+	OverridingParameters *jobs.RunParameters    `json:"overriding_parameters,omitempty"`
+	Tasks                []JobRunTaskInfo       `json:"tasks,omitempty"`
+}
+
+type jobRunsData struct {
+	Id            string                `json:"id,omitempty" tf:"computed"`
+	JobId         int64                 `json:"job_id,omitempty" tf:"computed"`
+	JobName       string                `json:"job_name,omitempty"`
+	ActiveOnly    bool                  `json:"active_only,omitempty"`
+	CompletedOnly bool                  `json:"completed_only,omitempty"`
+	ResultStates  []jobs.RunResultState `json:"result_states,omitempty"`
+	RunType       jobs.RunType          `json:"run_type,omitempty"`
+	StartTime     string                `json:"start_time,omitempty"`
+	EndTime       string                `json:"end_time,omitempty"`
+	MaxItems      int                   `json:"max_items,omitempty" tf:"default:25"`
+	Runs          []JobRunInfo          `json:"runs,omitempty" tf:"computed"`
+}
+
+func parseRunsTime(name, value string) (int64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return 0, fmt.Errorf("%s must be in RFC3339 format: %w", name, err)
+	}
+	return t.UnixMilli(), nil
+}
+
+// runDuration returns the duration of the run in milliseconds. Multitask runs
+// report it in run_duration, while single-task runs only report the durations
+// of the setup, execution and cleanup phases.
+func runDuration(run jobs.BaseRun) int64 {
+	if run.RunDuration > 0 {
+		return run.RunDuration
+	}
+	return run.SetupDuration + run.ExecutionDuration + run.CleanupDuration
+}
+
+func newJobRunInfo(run jobs.BaseRun) JobRunInfo {
+	info := JobRunInfo{
+		RunId:                run.RunId,
+		JobId:                run.JobId,
+		RunName:              run.RunName,
+		RunType:              run.RunType,
+		Trigger:              run.Trigger,
+		StartTime:            run.StartTime,
+		EndTime:              run.EndTime,
+		Duration:             runDuration(run),
+		RunPageUrl:           run.RunPageUrl,
+		OverridingParameters: run.OverridingParameters,
+	}
+	if run.State != nil {
+		info.LifeCycleState = run.State.LifeCycleState
+		info.ResultState = run.State.ResultState
+		info.StateMessage = run.State.StateMessage
+	}
+	if len(run.JobParameters) > 0 {
+		info.JobParameters = map[string]string{}
+		for _, p := range run.JobParameters {
+			value := p.Value
+			if value == "" {
+				value = p.Default
+			}
+			info.JobParameters[p.Name] = value
+		}
+	}
+	for _, task := range run.Tasks {
+		taskInfo := JobRunTaskInfo{
+			TaskKey:       task.TaskKey,
+			RunId:         task.RunId,
+			StartTime:     task.StartTime,
+			EndTime:       task.EndTime,
+			AttemptNumber: task.AttemptNumber,
+		}
+		if task.State != nil {
+			taskInfo.LifeCycleState = task.State.LifeCycleState
+			taskInfo.ResultState = task.State.ResultState
+			taskInfo.StateMessage = task.State.StateMessage
+		}
+		info.Tasks = append(info.Tasks, taskInfo)
+	}
+	return info
+}
+
+func DataSourceJobRuns() common.Resource {
+	return common.WorkspaceDataWithCustomizeFunc(func(ctx context.Context, data *jobRunsData, w *databricks.WorkspaceClient) error {
+		startTime, err := parseRunsTime("start_time", data.StartTime)
+		if err != nil {
+			return err
+		}
+		endTime, err := parseRunsTime("end_time", data.EndTime)
+		if err != nil {
+			return err
+		}
+		if data.JobName != "" {
+			found, err := w.Jobs.ListAll(ctx, jobs.ListJobsRequest{Name: data.JobName})
+			if err != nil {
+				return err
+			}
+			if len(found) != 1 {
+				return fmt.Errorf("expected exactly one job named '%s', found %d", data.JobName, len(found))
+			}
+			data.JobId = found[0].JobId
+		}
+		it := w.Jobs.ListRuns(ctx, jobs.ListRunsRequest{
+			JobId:         data.JobId,
+			ActiveOnly:    data.ActiveOnly,
+			CompletedOnly: data.CompletedOnly,
+			RunType:       data.RunType,
+			StartTimeFrom: startTime,
+			StartTimeTo:   endTime,
+			ExpandTasks:   true,
+		})
+		runs := []JobRunInfo{}
+		for it.HasNext(ctx) && len(runs) < data.MaxItems {
+			run, err := it.Next(ctx)
+			if err != nil {
+				return err
+			}
+			if len(data.ResultStates) > 0 && (run.State == nil ||
+				!slices.Contains(data.ResultStates, run.State.ResultState)) {
+				continue
+			}
+			runs = append(runs, newJobRunInfo(run))
+		}
+		data.Runs = runs
+		if data.JobId != 0 {
+			data.Id = fmt.Sprintf("%d", data.JobId)
+		} else {
+			data.Id = "_"
+		}
+		return nil
+	}, func(s map[string]*schema.Schema) map[string]*schema.Schema {
+		common.CustomizeSchemaPath(s, "job_id").SetOptional().SetConflictsWith([]string{"job_name"})
+		common.CustomizeSchemaPath(s, "job_name").SetConflictsWith([]string{"job_id"})
+		common.CustomizeSchemaPath(s, "active_only").SetConflictsWith([]string{"completed_only"})
+		common.CustomizeSchemaPath(s, "completed_only").SetConflictsWith([]string{"active_only"})
+		common.CustomizeSchemaPath(s, "max_items").SetValidateFunc(validation.IntBetween(1, 1000))
+		return s
+	})
+}
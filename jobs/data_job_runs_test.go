@@ -0,0 +1,180 @@
+package jobs
+
+import (
+	"testing"
+
+	"github.com/databricks/databricks-sdk-go/service/jobs"
+	"github.com/databricks/terraform-provider-databricks/qa"
+)
+
+func TestJobRunsDataSource(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/jobs/list?name=First",
+				Response: jobs.ListJobsResponse{
+					Jobs: []jobs.BaseJob{
+						{
+							JobId: 123,
+							Settings: &jobs.JobSettings{
+								Name: "First",
+							},
+						},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/jobs/runs/list?completed_only=true&expand_tasks=true&job_id=123&start_time_from=1704067200000",
+				Response: jobs.ListRunsResponse{
+					Runs: []jobs.BaseRun{
+						{
+							JobId:       123,
+							RunId:       3,
+							RunType:     jobs.RunTypeJobRun,
+							Trigger:     jobs.TriggerTypePeriodic,
+							StartTime:   1704067300000,
+							EndTime:     1704067360000,
+							RunDuration: 60000,
+							State: &jobs.RunState{
+								LifeCycleState: jobs.RunLifeCycleStateTerminated,
+								ResultState:    jobs.RunResultStateSuccess,
+							},
+							JobParameters: []jobs.JobParameter{
+								{Name: "env", Default: "dev", Value: "prod"},
+								{Name: "date", Default: "today"},
+							},
+							Tasks: []jobs.RunTask{
+								{
+									TaskKey: "ingest",
+									RunId:   4,
+									State: &jobs.RunState{
+										LifeCycleState: jobs.RunLifeCycleStateTerminated,
+										ResultState:    jobs.RunResultStateSuccess,
+									},
+								},
+							},
+						},
+						{
+							JobId:             123,
+							RunId:             2,
+							SetupDuration:     1000,
+							ExecutionDuration: 2000,
+							State: &jobs.RunState{
+								LifeCycleState: jobs.RunLifeCycleStateInternalError,
+								ResultState:    jobs.RunResultStateFailed,
+							},
+						},
+						{
+							JobId: 123,
+							RunId: 1,
+							State: &jobs.RunState{
+								LifeCycleState: jobs.RunLifeCycleStateTerminated,
+								ResultState:    jobs.RunResultStateSuccess,
+							},
+						},
+					},
+				},
+			},
+		},
+		Resource:    DataSourceJobRuns(),
+		NonWritable: true,
+		Read:        true,
+		ID:          "_",
+		HCL: `
+		job_name       = "First"
+		completed_only = true
+		start_time     = "2024-01-01T00:00:00Z"
+		max_items      = 2
+		`,
+	}.ApplyAndExpectData(t, map[string]any{
+		"id":                          "123",
+		"job_id":                      123,
+		"runs.#":                      2,
+		"runs.0.run_id":               3,
+		"runs.0.result_state":         "SUCCESS",
+		"runs.0.duration":             60000,
+		"runs.0.trigger":              "PERIODIC",
+		"runs.0.job_parameters.env":   "prod",
+		"runs.0.job_parameters.date":  "today",
+		"runs.0.tasks.0.task_key":     "ingest",
+		"runs.0.tasks.0.result_state": "SUCCESS",
+		"runs.1.run_id":               2,
+		"runs.1.result_state":         "FAILED",
+		"runs.1.duration":             3000,
+		"runs.1.life_cycle_state":     "INTERNAL_ERROR",
+	})
+}
+
+func TestJobRunsDataSource_ResultStates(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/jobs/runs/list?expand_tasks=true&job_id=123",
+				Response: jobs.ListRunsResponse{
+					Runs: []jobs.BaseRun{
+						{
+							RunId: 2,
+							State: &jobs.RunState{
+								ResultState: jobs.RunResultStateSuccess,
+							},
+						},
+						{
+							RunId: 1,
+							State: &jobs.RunState{
+								ResultState: jobs.RunResultStateFailed,
+							},
+						},
+						{
+							RunId: 0,
+						},
+					},
+				},
+			},
+		},
+		Resource:    DataSourceJobRuns(),
+		NonWritable: true,
+		Read:        true,
+		ID:          "_",
+		HCL: `
+		job_id        = 123
+		result_states = ["FAILED", "TIMEDOUT"]
+		`,
+	}.ApplyAndExpectData(t, map[string]any{
+		"runs.#":        1,
+		"runs.0.run_id": 1,
+	})
+}
+
+func TestJobRunsDataSource_JobNotFound(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/jobs/list?name=Unknown",
+				Response: jobs.ListJobsResponse{},
+			},
+		},
+		Resource:    DataSourceJobRuns(),
+		NonWritable: true,
+		Read:        true,
+		ID:          "_",
+		HCL:         `job_name = "Unknown"`,
+	}.ExpectError(t, "expected exactly one job named 'Unknown', found 0")
+}
+
+func TestJobRunsDataSource_InvalidTime(t *testing.T) {
+	qa.ResourceFixture{
+		Resource:    DataSourceJobRuns(),
+		NonWritable: true,
+		Read:        true,
+		ID:          "_",
+		HCL: `
+		job_id     = 123
+		start_time = "last week"
+		`,
+	}.ExpectError(t, "start_time must be in RFC3339 format: "+
+		"parsing time \"last week\" as \"2006-01-02T15:04:05Z07:00\": cannot parse \"last week\" as \"2006\"")
+}
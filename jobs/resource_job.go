@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -21,6 +22,7 @@ import (
 	"github.com/databricks/databricks-sdk-go/apierr"
 	"github.com/databricks/terraform-provider-databricks/clusters"
 	"github.com/databricks/terraform-provider-databricks/common"
+	"github.com/databricks/terraform-provider-databricks/policies"
 	"github.com/databricks/terraform-provider-databricks/repos"
 )
 
@@ -386,6 +388,7 @@ type RunParameters struct {
 	JobID int64 `json:"job_id,omitempty"`
 
 	NotebookParams    map[string]string `json:"notebook_params,omitempty"`
+	JobParameters     map[string]string `json:"job_parameters,omitempty"`
 	JarParams         []string          `json:"jar_params,omitempty"`
 	PythonParams      []string          `json:"python_params,omitempty"`
 	SparkSubmitParams []string          `json:"spark_submit_params,omitempty"`
@@ -405,15 +408,24 @@ type RunState struct {
 	StateMessage   string `json:"state_message,omitempty"`
 }
 
+// JobRunTask is a simplified representation of a task run within a job run
+type JobRunTask struct {
+	TaskKey string   `json:"task_key,omitempty"`
+	RunID   int64    `json:"run_id,omitempty"`
+	State   RunState `json:"state,omitempty"`
+}
+
 // JobRun is a simplified representation of corresponding entity
 type JobRun struct {
-	JobID       int64    `json:"job_id,omitempty"`
-	RunID       int64    `json:"run_id,omitempty"`
-	NumberInJob int64    `json:"number_in_job,omitempty"`
-	StartTime   int64    `json:"start_time,omitempty"`
-	State       RunState `json:"state,omitempty"`
-	Trigger     string   `json:"trigger,omitempty"`
-	RuntType    string   `json:"run_type,omitempty"`
+	JobID       int64        `json:"job_id,omitempty"`
+	RunID       int64        `json:"run_id,omitempty"`
+	NumberInJob int64        `json:"number_in_job,omitempty"`
+	StartTime   int64        `json:"start_time,omitempty"`
+	State       RunState     `json:"state,omitempty"`
+	Trigger     string       `json:"trigger,omitempty"`
+	RuntType    string       `json:"run_type,omitempty"`
+	RunPageURL  string       `json:"run_page_url,omitempty"`
+	Tasks       []JobRunTask `json:"tasks,omitempty"`
 
 	OverridingParameters RunParameters  `json:"overriding_parameters,omitempty"`
 	JobParameters        []JobParameter `json:"job_parameters,omitempty"`
@@ -562,9 +574,64 @@ func (JobSettingsResource) CustomizeSchema(s *common.CustomizableSchema) *common
 		Optional: true,
 		Default:  false,
 		Type:     schema.TypeBool,
+	}).AddNewField("validate_cluster_policies", &schema.Schema{
+		Optional: true,
+		Default:  false,
+		Type:     schema.TypeBool,
+	}).AddNewField("run_on_apply", &schema.Schema{
+		Optional: true,
+		Type:     schema.TypeList,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"run_trigger": {
+					Optional: true,
+					Type:     schema.TypeString,
+				},
+				"job_parameters": {
+					Optional: true,
+					Type:     schema.TypeMap,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+				"notebook_params": {
+					Optional: true,
+					Type:     schema.TypeMap,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+			},
+		},
+	}).AddNewField("last_apply_run", &schema.Schema{
+		Computed: true,
+		Type:     schema.TypeList,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"run_id": {
+					Computed: true,
+					Type:     schema.TypeInt,
+				},
+				"result_state": {
+					Computed: true,
+					Type:     schema.TypeString,
+				},
+				"state_message": {
+					Computed: true,
+					Type:     schema.TypeString,
+				},
+				"run_page_url": {
+					Computed: true,
+					Type:     schema.TypeString,
+				},
+				"task_outputs": {
+					Computed: true,
+					Type:     schema.TypeMap,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+			},
+		},
 	})
 
 	s.SchemaPath("always_running").SetConflictsWith([]string{"control_run_state", "continuous"})
+	s.SchemaPath("run_on_apply").SetConflictsWith([]string{"always_running", "continuous"})
 	s.SchemaPath("control_run_state").SetConflictsWith([]string{"always_running"})
 
 	s.SchemaPath("schedule").SetConflictsWith([]string{"continuous", "trigger"})
@@ -700,6 +767,11 @@ func (a JobsAPI) RunsCancel(runID int64, timeout time.Duration) error {
 }
 
 func (a JobsAPI) waitForRunState(runID int64, desiredState string, timeout time.Duration) error {
+	return a.waitForRunStateOrFail(runID, desiredState, []string{"INTERNAL_ERROR"}, timeout)
+}
+
+// waitForRunStateOrFail waits for the desired state and fails as soon as the run reaches one of the failure states
+func (a JobsAPI) waitForRunStateOrFail(runID int64, desiredState string, failureStates []string, timeout time.Duration) error {
 	return resource.RetryContext(a.context, timeout, func() *resource.RetryError {
 		jobRun, err := a.RunsGet(runID)
 		if err != nil {
@@ -710,7 +782,7 @@ func (a JobsAPI) waitForRunState(runID int64, desiredState string, timeout time.
 		if state.LifeCycleState == desiredState {
 			return nil
 		}
-		if state.LifeCycleState == "INTERNAL_ERROR" {
+		if slices.Contains(failureStates, state.LifeCycleState) {
 			return resource.NonRetryableError(
 				fmt.Errorf("cannot get job %s: %s",
 					desiredState, state.StateMessage))
@@ -724,10 +796,15 @@ func (a JobsAPI) waitForRunState(runID int64, desiredState string, timeout time.
 
 // RunNow triggers the job and returns a run ID
 func (a JobsAPI) RunNow(jobID int64) (int64, error) {
-	var jr JobRun
-	err := a.client.Post(a.context, "/jobs/run-now", RunParameters{
+	return a.RunNowWithParameters(RunParameters{
 		JobID: jobID,
-	}, &jr)
+	})
+}
+
+// RunNowWithParameters triggers the job with overriding parameters and returns a run ID
+func (a JobsAPI) RunNowWithParameters(params RunParameters) (int64, error) {
+	var jr JobRun
+	err := a.client.Post(a.context, "/jobs/run-now", params, &jr)
 	return jr.RunID, err
 }
 
@@ -1015,6 +1092,99 @@ func (c controlRunStateLifecycleManager) OnUpdate(ctx context.Context) error {
 	return api.StopActiveRun(jobID, c.d.Timeout(schema.TimeoutUpdate))
 }
 
+// changeGetter is a generalization between schema.ResourceDiff & schema.ResourceData
+type changeGetter interface {
+	Get(key string) any
+	GetChange(key string) (any, any)
+	HasChange(key string) bool
+}
+
+// shouldRunOnApply returns true if the job has to be triggered by `run_on_apply`: on creation,
+// when the block is added to an existing job, or when `run_trigger` changes.
+func shouldRunOnApply(d changeGetter, isNew bool) bool {
+	if d.Get("run_on_apply.#").(int) == 0 {
+		return false
+	}
+	if isNew {
+		return true
+	}
+	old, _ := d.GetChange("run_on_apply.#")
+	return old.(int) == 0 || d.HasChange("run_on_apply.0.run_trigger")
+}
+
+func runOutputText(output *jobs.RunOutput) string {
+	switch {
+	case output.Error != "":
+		return output.Error
+	case output.NotebookOutput != nil:
+		return output.NotebookOutput.Result
+	default:
+		return output.Logs
+	}
+}
+
+// runOnApply triggers a one-shot run of the job and waits for it to finish. The apply fails,
+// if the run doesn't succeed, so that resources depending on the job aren't created.
+func runOnApply(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient, timeout time.Duration) error {
+	jobID, err := parseJobId(d.Id())
+	if err != nil {
+		return err
+	}
+	params := RunParameters{
+		JobID:          jobID,
+		JobParameters:  map[string]string{},
+		NotebookParams: map[string]string{},
+	}
+	for k, v := range d.Get("run_on_apply.0.job_parameters").(map[string]any) {
+		params.JobParameters[k] = v.(string)
+	}
+	for k, v := range d.Get("run_on_apply.0.notebook_params").(map[string]any) {
+		params.NotebookParams[k] = v.(string)
+	}
+	api := NewJobsAPI(context.WithValue(ctx, common.Api, common.API_2_1), c)
+	runID, err := api.RunNowWithParameters(params)
+	if err != nil {
+		return fmt.Errorf("cannot start run on apply: %w", err)
+	}
+	// a skipped run never terminates, e.g. when another run of the job is active
+	err = api.waitForRunStateOrFail(runID, "TERMINATED", []string{"INTERNAL_ERROR", "SKIPPED"}, timeout)
+	if err != nil {
+		return fmt.Errorf("run %d didn't finish: %w", runID, err)
+	}
+	run, err := api.RunsGet(runID)
+	if err != nil {
+		return err
+	}
+	w, err := c.WorkspaceClient()
+	if err != nil {
+		return err
+	}
+	taskOutputs := map[string]string{}
+	for _, task := range run.Tasks {
+		output, err := w.Jobs.GetRunOutput(ctx, jobs.GetRunOutputRequest{
+			RunId: task.RunID,
+		})
+		if err != nil {
+			return fmt.Errorf("cannot get output of task %s: %w", task.TaskKey, err)
+		}
+		taskOutputs[task.TaskKey] = runOutputText(output)
+	}
+	d.Set("last_apply_run", []any{
+		map[string]any{
+			"run_id":        runID,
+			"result_state":  run.State.ResultState,
+			"state_message": run.State.StateMessage,
+			"run_page_url":  run.RunPageURL,
+			"task_outputs":  taskOutputs,
+		},
+	})
+	if run.State.ResultState != "SUCCESS" {
+		return fmt.Errorf("run %d finished with %s: %s. See %s for details",
+			runID, run.State.ResultState, run.State.StateMessage, run.RunPageURL)
+	}
+	return nil
+}
+
 func prepareJobSettingsForUpdate(d *schema.ResourceData, js JobSettings) {
 	if js.NewCluster != nil {
 		js.NewCluster.ModifyRequestOnInstancePool()
@@ -1036,6 +1206,33 @@ func prepareJobSettingsForUpdate(d *schema.ResourceData, js JobSettings) {
 
 var jobsGoSdkSchema = common.StructToSchema(JobSettingsResource{}, nil)
 
+// validateClusterPolicies checks all job, task and legacy job clusters against their
+// cluster policies and reports violations of all of them at once.
+func validateClusterPolicies(ctx context.Context, d *schema.ResourceDiff, js JobSettingsResource) error {
+	var errs []error
+	validate := func(tfPrefix string, scm map[string]*schema.Schema, cluster compute.ClusterSpec) {
+		err := policies.ValidateClusterPolicy(ctx, d, tfPrefix, scm, policies.ClusterTypeJob, cluster)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for i, jc := range js.JobClusters {
+		validate(fmt.Sprintf("job_cluster.%d.new_cluster.0.", i),
+			common.MustSchemaMap(jobsGoSdkSchema, "job_cluster", "new_cluster"), jc.NewCluster)
+	}
+	for i, task := range js.Tasks {
+		if task.NewCluster == nil {
+			continue
+		}
+		validate(fmt.Sprintf("task.%d.new_cluster.0.", i),
+			common.MustSchemaMap(jobsGoSdkSchema, "task", "new_cluster"), *task.NewCluster)
+	}
+	if js.NewCluster != nil {
+		validate("new_cluster.0.", common.MustSchemaMap(jobsGoSdkSchema, "new_cluster"), *js.NewCluster)
+	}
+	return errors.Join(errs...)
+}
+
 func ResourceJob() common.Resource {
 	getReadCtx := func(ctx context.Context, d *schema.ResourceData) context.Context {
 		var js JobSettingsResource
@@ -1059,6 +1256,11 @@ func ResourceJob() common.Resource {
 			if alwaysRunning && js.MaxConcurrentRuns > 1 {
 				return fmt.Errorf("`always_running` must be specified only with `max_concurrent_runs = 1`")
 			}
+			if shouldRunOnApply(d, d.Id() == "") {
+				if err := d.SetNewComputed("last_apply_run"); err != nil {
+					return err
+				}
+			}
 			controlRunState := d.Get("control_run_state").(bool)
 			if controlRunState {
 				if js.Continuous == nil {
@@ -1081,6 +1283,9 @@ func ResourceJob() common.Resource {
 					return fmt.Errorf("invalid job cluster: %w", err)
 				}
 			}
+			if d.Get("validate_cluster_policies").(bool) {
+				return validateClusterPolicies(ctx, d, js)
+			}
 			return nil
 		},
 		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
@@ -1103,7 +1308,11 @@ func ResourceJob() common.Resource {
 					return err
 				}
 				d.SetId(fmt.Sprintf("%d", jobId))
-				return getJobLifecycleManagerGoSdk(d, c).OnCreate(ctx)
+				err = getJobLifecycleManagerGoSdk(d, c).OnCreate(ctx)
+				if err != nil || !shouldRunOnApply(d, true) {
+					return err
+				}
+				return runOnApply(ctx, d, c, d.Timeout(schema.TimeoutCreate))
 			} else {
 				// Api 2.0
 				// TODO: Deprecate and remove this code path
@@ -1113,7 +1322,11 @@ func ResourceJob() common.Resource {
 					return err
 				}
 				d.SetId(job.ID())
-				return getJobLifecycleManager(d, c).OnCreate(ctx)
+				err = getJobLifecycleManager(d, c).OnCreate(ctx)
+				if err != nil || !shouldRunOnApply(d, true) {
+					return err
+				}
+				return runOnApply(ctx, d, c, d.Timeout(schema.TimeoutCreate))
 			}
 		},
 		Read: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
@@ -1171,7 +1384,10 @@ func ResourceJob() common.Resource {
 				if err != nil {
 					return err
 				}
-				return getJobLifecycleManagerGoSdk(d, c).OnUpdate(ctx)
+				err = getJobLifecycleManagerGoSdk(d, c).OnUpdate(ctx)
+				if err != nil {
+					return err
+				}
 			} else {
 				// Api 2.0
 				// TODO: Deprecate and remove this code path
@@ -1185,8 +1401,20 @@ func ResourceJob() common.Resource {
 				if err != nil {
 					return err
 				}
-				return getJobLifecycleManager(d, c).OnUpdate(ctx)
+				err = getJobLifecycleManager(d, c).OnUpdate(ctx)
+				if err != nil {
+					return err
+				}
+			}
+			if !shouldRunOnApply(d, false) {
+				return nil
+			}
+			err := runOnApply(ctx, d, c, d.Timeout(schema.TimeoutUpdate))
+			if err != nil {
+				// keep the previous `run_trigger` in the state, so that the run is retried on the next apply
+				d.Partial(true)
 			}
+			return err
 		},
 		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 			ctx = getReadCtx(ctx, d)
@@ -1246,6 +1246,266 @@ func TestResourceJobCreate_AlwaysRunning(t *testing.T) {
 	assert.Equal(t, "789", d.Id())
 }
 
+func TestResourceJobCreate_RunOnApply(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/jobs/create",
+				ExpectedRequest: JobSettings{
+					ExistingClusterID: "abc",
+					NotebookTask: &NotebookTask{
+						NotebookPath: "/Shared/migrate",
+					},
+					Name:              "Migrations",
+					MaxConcurrentRuns: 1,
+				},
+				Response: Job{
+					JobID: 789,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/jobs/get?job_id=789",
+				Response: Job{
+					JobID: 789,
+					Settings: &JobSettings{
+						ExistingClusterID: "abc",
+						NotebookTask: &NotebookTask{
+							NotebookPath: "/Shared/migrate",
+						},
+						Name: "Migrations",
+					},
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.1/jobs/run-now",
+				ExpectedRequest: RunParameters{
+					JobID: 789,
+					NotebookParams: map[string]string{
+						"version": "42",
+					},
+				},
+				Response: JobRun{
+					RunID: 890,
+				},
+			},
+			{
+				Method:       "GET",
+				Resource:     "/api/2.1/jobs/runs/get?run_id=890",
+				ReuseRequest: true,
+				Response: JobRun{
+					RunID:      890,
+					RunPageURL: "https://example.com/#job/789/run/890",
+					State: RunState{
+						LifeCycleState: "TERMINATED",
+						ResultState:    "SUCCESS",
+					},
+					Tasks: []JobRunTask{
+						{
+							TaskKey: "migrate",
+							RunID:   891,
+						},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/jobs/runs/get-output?run_id=891",
+				Response: jobs.RunOutput{
+					NotebookOutput: &jobs.NotebookOutput{
+						Result: "applied 3 migrations",
+					},
+				},
+			},
+		},
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `existing_cluster_id = "abc"
+		name = "Migrations"
+		notebook_task {
+			notebook_path = "/Shared/migrate"
+		}
+		run_on_apply {
+			run_trigger = "v42"
+			notebook_params = {
+				version = "42"
+			}
+		}
+		`,
+	}.ApplyAndExpectData(t, map[string]any{
+		"id":                                    "789",
+		"last_apply_run.0.run_id":               890,
+		"last_apply_run.0.result_state":         "SUCCESS",
+		"last_apply_run.0.task_outputs.migrate": "applied 3 migrations",
+	})
+}
+
+func TestResourceJobUpdate_RunOnApplyFails(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/jobs/reset",
+				ExpectedRequest: UpdateJobRequest{
+					JobID: 789,
+					NewSettings: &JobSettings{
+						Name:              "Migrations",
+						MaxConcurrentRuns: 1,
+					},
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.1/jobs/run-now",
+				ExpectedRequest: RunParameters{
+					JobID: 789,
+					JobParameters: map[string]string{
+						"env": "prod",
+					},
+				},
+				Response: JobRun{
+					RunID: 890,
+				},
+			},
+			{
+				Method:       "GET",
+				Resource:     "/api/2.1/jobs/runs/get?run_id=890",
+				ReuseRequest: true,
+				Response: JobRun{
+					RunID:      890,
+					RunPageURL: "https://example.com/#job/789/run/890",
+					State: RunState{
+						LifeCycleState: "TERMINATED",
+						ResultState:    "FAILED",
+						StateMessage:   "Task migrate failed",
+					},
+				},
+			},
+		},
+		ID:       "789",
+		Update:   true,
+		Resource: ResourceJob(),
+		InstanceState: map[string]string{
+			"name":                       "Migrations",
+			"run_on_apply.#":             "1",
+			"run_on_apply.0.run_trigger": "v1",
+		},
+		HCL: `
+		name = "Migrations"
+		run_on_apply {
+			run_trigger = "v2"
+			job_parameters = {
+				env = "prod"
+			}
+		}
+		`,
+	}.ExpectError(t, "run 890 finished with FAILED: Task migrate failed. See https://example.com/#job/789/run/890 for details")
+}
+
+func TestResourceJobUpdate_RunOnApplySkipped(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/jobs/reset",
+				ExpectedRequest: UpdateJobRequest{
+					JobID: 789,
+					NewSettings: &JobSettings{
+						Name:              "Migrations",
+						MaxConcurrentRuns: 1,
+					},
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.1/jobs/run-now",
+				ExpectedRequest: RunParameters{
+					JobID: 789,
+					JobParameters: map[string]string{
+						"env": "prod",
+					},
+				},
+				Response: JobRun{
+					RunID: 890,
+				},
+			},
+			{
+				Method:       "GET",
+				Resource:     "/api/2.1/jobs/runs/get?run_id=890",
+				ReuseRequest: true,
+				Response: JobRun{
+					RunID:      890,
+					State: RunState{
+						LifeCycleState: "SKIPPED",
+						StateMessage:   "The run was skipped, because the job has an active run",
+					},
+				},
+			},
+		},
+		ID:       "789",
+		Update:   true,
+		Resource: ResourceJob(),
+		InstanceState: map[string]string{
+			"name":                       "Migrations",
+			"run_on_apply.#":             "1",
+			"run_on_apply.0.run_trigger": "v1",
+		},
+		HCL: `
+		name = "Migrations"
+		run_on_apply {
+			run_trigger = "v2"
+			job_parameters = {
+				env = "prod"
+			}
+		}
+		`,
+	}.ExpectError(t, "run 890 didn't finish: cannot get job TERMINATED: The run was skipped, because the job has an active run")
+}
+
+func TestResourceJobUpdate_RunOnApplyNotTriggered(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/jobs/reset",
+				ExpectedRequest: UpdateJobRequest{
+					JobID: 789,
+					NewSettings: &JobSettings{
+						Name:              "Migrations New",
+						MaxConcurrentRuns: 1,
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/jobs/get?job_id=789",
+				Response: Job{
+					JobID: 789,
+					Settings: &JobSettings{
+						Name: "Migrations New",
+					},
+				},
+			},
+		},
+		ID:       "789",
+		Update:   true,
+		Resource: ResourceJob(),
+		InstanceState: map[string]string{
+			"name":                       "Migrations",
+			"run_on_apply.#":             "1",
+			"run_on_apply.0.run_trigger": "v1",
+		},
+		HCL: `
+		name = "Migrations New"
+		run_on_apply {
+			run_trigger = "v1"
+		}
+		`,
+	}.ApplyNoError(t)
+}
+
 func TestResourceJobCreate_AlwaysRunning_Conflict(t *testing.T) {
 	qa.ResourceFixture{
 		Create:   true,
@@ -3072,3 +3332,54 @@ func TestJobResource_SparkConfDiffSuppress(t *testing.T) {
 	assert.True(t, scs.DiffSuppressFunc("new_cluster.0.spark_conf.%", "1", "0", nil))
 	assert.False(t, scs.DiffSuppressFunc("new_cluster.0.spark_conf.%", "1", "1", nil))
 }
+
+func TestResourceJobCreate_ClusterPolicyViolations(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:       "GET",
+				Resource:     "/api/2.0/policies/clusters/get?policy_id=abc",
+				ReuseRequest: true,
+				Response: compute.Policy{
+					PolicyId: "abc",
+					Name:     "Jobs",
+					Definition: `{
+						"cluster_type": {"type": "fixed", "value": "job"},
+						"num_workers": {"type": "range", "maxValue": 8},
+						"spark_version": {"type": "regex", "pattern": "1[45]\\..*"}
+					}`,
+				},
+			},
+		},
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `
+		name                      = "JobClustered"
+		validate_cluster_policies = true
+
+		job_cluster {
+			job_cluster_key = "j"
+			new_cluster {
+				num_workers   = 7
+				spark_version = "15.4.x-scala2.12"
+				node_type_id  = "c"
+				policy_id     = "abc"
+			}
+		}
+
+		task {
+			task_key = "a"
+			new_cluster {
+				num_workers   = 9
+				spark_version = "13.3.x-scala2.12"
+				node_type_id  = "c"
+				policy_id     = "abc"
+			}
+			notebook_task {
+				notebook_path = "/Stuff"
+			}
+		}`,
+	}.ExpectError(t, "cluster policy Jobs (abc) is violated:\n"+
+		"  task.0.new_cluster.0.num_workers: 9 is greater than the maximum of 8\n"+
+		"  task.0.new_cluster.0.spark_version: 13.3.x-scala2.12 doesn't match pattern 1[45]\\..*")
+}
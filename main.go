@@ -9,6 +9,7 @@ import (
 	"github.com/databricks/terraform-provider-databricks/common"
 	"github.com/databricks/terraform-provider-databricks/exporter"
 	"github.com/databricks/terraform-provider-databricks/internal/providers"
+	"github.com/databricks/terraform-provider-databricks/scim"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6/tf6server"
 )
@@ -35,6 +36,22 @@ func main() {
 		return
 	}
 
+	if len(os.Args) > 1 && os.Args[1] == "migrate-sql-permissions" {
+		if err := exporter.RunSqlPermissionsMigration(os.Args...); err != nil {
+			log.Printf("[ERROR] %s", err.Error())
+			os.Exit(1)
+		}
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "identity-sync" {
+		if err := scim.RunIdentitySync(os.Args...); err != nil {
+			log.Printf("[ERROR] %s", err.Error())
+			os.Exit(1)
+		}
+		return
+	}
+
 	log.Printf(startMessageFormat, common.Version())
 
 	ctx := context.Background()
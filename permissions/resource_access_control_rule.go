@@ -0,0 +1,195 @@
+package permissions
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/databricks/databricks-sdk-go/service/iam"
+	"github.com/databricks/terraform-provider-databricks/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// maximum number of attempts to modify the rule set, that is concurrently changed by others
+const ruleSetModifyAttempts = 5
+
+// AccessControlRule is a single grant of a role to a principal on a service principal or a group
+type AccessControlRule struct {
+	ServicePrincipal string `json:"service_principal,omitempty" tf:"force_new"`
+	GroupID          string `json:"group_id,omitempty" tf:"force_new"`
+	AccountID        string `json:"account_id,omitempty" tf:"force_new,computed"`
+	Role             string `json:"role" tf:"force_new"`
+	Principal        string `json:"principal" tf:"force_new"`
+	RuleSetName      string `json:"rule_set_name,omitempty" tf:"computed"`
+}
+
+func (r AccessControlRule) ruleSetName() string {
+	if r.ServicePrincipal != "" {
+		return fmt.Sprintf("accounts/%s/servicePrincipals/%s/ruleSets/default", r.AccountID, r.ServicePrincipal)
+	}
+	return fmt.Sprintf("accounts/%s/groups/%s/ruleSets/default", r.AccountID, r.GroupID)
+}
+
+func (r AccessControlRule) id() string {
+	return fmt.Sprintf("%s|%s|%s", r.RuleSetName, r.Role, r.Principal)
+}
+
+func parseAccessControlRuleID(id string) (ruleSetName, role, principal string, err error) {
+	split := strings.SplitN(id, "|", 3)
+	if len(split) != 3 {
+		err = fmt.Errorf("invalid ID: %s", id)
+		return
+	}
+	return split[0], split[1], split[2], nil
+}
+
+// withPrincipal adds principal to the rule with the given role, leaving other rules untouched
+func withPrincipal(rules []iam.GrantRule, role, principal string) []iam.GrantRule {
+	for i, rule := range rules {
+		if rule.Role != role {
+			continue
+		}
+		if !slices.Contains(rule.Principals, principal) {
+			rules[i].Principals = append(rule.Principals, principal)
+		}
+		return rules
+	}
+	return append(rules, iam.GrantRule{
+		Role:       role,
+		Principals: []string{principal},
+	})
+}
+
+// withoutPrincipal removes principal from the rule with the given role and drops the rule, if it's empty
+func withoutPrincipal(rules []iam.GrantRule, role, principal string) []iam.GrantRule {
+	result := []iam.GrantRule{}
+	for _, rule := range rules {
+		if rule.Role == role {
+			rule.Principals = slices.DeleteFunc(slices.Clone(rule.Principals), func(p string) bool {
+				return p == principal
+			})
+			if len(rule.Principals) == 0 {
+				continue
+			}
+		}
+		result = append(result, rule)
+	}
+	return result
+}
+
+// modifyRuleSet applies the change to the latest version of the rule set, and retries with the newer etag,
+// if the rule set was concurrently modified.
+func modifyRuleSet(ctx context.Context, c *common.DatabricksClient, name string,
+	modify func([]iam.GrantRule) []iam.GrantRule) error {
+	var err error
+	for attempt := 0; attempt < ruleSetModifyAttempts; attempt++ {
+		var ruleSet *iam.RuleSetResponse
+		ruleSet, err = readFromWsOrAcc(ctx, c, iam.GetRuleSetRequest{
+			Name: name,
+			Etag: "",
+		})
+		if err != nil {
+			return err
+		}
+		_, err = updateThroughWsOrAcc(ctx, c, iam.UpdateRuleSetRequest{
+			Name: name,
+			RuleSet: iam.RuleSetUpdateRequest{
+				Name:       name,
+				Etag:       ruleSet.Etag,
+				GrantRules: modify(ruleSet.GrantRules),
+			},
+		})
+		if !isRuleSetConflict(err) {
+			return err
+		}
+	}
+	return err
+}
+
+// ResourceAccessControlRule manages a single grant rule, leaving other rules of the same rule set untouched
+func ResourceAccessControlRule() common.Resource {
+	s := common.StructToSchema(AccessControlRule{},
+		func(m map[string]*schema.Schema) map[string]*schema.Schema {
+			for _, field := range []string{"service_principal", "group_id"} {
+				common.CustomizeSchemaPath(m, field).SetExactlyOneOf([]string{"service_principal", "group_id"})
+			}
+			return m
+		})
+	return common.Resource{
+		Schema: s,
+		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			var rule AccessControlRule
+			common.DataToStructPointer(d, s, &rule)
+			if rule.AccountID == "" {
+				rule.AccountID = c.Config.AccountID
+			}
+			if rule.AccountID == "" {
+				return fmt.Errorf("account_id must be set either in the resource or in the provider configuration")
+			}
+			rule.RuleSetName = rule.ruleSetName()
+			err := modifyRuleSet(ctx, c, rule.RuleSetName, func(rules []iam.GrantRule) []iam.GrantRule {
+				return withPrincipal(rules, rule.Role, rule.Principal)
+			})
+			if err != nil {
+				return err
+			}
+			d.Set("account_id", rule.AccountID)
+			d.Set("rule_set_name", rule.RuleSetName)
+			d.SetId(rule.id())
+			return nil
+		},
+		Read: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			ruleSetName, role, principal, err := parseAccessControlRuleID(d.Id())
+			if err != nil {
+				return err
+			}
+			ruleSet, err := readFromWsOrAcc(ctx, c, iam.GetRuleSetRequest{
+				Name: ruleSetName,
+				Etag: "",
+			})
+			if err != nil {
+				return err
+			}
+			found := false
+			for _, rule := range ruleSet.GrantRules {
+				if rule.Role == role && slices.Contains(rule.Principals, principal) {
+					found = true
+				}
+			}
+			if !found {
+				d.SetId("")
+				return nil
+			}
+			// accounts/<account_id>/(servicePrincipals|groups)/<id>/ruleSets/default
+			parts := strings.Split(ruleSetName, "/")
+			if len(parts) != 6 {
+				return fmt.Errorf("unsupported rule set: %s", ruleSetName)
+			}
+			rule := AccessControlRule{
+				AccountID:   parts[1],
+				Role:        role,
+				Principal:   principal,
+				RuleSetName: ruleSetName,
+			}
+			switch parts[2] {
+			case "servicePrincipals":
+				rule.ServicePrincipal = parts[3]
+			case "groups":
+				rule.GroupID = parts[3]
+			default:
+				return fmt.Errorf("unsupported rule set: %s", ruleSetName)
+			}
+			return common.StructToData(rule, s, d)
+		},
+		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			ruleSetName, role, principal, err := parseAccessControlRuleID(d.Id())
+			if err != nil {
+				return err
+			}
+			return modifyRuleSet(ctx, c, ruleSetName, func(rules []iam.GrantRule) []iam.GrantRule {
+				return withoutPrincipal(rules, role, principal)
+			})
+		},
+	}
+}
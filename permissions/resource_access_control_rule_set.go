@@ -12,78 +12,85 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
-func ResourceAccessControlRuleSet() common.Resource {
-	s := common.StructToSchema(
-		iam.RuleSetUpdateRequest{},
-		func(m map[string]*schema.Schema) map[string]*schema.Schema {
-			m["etag"].Required = false
-			m["etag"].Computed = true
-			m["grant_rules"].Type = schema.TypeSet
-			common.MustSchemaPath(m, "grant_rules", "principals").Type = schema.TypeSet
-
-			return m
-		})
-	readFromWsOrAcc := func(ctx context.Context, c *common.DatabricksClient, getRuleSetReq iam.GetRuleSetRequest) (*iam.RuleSetResponse, error) {
-		if c.Config.AccountID != "" {
-			accountClient, err := c.AccountClient()
-			if err != nil {
-				return nil, err
-			}
-			return accountClient.AccessControl.GetRuleSet(ctx, getRuleSetReq)
-		}
-		workspaceClient, err := c.WorkspaceClient()
+func readFromWsOrAcc(ctx context.Context, c *common.DatabricksClient, getRuleSetReq iam.GetRuleSetRequest) (*iam.RuleSetResponse, error) {
+	if c.Config.AccountID != "" {
+		accountClient, err := c.AccountClient()
 		if err != nil {
 			return nil, err
 		}
-		return workspaceClient.AccountAccessControlProxy.GetRuleSet(ctx, getRuleSetReq)
+		return accountClient.AccessControl.GetRuleSet(ctx, getRuleSetReq)
 	}
-	updateThroughWsOrAcc := func(ctx context.Context, c *common.DatabricksClient, updateRuleSetReq iam.UpdateRuleSetRequest) (*iam.RuleSetResponse, error) {
-		if c.Config.AccountID != "" {
-			accountClient, err := c.AccountClient()
-			if err != nil {
-				return nil, err
-			}
-			return accountClient.AccessControl.UpdateRuleSet(ctx, updateRuleSetReq)
-		}
-		workspaceClient, err := c.WorkspaceClient()
+	workspaceClient, err := c.WorkspaceClient()
+	if err != nil {
+		return nil, err
+	}
+	return workspaceClient.AccountAccessControlProxy.GetRuleSet(ctx, getRuleSetReq)
+}
+
+func updateThroughWsOrAcc(ctx context.Context, c *common.DatabricksClient, updateRuleSetReq iam.UpdateRuleSetRequest) (*iam.RuleSetResponse, error) {
+	if c.Config.AccountID != "" {
+		accountClient, err := c.AccountClient()
 		if err != nil {
 			return nil, err
 		}
-		return workspaceClient.AccountAccessControlProxy.UpdateRuleSet(ctx, updateRuleSetReq)
+		return accountClient.AccessControl.UpdateRuleSet(ctx, updateRuleSetReq)
 	}
-	fetchLatestEtagAndUpdateRuleSet := func(ctx context.Context, c *common.DatabricksClient, ruleSetUpdateReq iam.UpdateRuleSetRequest) (string, error) {
-		ruleSetGetRes, err := readFromWsOrAcc(ctx, c, iam.GetRuleSetRequest{
-			Name: ruleSetUpdateReq.Name,
-			Etag: "",
-		})
-		if err != nil {
-			return "", err
-		}
-		ruleSetUpdateReq.RuleSet.Etag = ruleSetGetRes.Etag
-		ruleSetUpdateRes, err := updateThroughWsOrAcc(ctx, c, ruleSetUpdateReq)
-		if err != nil {
-			return "", err
-		}
-		return ruleSetUpdateRes.Etag, nil
+	workspaceClient, err := c.WorkspaceClient()
+	if err != nil {
+		return nil, err
 	}
-	handleConflictAndUpdate := func(ctx context.Context, c *common.DatabricksClient, ruleSetUpdateReq iam.UpdateRuleSetRequest) (string, error) {
-		ruleSetUpdateRes, err := updateThroughWsOrAcc(ctx, c, ruleSetUpdateReq)
-		if err != nil {
-			var aerr *apierr.APIError
-			if !errors.As(err, &aerr) {
-				return "", err
-			}
-			if aerr.StatusCode == http.StatusConflict {
-				if aerr.ErrorCode == "RESOURCE_CONFLICT" {
-					// we need to get and update
-					etag, err := fetchLatestEtagAndUpdateRuleSet(ctx, c, ruleSetUpdateReq)
-					return etag, err
-				}
-			}
-			return "", err
+	return workspaceClient.AccountAccessControlProxy.UpdateRuleSet(ctx, updateRuleSetReq)
+}
+
+func fetchLatestEtagAndUpdateRuleSet(ctx context.Context, c *common.DatabricksClient, ruleSetUpdateReq iam.UpdateRuleSetRequest) (string, error) {
+	ruleSetGetRes, err := readFromWsOrAcc(ctx, c, iam.GetRuleSetRequest{
+		Name: ruleSetUpdateReq.Name,
+		Etag: "",
+	})
+	if err != nil {
+		return "", err
+	}
+	ruleSetUpdateReq.RuleSet.Etag = ruleSetGetRes.Etag
+	ruleSetUpdateRes, err := updateThroughWsOrAcc(ctx, c, ruleSetUpdateReq)
+	if err != nil {
+		return "", err
+	}
+	return ruleSetUpdateRes.Etag, nil
+}
+
+func handleConflictAndUpdate(ctx context.Context, c *common.DatabricksClient, ruleSetUpdateReq iam.UpdateRuleSetRequest) (string, error) {
+	ruleSetUpdateRes, err := updateThroughWsOrAcc(ctx, c, ruleSetUpdateReq)
+	if err != nil {
+		if isRuleSetConflict(err) {
+			// we need to get and update
+			etag, err := fetchLatestEtagAndUpdateRuleSet(ctx, c, ruleSetUpdateReq)
+			return etag, err
 		}
-		return ruleSetUpdateRes.Etag, err
+		return "", err
+	}
+	return ruleSetUpdateRes.Etag, err
+}
+
+// isRuleSetConflict is true when the rule set was modified since its etag was read
+func isRuleSetConflict(err error) bool {
+	var aerr *apierr.APIError
+	if !errors.As(err, &aerr) {
+		return false
 	}
+	return aerr.StatusCode == http.StatusConflict && aerr.ErrorCode == "RESOURCE_CONFLICT"
+}
+
+func ResourceAccessControlRuleSet() common.Resource {
+	s := common.StructToSchema(
+		iam.RuleSetUpdateRequest{},
+		func(m map[string]*schema.Schema) map[string]*schema.Schema {
+			m["etag"].Required = false
+			m["etag"].Computed = true
+			m["grant_rules"].Type = schema.TypeSet
+			common.MustSchemaPath(m, "grant_rules", "principals").Type = schema.TypeSet
+
+			return m
+		})
 	return common.Resource{
 		Schema: s,
 		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
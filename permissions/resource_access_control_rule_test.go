@@ -0,0 +1,309 @@
+package permissions
+
+import (
+	"testing"
+
+	"github.com/databricks/databricks-sdk-go/service/iam"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/databricks/terraform-provider-databricks/common"
+	"github.com/databricks/terraform-provider-databricks/qa"
+)
+
+var testAccessControlRuleID = testServicePrincipalRuleSetName + "|roles/servicePrincipal.user|groups/data"
+
+func TestAccessControlRuleWithPrincipal(t *testing.T) {
+	rules := []iam.GrantRule{
+		{Role: "roles/servicePrincipal.manager", Principals: []string{"users/a"}},
+		{Role: "roles/servicePrincipal.user", Principals: []string{"users/b"}},
+	}
+	assert.Equal(t, []iam.GrantRule{
+		{Role: "roles/servicePrincipal.manager", Principals: []string{"users/a"}},
+		{Role: "roles/servicePrincipal.user", Principals: []string{"users/b", "users/c"}},
+	}, withPrincipal(rules, "roles/servicePrincipal.user", "users/c"))
+	assert.Equal(t, []iam.GrantRule{
+		{Role: "roles/servicePrincipal.user", Principals: []string{"users/b"}},
+	}, withPrincipal([]iam.GrantRule{
+		{Role: "roles/servicePrincipal.user", Principals: []string{"users/b"}},
+	}, "roles/servicePrincipal.user", "users/b"))
+	assert.Equal(t, []iam.GrantRule{
+		{Role: "roles/group.manager", Principals: []string{"users/a"}},
+	}, withPrincipal(nil, "roles/group.manager", "users/a"))
+}
+
+func TestAccessControlRuleWithoutPrincipal(t *testing.T) {
+	assert.Equal(t, []iam.GrantRule{
+		{Role: "roles/servicePrincipal.user", Principals: []string{"users/b"}},
+	}, withoutPrincipal([]iam.GrantRule{
+		{Role: "roles/servicePrincipal.manager", Principals: []string{"users/a"}},
+		{Role: "roles/servicePrincipal.user", Principals: []string{"users/b"}},
+	}, "roles/servicePrincipal.manager", "users/a"))
+}
+
+func TestResourceAccessControlRuleCreate(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: getResourceName(testServicePrincipalRuleSetName, ""),
+				Response: iam.RuleSetResponse{
+					Name: testServicePrincipalRuleSetName,
+					Etag: "etagEx=",
+					GrantRules: []iam.GrantRule{
+						{
+							Principals: []string{"users/abc@example.com"},
+							Role:       "roles/servicePrincipal.manager",
+						},
+						{
+							Principals: []string{"groups/other"},
+							Role:       "roles/servicePrincipal.user",
+						},
+					},
+				},
+			},
+			{
+				Method:   "PUT",
+				Resource: ruleSetApiPath,
+				ExpectedRequest: iam.UpdateRuleSetRequest{
+					Name: testServicePrincipalRuleSetName,
+					RuleSet: iam.RuleSetUpdateRequest{
+						Name: testServicePrincipalRuleSetName,
+						Etag: "etagEx=",
+						GrantRules: []iam.GrantRule{
+							{
+								Principals: []string{"users/abc@example.com"},
+								Role:       "roles/servicePrincipal.manager",
+							},
+							{
+								Principals: []string{"groups/other", "groups/data"},
+								Role:       "roles/servicePrincipal.user",
+							},
+						},
+					},
+				},
+				Response: iam.RuleSetResponse{
+					Name: testServicePrincipalRuleSetName,
+					Etag: "etagEx2=",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: getResourceName(testServicePrincipalRuleSetName, ""),
+				Response: iam.RuleSetResponse{
+					Name: testServicePrincipalRuleSetName,
+					Etag: "etagEx2=",
+					GrantRules: []iam.GrantRule{
+						{
+							Principals: []string{"groups/other", "groups/data"},
+							Role:       "roles/servicePrincipal.user",
+						},
+					},
+				},
+			},
+		},
+		Resource: ResourceAccessControlRule(),
+		Create:   true,
+		HCL: `
+		account_id        = "cb376b18-60fa-4058-b2cd-dd85acf63165"
+		service_principal = "1686b74b-a611-4360-8feb-3ef226ad1145"
+		role              = "roles/servicePrincipal.user"
+		principal         = "groups/data"
+		`,
+	}.ApplyAndExpectData(t, map[string]any{
+		"id":                testAccessControlRuleID,
+		"rule_set_name":     testServicePrincipalRuleSetName,
+		"service_principal": testServicePrincipalId,
+	})
+}
+
+func TestResourceAccessControlRuleCreate_Conflict(t *testing.T) {
+	groupRuleSetName := "accounts/" + testAccountId + "/groups/123/ruleSets/default"
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: getResourceName(groupRuleSetName, ""),
+				Response: iam.RuleSetResponse{
+					Name: groupRuleSetName,
+					Etag: "etagEx=",
+				},
+			},
+			{
+				Method:   "PUT",
+				Resource: ruleSetApiPath,
+				ExpectedRequest: iam.UpdateRuleSetRequest{
+					Name: groupRuleSetName,
+					RuleSet: iam.RuleSetUpdateRequest{
+						Name: groupRuleSetName,
+						Etag: "etagEx=",
+						GrantRules: []iam.GrantRule{
+							{
+								Principals: []string{"users/abc@example.com"},
+								Role:       "roles/group.manager",
+							},
+						},
+					},
+				},
+				Response: common.APIErrorBody{
+					ErrorCode: "RESOURCE_CONFLICT",
+					Message:   "Conflict with another RuleSet operation",
+				},
+				Status: 409,
+			},
+			{
+				Method:   "GET",
+				Resource: getResourceName(groupRuleSetName, ""),
+				Response: iam.RuleSetResponse{
+					Name: groupRuleSetName,
+					Etag: "etagEx2=",
+					GrantRules: []iam.GrantRule{
+						{
+							Principals: []string{"users/other@example.com"},
+							Role:       "roles/group.manager",
+						},
+					},
+				},
+			},
+			{
+				Method:   "PUT",
+				Resource: ruleSetApiPath,
+				ExpectedRequest: iam.UpdateRuleSetRequest{
+					Name: groupRuleSetName,
+					RuleSet: iam.RuleSetUpdateRequest{
+						Name: groupRuleSetName,
+						Etag: "etagEx2=",
+						GrantRules: []iam.GrantRule{
+							{
+								Principals: []string{"users/other@example.com", "users/abc@example.com"},
+								Role:       "roles/group.manager",
+							},
+						},
+					},
+				},
+				Response: iam.RuleSetResponse{
+					Name: groupRuleSetName,
+					Etag: "etagEx3=",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: getResourceName(groupRuleSetName, ""),
+				Response: iam.RuleSetResponse{
+					Name: groupRuleSetName,
+					Etag: "etagEx3=",
+					GrantRules: []iam.GrantRule{
+						{
+							Principals: []string{"users/other@example.com", "users/abc@example.com"},
+							Role:       "roles/group.manager",
+						},
+					},
+				},
+			},
+		},
+		Resource: ResourceAccessControlRule(),
+		Create:   true,
+		HCL: `
+		account_id = "cb376b18-60fa-4058-b2cd-dd85acf63165"
+		group_id   = "123"
+		role       = "roles/group.manager"
+		principal  = "users/abc@example.com"
+		`,
+	}.ApplyAndExpectData(t, map[string]any{
+		"id":       groupRuleSetName + "|roles/group.manager|users/abc@example.com",
+		"group_id": "123",
+	})
+}
+
+func TestResourceAccessControlRuleCreate_NoAccountID(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceAccessControlRule(),
+		Create:   true,
+		HCL: `
+		group_id  = "123"
+		role      = "roles/group.manager"
+		principal = "users/abc@example.com"
+		`,
+	}.ExpectError(t, "account_id must be set either in the resource or in the provider configuration")
+}
+
+func TestResourceAccessControlRuleRead_Removed(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: getResourceName(testServicePrincipalRuleSetName, ""),
+				Response: iam.RuleSetResponse{
+					Name: testServicePrincipalRuleSetName,
+					Etag: "etagEx=",
+					GrantRules: []iam.GrantRule{
+						{
+							Principals: []string{"groups/other"},
+							Role:       "roles/servicePrincipal.user",
+						},
+					},
+				},
+			},
+		},
+		Resource: ResourceAccessControlRule(),
+		Read:     true,
+		Removed:  true,
+		ID:       testAccessControlRuleID,
+	}.ApplyNoError(t)
+}
+
+func TestResourceAccessControlRuleRead_InvalidID(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceAccessControlRule(),
+		Read:     true,
+		ID:       "abc",
+	}.ExpectError(t, "invalid ID: abc")
+}
+
+func TestResourceAccessControlRuleDelete(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: getResourceName(testServicePrincipalRuleSetName, ""),
+				Response: iam.RuleSetResponse{
+					Name: testServicePrincipalRuleSetName,
+					Etag: "etagEx=",
+					GrantRules: []iam.GrantRule{
+						{
+							Principals: []string{"users/abc@example.com"},
+							Role:       "roles/servicePrincipal.manager",
+						},
+						{
+							Principals: []string{"groups/data"},
+							Role:       "roles/servicePrincipal.user",
+						},
+					},
+				},
+			},
+			{
+				Method:   "PUT",
+				Resource: ruleSetApiPath,
+				ExpectedRequest: iam.UpdateRuleSetRequest{
+					Name: testServicePrincipalRuleSetName,
+					RuleSet: iam.RuleSetUpdateRequest{
+						Name: testServicePrincipalRuleSetName,
+						Etag: "etagEx=",
+						GrantRules: []iam.GrantRule{
+							{
+								Principals: []string{"users/abc@example.com"},
+								Role:       "roles/servicePrincipal.manager",
+							},
+						},
+					},
+				},
+				Response: iam.RuleSetResponse{
+					Name: testServicePrincipalRuleSetName,
+					Etag: "etagEx2=",
+				},
+			},
+		},
+		Resource: ResourceAccessControlRule(),
+		Delete:   true,
+		ID:       testAccessControlRuleID,
+	}.ApplyNoError(t)
+}
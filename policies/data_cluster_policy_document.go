@@ -0,0 +1,190 @@
+package policies
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/databricks/databricks-sdk-go/service/compute"
+	"github.com/databricks/terraform-provider-databricks/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var clusterSpecSchema = common.StructToSchema(compute.ClusterSpec{}, nil)
+
+// virtualPolicyAttributes are not part of cluster specification, but can be used in policies
+var virtualPolicyAttributes = map[string]schema.ValueType{
+	"cluster_type":          schema.TypeString,
+	"cluster_log_conf.type": schema.TypeString,
+	"cluster_log_conf.path": schema.TypeString,
+	"dbus_per_hour":         schema.TypeFloat,
+}
+
+type clusterPolicyDocumentRule struct {
+	Path         string   `json:"path"`
+	Type         string   `json:"type"`
+	Value        string   `json:"value,omitempty"`
+	Values       []string `json:"values,omitempty"`
+	DefaultValue string   `json:"default_value,omitempty"`
+	Pattern      string   `json:"pattern,omitempty"`
+	MinValue     string   `json:"min_value,omitempty"`
+	MaxValue     string   `json:"max_value,omitempty"`
+	IsOptional   bool     `json:"is_optional,omitempty"`
+	Hidden       bool     `json:"hidden,omitempty"`
+}
+
+// policyAttributeType returns the type of a primitive attribute of cluster specification
+func policyAttributeType(path string) (schema.ValueType, error) {
+	if t, ok := virtualPolicyAttributes[path]; ok {
+		return t, nil
+	}
+	_, s, ok := policyPathSchema(clusterSpecSchema, path)
+	if !ok {
+		return schema.TypeInvalid, fmt.Errorf("unknown attribute path")
+	}
+	switch s.Type {
+	case schema.TypeString, schema.TypeInt, schema.TypeFloat, schema.TypeBool:
+		return s.Type, nil
+	}
+	return schema.TypeInvalid, fmt.Errorf("policies can only be defined for primitive attributes")
+}
+
+// typedPolicyValue converts the string value of a rule into JSON type of the attribute
+func typedPolicyValue(t schema.ValueType, v string) (any, error) {
+	switch t {
+	case schema.TypeInt, schema.TypeFloat:
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s is not a number", v)
+		}
+		return f, nil
+	case schema.TypeBool:
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("%s is not a boolean", v)
+		}
+		return b, nil
+	}
+	return v, nil
+}
+
+func (r clusterPolicyDocumentRule) toPolicyRule() (PolicyRule, error) {
+	t, err := policyAttributeType(r.Path)
+	if err != nil {
+		return PolicyRule{}, err
+	}
+	rule := PolicyRule{
+		Type:       r.Type,
+		Pattern:    r.Pattern,
+		IsOptional: r.IsOptional,
+		Hidden:     r.Hidden,
+	}
+	isLimiting := false
+	switch r.Type {
+	case PolicyRuleFixed:
+		if r.Value == "" {
+			return rule, fmt.Errorf("value is required for fixed rules")
+		}
+	case PolicyRuleForbidden:
+	case PolicyRuleAllowlist, PolicyRuleBlocklist:
+		if len(r.Values) == 0 {
+			return rule, fmt.Errorf("values are required for %s rules", r.Type)
+		}
+		isLimiting = true
+	case PolicyRuleRegex:
+		if r.Pattern == "" {
+			return rule, fmt.Errorf("pattern is required for regex rules")
+		}
+		if _, err := regexp.Compile(r.Pattern); err != nil {
+			return rule, fmt.Errorf("invalid pattern: %w", err)
+		}
+		isLimiting = true
+	case PolicyRuleRange:
+		if r.MinValue == "" && r.MaxValue == "" {
+			return rule, fmt.Errorf("min_value or max_value is required for range rules")
+		}
+		if t != schema.TypeInt && t != schema.TypeFloat {
+			return rule, fmt.Errorf("range rules can only be used for numeric attributes")
+		}
+		isLimiting = true
+	case PolicyRuleUnlimited:
+		isLimiting = true
+	default:
+		return rule, fmt.Errorf("unknown rule type %s", r.Type)
+	}
+	if r.Value != "" && r.Type != PolicyRuleFixed {
+		return rule, fmt.Errorf("value can only be used with fixed rules")
+	}
+	if len(r.Values) > 0 && r.Type != PolicyRuleAllowlist && r.Type != PolicyRuleBlocklist {
+		return rule, fmt.Errorf("values can only be used with allowlist and blocklist rules")
+	}
+	if r.Pattern != "" && r.Type != PolicyRuleRegex {
+		return rule, fmt.Errorf("pattern can only be used with regex rules")
+	}
+	if (r.MinValue != "" || r.MaxValue != "") && r.Type != PolicyRuleRange {
+		return rule, fmt.Errorf("min_value and max_value can only be used with range rules")
+	}
+	if (r.DefaultValue != "" || r.IsOptional) && !isLimiting {
+		return rule, fmt.Errorf("default_value and is_optional can only be used with limiting rules")
+	}
+	if r.Value != "" {
+		if rule.Value, err = typedPolicyValue(t, r.Value); err != nil {
+			return rule, err
+		}
+	}
+	for _, v := range r.Values {
+		typed, err := typedPolicyValue(t, v)
+		if err != nil {
+			return rule, err
+		}
+		rule.Values = append(rule.Values, typed)
+	}
+	if r.DefaultValue != "" {
+		if rule.DefaultValue, err = typedPolicyValue(t, r.DefaultValue); err != nil {
+			return rule, err
+		}
+	}
+	for _, bound := range []struct {
+		value  string
+		target **float64
+	}{{r.MinValue, &rule.MinValue}, {r.MaxValue, &rule.MaxValue}} {
+		if bound.value == "" {
+			continue
+		}
+		f, err := strconv.ParseFloat(bound.value, 64)
+		if err != nil {
+			return rule, fmt.Errorf("%s is not a number", bound.value)
+		}
+		*bound.target = &f
+	}
+	return rule, nil
+}
+
+// DataSourceClusterPolicyDocument builds JSON definition of a cluster policy from typed rules
+func DataSourceClusterPolicyDocument() common.Resource {
+	type clusterPolicyDocument struct {
+		Rules []clusterPolicyDocumentRule `json:"rule,omitempty"`
+		JSON  string                      `json:"json" tf:"computed"`
+	}
+	return common.NoClientData(func(ctx context.Context, data *clusterPolicyDocument) error {
+		definition := map[string]PolicyRule{}
+		for _, r := range data.Rules {
+			if _, ok := definition[r.Path]; ok {
+				return fmt.Errorf("duplicate rule for %s", r.Path)
+			}
+			rule, err := r.toPolicyRule()
+			if err != nil {
+				return fmt.Errorf("invalid rule for %s: %w", r.Path, err)
+			}
+			definition[r.Path] = rule
+		}
+		policyJSON, err := json.Marshal(definition)
+		if err != nil {
+			return err
+		}
+		data.JSON = string(policyJSON)
+		return nil
+	})
+}
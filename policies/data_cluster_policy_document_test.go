@@ -0,0 +1,123 @@
+package policies
+
+import (
+	"testing"
+
+	"github.com/databricks/terraform-provider-databricks/qa"
+)
+
+func TestDataSourceClusterPolicyDocument(t *testing.T) {
+	qa.ResourceFixture{
+		Read:        true,
+		Resource:    DataSourceClusterPolicyDocument(),
+		NonWritable: true,
+		ID:          "_",
+		HCL: `
+		rule {
+			path   = "spark_version"
+			type   = "allowlist"
+			values = ["15.4.x-scala2.12", "14.3.x-scala2.12"]
+			default_value = "15.4.x-scala2.12"
+		}
+		rule {
+			path      = "autotermination_minutes"
+			type      = "range"
+			max_value = "120"
+			default_value = "60"
+		}
+		rule {
+			path   = "enable_elastic_disk"
+			type   = "fixed"
+			value  = "true"
+			hidden = true
+		}
+		rule {
+			path    = "custom_tags.team"
+			type    = "regex"
+			pattern = "[a-z-]+"
+		}
+		rule {
+			path = "cluster_type"
+			type = "fixed"
+			value = "job"
+		}
+		rule {
+			path = "init_scripts.*.dbfs.destination"
+			type = "forbidden"
+		}
+		rule {
+			path        = "autoscale.min_workers"
+			type        = "unlimited"
+			is_optional = true
+		}
+		`,
+	}.ApplyAndExpectData(t, map[string]any{
+		"json": `{"autoscale.min_workers":{"type":"unlimited","isOptional":true},` +
+			`"autotermination_minutes":{"type":"range","defaultValue":60,"maxValue":120},` +
+			`"cluster_type":{"type":"fixed","value":"job"},` +
+			`"custom_tags.team":{"type":"regex","pattern":"[a-z-]+"},` +
+			`"enable_elastic_disk":{"type":"fixed","value":true,"hidden":true},` +
+			`"init_scripts.*.dbfs.destination":{"type":"forbidden"},` +
+			`"spark_version":{"type":"allowlist","values":["15.4.x-scala2.12","14.3.x-scala2.12"],"defaultValue":"15.4.x-scala2.12"}}`,
+	})
+}
+
+func TestDataSourceClusterPolicyDocument_Errors(t *testing.T) {
+	for hcl, expected := range map[string]string{
+		`rule {
+			path  = "spark_versions"
+			type  = "fixed"
+			value = "a"
+		}`: "invalid rule for spark_versions: unknown attribute path",
+		`rule {
+			path  = "autoscale"
+			type  = "forbidden"
+		}`: "invalid rule for autoscale: policies can only be defined for primitive attributes",
+		`rule {
+			path  = "num_workers"
+			type  = "fixed"
+			value = "many"
+		}`: "invalid rule for num_workers: many is not a number",
+		`rule {
+			path      = "spark_version"
+			type      = "range"
+			min_value = "1"
+		}`: "invalid rule for spark_version: range rules can only be used for numeric attributes",
+		`rule {
+			path  = "spark_version"
+			type  = "allowlist"
+			value = "a"
+		}`: "invalid rule for spark_version: values are required for allowlist rules",
+		`rule {
+			path          = "spark_version"
+			type          = "fixed"
+			value         = "a"
+			default_value = "b"
+		}`: "invalid rule for spark_version: default_value and is_optional can only be used with limiting rules",
+		`rule {
+			path    = "spark_version"
+			type    = "regex"
+			pattern = "("
+		}`: "invalid rule for spark_version: invalid pattern: error parsing regexp: missing closing ): `(`",
+		`rule {
+			path = "spark_version"
+			type = "required"
+		}`: "invalid rule for spark_version: unknown rule type required",
+		`rule {
+			path = "spark_version"
+			type = "forbidden"
+		}
+		rule {
+			path = "spark_version"
+			type = "unlimited"
+		}`: "duplicate rule for spark_version",
+	} {
+		qa.ResourceFixture{
+			Read:        true,
+			Resource:    DataSourceClusterPolicyDocument(),
+			NonWritable: true,
+			ID:          "_",
+			HCL:         hcl,
+		}.ExpectError(t, expected)
+	}
+}
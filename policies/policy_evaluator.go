@@ -0,0 +1,371 @@
+package policies
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/databricks/databricks-sdk-go/service/compute"
+	"github.com/databricks/terraform-provider-databricks/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Types of rules of the cluster policy definition language,
+// see https://docs.databricks.com/admin/clusters/policy-definition.html
+const (
+	PolicyRuleFixed     = "fixed"
+	PolicyRuleForbidden = "forbidden"
+	PolicyRuleAllowlist = "allowlist"
+	PolicyRuleBlocklist = "blocklist"
+	PolicyRuleRegex     = "regex"
+	PolicyRuleRange     = "range"
+	PolicyRuleUnlimited = "unlimited"
+)
+
+// Cluster types, as seen by the virtual cluster_type attribute of policies
+const (
+	ClusterTypeAllPurpose = "all-purpose"
+	ClusterTypeJob        = "job"
+)
+
+// PolicyRule is a rule for a single attribute path of a cluster policy definition
+type PolicyRule struct {
+	Type         string   `json:"type"`
+	Value        any      `json:"value,omitempty"`
+	Values       []any    `json:"values,omitempty"`
+	DefaultValue any      `json:"defaultValue,omitempty"`
+	Pattern      string   `json:"pattern,omitempty"`
+	MinValue     *float64 `json:"minValue,omitempty"`
+	MaxValue     *float64 `json:"maxValue,omitempty"`
+	IsOptional   bool     `json:"isOptional,omitempty"`
+	Hidden       bool     `json:"hidden,omitempty"`
+}
+
+// PolicyViolation describes an attribute, that doesn't satisfy a policy rule
+type PolicyViolation struct {
+	Path    string
+	Message string
+}
+
+func (v PolicyViolation) String() string {
+	return fmt.Sprintf("%s: %s", v.Path, v.Message)
+}
+
+// ParsePolicyDefinition parses JSON definition of a cluster policy
+func ParsePolicyDefinition(definition string) (map[string]PolicyRule, error) {
+	rules := map[string]PolicyRule{}
+	if definition == "" {
+		return rules, nil
+	}
+	err := json.Unmarshal([]byte(definition), &rules)
+	if err != nil {
+		return nil, fmt.Errorf("invalid policy definition: %w", err)
+	}
+	return rules, nil
+}
+
+func flattenPolicyAttributes(prefix string, v any, out map[string]any) {
+	switch vv := v.(type) {
+	case map[string]any:
+		for k, item := range vv {
+			flattenPolicyAttributes(prefix+k+".", item, out)
+		}
+	case []any:
+		for i, item := range vv {
+			flattenPolicyAttributes(fmt.Sprintf("%s%d.", prefix, i), item, out)
+		}
+	default:
+		out[strings.TrimSuffix(prefix, ".")] = v
+	}
+}
+
+// clusterPolicyAttributes converts cluster specification into the flat attribute
+// paths of the policy definition language, including the virtual attributes.
+func clusterPolicyAttributes(clusterType string, cluster compute.ClusterSpec) (map[string]any, error) {
+	raw, err := json.Marshal(cluster)
+	if err != nil {
+		return nil, err
+	}
+	var generic map[string]any
+	if err = json.Unmarshal(raw, &generic); err != nil {
+		return nil, err
+	}
+	attrs := map[string]any{}
+	flattenPolicyAttributes("", generic, attrs)
+	attrs["cluster_type"] = clusterType
+	if cluster.Autoscale == nil {
+		// number of workers is always sent for fixed size clusters, even if it's zero
+		attrs["num_workers"] = float64(cluster.NumWorkers)
+	}
+	if logConf := cluster.ClusterLogConf; logConf != nil {
+		switch {
+		case logConf.Dbfs != nil:
+			attrs["cluster_log_conf.type"] = "DBFS"
+			attrs["cluster_log_conf.path"] = logConf.Dbfs.Destination
+		case logConf.S3 != nil:
+			attrs["cluster_log_conf.type"] = "S3"
+			attrs["cluster_log_conf.path"] = logConf.S3.Destination
+		}
+	}
+	return attrs, nil
+}
+
+func policyValueString(v any) string {
+	if f, ok := v.(float64); ok {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	return fmt.Sprint(v)
+}
+
+func policyValuesString(values []any) string {
+	s := []string{}
+	for _, v := range values {
+		s = append(s, policyValueString(v))
+	}
+	return strings.Join(s, ", ")
+}
+
+func policyValueIn(v any, values []any) bool {
+	for _, allowed := range values {
+		if policyValueString(v) == policyValueString(allowed) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkValue checks a value, that is present in the cluster specification
+func (r PolicyRule) checkValue(v any) string {
+	switch r.Type {
+	case PolicyRuleFixed:
+		if policyValueString(v) != policyValueString(r.Value) {
+			return fmt.Sprintf("must be %s, but is %s", policyValueString(r.Value), policyValueString(v))
+		}
+	case PolicyRuleForbidden:
+		return "is forbidden by the policy"
+	case PolicyRuleAllowlist:
+		if !policyValueIn(v, r.Values) {
+			return fmt.Sprintf("%s is not one of the allowed values: %s",
+				policyValueString(v), policyValuesString(r.Values))
+		}
+	case PolicyRuleBlocklist:
+		if policyValueIn(v, r.Values) {
+			return fmt.Sprintf("%s is not allowed by the policy", policyValueString(v))
+		}
+	case PolicyRuleRegex:
+		// patterns are always anchored to the beginning and the end of the value
+		re, err := regexp.Compile("^(?:" + r.Pattern + ")$")
+		if err != nil {
+			return fmt.Sprintf("policy has invalid pattern %s: %s", r.Pattern, err)
+		}
+		if !re.MatchString(policyValueString(v)) {
+			return fmt.Sprintf("%s doesn't match pattern %s", policyValueString(v), r.Pattern)
+		}
+	case PolicyRuleRange:
+		f, err := strconv.ParseFloat(policyValueString(v), 64)
+		if err != nil {
+			return fmt.Sprintf("%s is not a number", policyValueString(v))
+		}
+		if r.MinValue != nil && f < *r.MinValue {
+			return fmt.Sprintf("%s is less than the minimum of %s", policyValueString(v), policyValueString(*r.MinValue))
+		}
+		if r.MaxValue != nil && f > *r.MaxValue {
+			return fmt.Sprintf("%s is greater than the maximum of %s", policyValueString(v), policyValueString(*r.MaxValue))
+		}
+	}
+	return ""
+}
+
+// checkMissing checks an attribute, that is not present in the cluster specification.
+// Fixed values and defaults are filled in by the platform, while limiting rules require
+// the attribute, unless it's marked as optional.
+func (r PolicyRule) checkMissing() string {
+	switch r.Type {
+	case PolicyRuleAllowlist, PolicyRuleBlocklist, PolicyRuleRegex, PolicyRuleRange, PolicyRuleUnlimited:
+		if !r.IsOptional && r.DefaultValue == nil {
+			return "is required by the policy"
+		}
+	}
+	return ""
+}
+
+// usesAutoValues returns true for rules with values like auto:latest-lts, that are
+// resolved by the platform and cannot be evaluated locally.
+func (r PolicyRule) usesAutoValues() bool {
+	for _, v := range append([]any{r.Value, r.DefaultValue}, r.Values...) {
+		if s, ok := v.(string); ok && strings.HasPrefix(s, "auto:") {
+			return true
+		}
+	}
+	return false
+}
+
+func policyPathRegex(path string) *regexp.Regexp {
+	quoted := regexp.QuoteMeta(path)
+	return regexp.MustCompile("^" + strings.ReplaceAll(quoted, `\*`, `[^.]+`) + "$")
+}
+
+// EvaluatePolicy checks attributes against rules and returns all violations, sorted by path.
+// Attributes, for which isUnknown returns true, are skipped, as their values are not known
+// at plan time.
+func EvaluatePolicy(rules map[string]PolicyRule, attrs map[string]any,
+	isUnknown func(path string) bool) []PolicyViolation {
+	violations := []PolicyViolation{}
+	for path, rule := range rules {
+		if isUnknown(path) || rule.usesAutoValues() {
+			continue
+		}
+		if !strings.Contains(path, "*") {
+			v, ok := attrs[path]
+			var message string
+			if ok {
+				message = rule.checkValue(v)
+			} else {
+				message = rule.checkMissing()
+			}
+			if message != "" {
+				violations = append(violations, PolicyViolation{path, message})
+			}
+			continue
+		}
+		re := policyPathRegex(path)
+		for attrPath, v := range attrs {
+			if !re.MatchString(attrPath) || isUnknown(attrPath) {
+				continue
+			}
+			if message := rule.checkValue(v); message != "" {
+				violations = append(violations, PolicyViolation{attrPath, message})
+			}
+		}
+	}
+	sort.Slice(violations, func(i, j int) bool {
+		return violations[i].Path < violations[j].Path
+	})
+	return violations
+}
+
+// policyPathSchema resolves attribute path of the policy definition language in the schema
+// of cluster specification. It returns the key of the attribute in Terraform schema, like
+// autoscale.0.max_workers for autoscale.max_workers, and the schema of the attribute itself.
+// For paths with wildcards, the key points to the list containing the wildcard. Returns
+// false, if path doesn't correspond to the schema, e.g. for virtual attributes.
+func policyPathSchema(scm map[string]*schema.Schema, path string) (string, *schema.Schema, bool) {
+	parts := strings.Split(path, ".")
+	key := []string{}
+	wildcard := false
+	addKey := func(k string) {
+		if !wildcard {
+			key = append(key, k)
+		}
+	}
+	for i := 0; i < len(parts); i++ {
+		s, ok := scm[parts[i]]
+		if !ok {
+			return "", nil, false
+		}
+		addKey(parts[i])
+		last := i+1 == len(parts)
+		switch s.Type {
+		case schema.TypeMap:
+			if last {
+				return strings.Join(key, "."), s, true
+			}
+			// keys of maps, like spark_conf, may contain dots
+			addKey(strings.Join(parts[i+1:], "."))
+			return strings.Join(key, "."), &schema.Schema{Type: schema.TypeString}, true
+		case schema.TypeList, schema.TypeSet:
+			if last {
+				return strings.Join(key, "."), s, true
+			}
+			if s.MaxItems == 1 {
+				addKey("0")
+			} else {
+				// index of the element, that may also be a wildcard
+				i++
+				last = i+1 == len(parts)
+				if parts[i] == "*" {
+					wildcard = true
+				} else if _, err := strconv.Atoi(parts[i]); err != nil {
+					return "", nil, false
+				}
+				addKey(parts[i])
+			}
+			switch elem := s.Elem.(type) {
+			case *schema.Resource:
+				if last {
+					return strings.Join(key, "."), &schema.Schema{Type: schema.TypeList, Elem: elem}, true
+				}
+				scm = elem.Schema
+			case *schema.Schema:
+				if !last {
+					return "", nil, false
+				}
+				return strings.Join(key, "."), elem, true
+			}
+		default:
+			if !last {
+				return "", nil, false
+			}
+			return strings.Join(key, "."), s, true
+		}
+	}
+	return "", nil, false
+}
+
+// ValidateClusterPolicy retrieves the policy of a cluster, that is planned with the given
+// diff, and returns an error with all violations of the policy. The cluster is located
+// at tfPrefix of the diff, described by the scm schema. Violations are never reported,
+// when the policy cannot be retrieved at plan time.
+func ValidateClusterPolicy(ctx context.Context, d *schema.ResourceDiff, tfPrefix string,
+	scm map[string]*schema.Schema, clusterType string, cluster compute.ClusterSpec) error {
+	if cluster.PolicyId == "" || !d.NewValueKnown(tfPrefix+"policy_id") {
+		return nil
+	}
+	c, ok := common.DiffClient(ctx)
+	if !ok {
+		return nil
+	}
+	w, err := c.WorkspaceClient()
+	if err != nil {
+		log.Printf("[WARN] Skipping validation against cluster policy %s: %s", cluster.PolicyId, err)
+		return nil
+	}
+	policy, err := w.ClusterPolicies.GetByPolicyId(ctx, cluster.PolicyId)
+	if err != nil {
+		log.Printf("[WARN] Skipping validation against cluster policy %s: %s", cluster.PolicyId, err)
+		return nil
+	}
+	rules, err := ParsePolicyDefinition(policy.Definition)
+	if err != nil {
+		return err
+	}
+	attrs, err := clusterPolicyAttributes(clusterType, cluster)
+	if err != nil {
+		return err
+	}
+	violations := EvaluatePolicy(rules, attrs, func(path string) bool {
+		if path == "dbus_per_hour" {
+			// depends on the pricing of node types and cannot be evaluated locally
+			return true
+		}
+		key, _, ok := policyPathSchema(scm, path)
+		if !ok {
+			return false
+		}
+		return !d.NewValueKnown(tfPrefix + key)
+	})
+	if len(violations) == 0 {
+		return nil
+	}
+	messages := []string{}
+	for _, v := range violations {
+		messages = append(messages, fmt.Sprintf("  %s%s", tfPrefix, v))
+	}
+	return fmt.Errorf("cluster policy %s (%s) is violated:\n%s",
+		policy.Name, cluster.PolicyId, strings.Join(messages, "\n"))
+}
@@ -0,0 +1,120 @@
+package policies
+
+import (
+	"testing"
+
+	"github.com/databricks/databricks-sdk-go/service/compute"
+	"github.com/databricks/terraform-provider-databricks/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluatePolicy(t *testing.T) {
+	rules, err := ParsePolicyDefinition(`{
+		"spark_version": {"type": "allowlist", "values": ["15.4.x-scala2.12", "14.3.x-scala2.12"]},
+		"node_type_id": {"type": "blocklist", "values": ["i3.16xlarge"]},
+		"autotermination_minutes": {"type": "range", "minValue": 10, "maxValue": 120},
+		"custom_tags.team": {"type": "unlimited"},
+		"custom_tags.cost_center": {"type": "regex", "pattern": "cc-[0-9]+"},
+		"spark_conf.spark.databricks.io.cache.enabled": {"type": "fixed", "value": "true"},
+		"data_security_mode": {"type": "allowlist", "values": ["USER_ISOLATION"], "defaultValue": "USER_ISOLATION"},
+		"instance_pool_id": {"type": "forbidden", "hidden": true},
+		"driver_node_type_id": {"type": "fixed", "value": "i3.xlarge"},
+		"autoscale.max_workers": {"type": "range", "maxValue": 10, "isOptional": true},
+		"init_scripts.*.workspace.destination": {"type": "regex", "pattern": "/Shared/.*"},
+		"cluster_type": {"type": "fixed", "value": "all-purpose"},
+		"dbus_per_hour": {"type": "range", "maxValue": 10}
+	}`)
+	require.NoError(t, err)
+	attrs, err := clusterPolicyAttributes(ClusterTypeJob, compute.ClusterSpec{
+		SparkVersion:           "13.3.x-scala2.12",
+		NodeTypeId:             "i3.16xlarge",
+		AutoterminationMinutes: 240,
+		CustomTags: map[string]string{
+			"cost_center": "marketing",
+		},
+		SparkConf: map[string]string{
+			"spark.databricks.io.cache.enabled": "false",
+		},
+		InstancePoolId: "abc",
+		Autoscale: &compute.AutoScale{
+			MinWorkers: 1,
+			MaxWorkers: 20,
+		},
+		InitScripts: []compute.InitScriptInfo{
+			{Workspace: &compute.WorkspaceStorageInfo{Destination: "/Shared/init.sh"}},
+			{Workspace: &compute.WorkspaceStorageInfo{Destination: "/Users/me/init.sh"}},
+		},
+	})
+	require.NoError(t, err)
+	violations := EvaluatePolicy(rules, attrs, func(path string) bool {
+		return path == "dbus_per_hour"
+	})
+	messages := []string{}
+	for _, v := range violations {
+		messages = append(messages, v.String())
+	}
+	assert.Equal(t, []string{
+		"autoscale.max_workers: 20 is greater than the maximum of 10",
+		"autotermination_minutes: 240 is greater than the maximum of 120",
+		"cluster_type: must be all-purpose, but is job",
+		"custom_tags.cost_center: marketing doesn't match pattern cc-[0-9]+",
+		"custom_tags.team: is required by the policy",
+		"init_scripts.1.workspace.destination: /Users/me/init.sh doesn't match pattern /Shared/.*",
+		"instance_pool_id: is forbidden by the policy",
+		"node_type_id: i3.16xlarge is not allowed by the policy",
+		"spark_conf.spark.databricks.io.cache.enabled: must be true, but is false",
+		"spark_version: 13.3.x-scala2.12 is not one of the allowed values: 15.4.x-scala2.12, 14.3.x-scala2.12",
+	}, messages)
+}
+
+func TestEvaluatePolicy_NoViolations(t *testing.T) {
+	rules, err := ParsePolicyDefinition(`{
+		"spark_version": {"type": "fixed", "value": "auto:latest-lts"},
+		"num_workers": {"type": "range", "maxValue": 0},
+		"node_type_id": {"type": "allowlist", "values": ["i3.xlarge"]},
+		"cluster_log_conf.type": {"type": "fixed", "value": "DBFS"},
+		"cluster_log_conf.path": {"type": "regex", "pattern": "dbfs:/logs/.*"}
+	}`)
+	require.NoError(t, err)
+	attrs, err := clusterPolicyAttributes(ClusterTypeAllPurpose, compute.ClusterSpec{
+		SparkVersion: "15.4.x-scala2.12",
+		ClusterLogConf: &compute.ClusterLogConf{
+			Dbfs: &compute.DbfsStorageInfo{Destination: "dbfs:/logs/a"},
+		},
+	})
+	require.NoError(t, err)
+	violations := EvaluatePolicy(rules, attrs, func(path string) bool {
+		return path == "node_type_id"
+	})
+	assert.Len(t, violations, 0)
+}
+
+func TestParsePolicyDefinition_Invalid(t *testing.T) {
+	_, err := ParsePolicyDefinition(`{"spark_version": []}`)
+	assert.ErrorContains(t, err, "invalid policy definition")
+}
+
+func TestPolicyPathSchema(t *testing.T) {
+	scm := common.StructToSchema(compute.ClusterSpec{}, nil)
+	for path, expected := range map[string]string{
+		"spark_version":                                "spark_version",
+		"autoscale.max_workers":                        "autoscale.0.max_workers",
+		"spark_conf.spark.databricks.io.cache.enabled": "spark_conf.spark.databricks.io.cache.enabled",
+		"init_scripts.1.workspace.destination":         "init_scripts.1.workspace.0.destination",
+		"init_scripts.*.workspace.destination":         "init_scripts",
+		"aws_attributes.availability":                  "aws_attributes.0.availability",
+	} {
+		key, _, ok := policyPathSchema(scm, path)
+		assert.True(t, ok, path)
+		assert.Equal(t, expected, key, path)
+	}
+	_, s, ok := policyPathSchema(scm, "ssh_public_keys.0")
+	assert.True(t, ok)
+	assert.Equal(t, schema.TypeString, s.Type)
+	for _, path := range []string{"cluster_type", "autoscale.max_workers.x", "init_scripts.a.workspace.destination"} {
+		_, _, ok := policyPathSchema(scm, path)
+		assert.False(t, ok, path)
+	}
+}
@@ -340,7 +340,8 @@ func (f ResourceFixture) Apply(t *testing.T) (*schema.ResourceData, error) {
 	if execute != nil {
 		// this is a bit strange, but we'll fix it later
 		diags := execute(ctx, resourceData, client)
-		if diags != nil {
+		// warnings don't fail the operation
+		if diags.HasError() {
 			return resourceData, errors.New(diagsToString(diags))
 		}
 	}
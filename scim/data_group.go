@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"sort"
-	"strings"
 
 	"github.com/databricks/terraform-provider-databricks/common"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -14,16 +13,17 @@ import (
 // DataSourceGroup returns information about group specified by display name
 func DataSourceGroup() common.Resource {
 	type entity struct {
-		DisplayName       string   `json:"display_name"`
-		Recursive         bool     `json:"recursive,omitempty"`
-		Members           []string `json:"members,omitempty" tf:"slice_set,computed"`
-		Users             []string `json:"users,omitempty" tf:"slice_set,computed"`
-		ServicePrincipals []string `json:"service_principals,omitempty" tf:"slice_set,computed"`
-		ChildGroups       []string `json:"child_groups,omitempty" tf:"slice_set,computed"`
-		Groups            []string `json:"groups,omitempty" tf:"slice_set,computed"`
-		InstanceProfiles  []string `json:"instance_profiles,omitempty" tf:"slice_set,computed"`
-		ExternalID        string   `json:"external_id,omitempty" tf:"computed"`
-		AclPrincipalID    string   `json:"acl_principal_id,omitempty" tf:"computed"`
+		DisplayName       string            `json:"display_name"`
+		Recursive         bool              `json:"recursive,omitempty"`
+		Members           []string          `json:"members,omitempty" tf:"slice_set,computed"`
+		Users             []string          `json:"users,omitempty" tf:"slice_set,computed"`
+		ServicePrincipals []string          `json:"service_principals,omitempty" tf:"slice_set,computed"`
+		ChildGroups       []string          `json:"child_groups,omitempty" tf:"slice_set,computed"`
+		Groups            []string          `json:"groups,omitempty" tf:"slice_set,computed"`
+		InstanceProfiles  []string          `json:"instance_profiles,omitempty" tf:"slice_set,computed"`
+		ExternalID        string            `json:"external_id,omitempty" tf:"computed"`
+		AclPrincipalID    string            `json:"acl_principal_id,omitempty" tf:"computed"`
+		Membership        []GroupMembership `json:"membership,omitempty" tf:"computed"`
 	}
 
 	s := common.StructToSchema(entity{}, func(
@@ -42,41 +42,39 @@ func DataSourceGroup() common.Resource {
 			var this entity
 			common.DataToStructPointer(d, s, &this)
 			groupsAPI := NewGroupsAPI(ctx, m)
-			groupAttributes := "members,roles,entitlements,externalId"
 			group, err := groupsAPI.ReadByDisplayName(this.DisplayName, groupAttributes)
 			if err != nil {
 				return err
 			}
 			d.SetId(group.ID)
-			queue := []Group{group}
-			for len(queue) > 0 {
-				current := queue[0]
-				queue = queue[1:]
-				for _, x := range current.Members {
-					this.Members = append(this.Members, x.Value)
-					if strings.HasPrefix(x.Ref, "Users/") {
-						this.Users = append(this.Users, x.Value)
-					}
-					if strings.HasPrefix(x.Ref, "Groups/") {
-						this.ChildGroups = append(this.ChildGroups, x.Value)
-					}
-					if strings.HasPrefix(x.Ref, "ServicePrincipals/") {
-						this.ServicePrincipals = append(this.ServicePrincipals, x.Value)
-					}
+			expander := newGroupExpander(groupsAPI)
+			children, err := expander.expand(group, this.Recursive, childGroups)
+			if err != nil {
+				return err
+			}
+			this.Membership = membership(children)
+			for _, x := range this.Membership {
+				this.Members = append(this.Members, x.ID)
+				switch x.Type {
+				case "user":
+					this.Users = append(this.Users, x.ID)
+				case "group":
+					this.ChildGroups = append(this.ChildGroups, x.ID)
+				case "service_principal":
+					this.ServicePrincipals = append(this.ServicePrincipals, x.ID)
 				}
+			}
+			parents, err := expander.expand(group, this.Recursive, parentGroups)
+			if err != nil {
+				return err
+			}
+			for _, current := range parents {
 				for _, x := range current.Roles {
 					this.InstanceProfiles = append(this.InstanceProfiles, x.Value)
 				}
 				current.Entitlements.readIntoData(d)
 				for _, x := range current.Groups {
 					this.Groups = append(this.Groups, x.Value)
-					if this.Recursive {
-						childGroup, err := groupsAPI.Read(x.Value, groupAttributes)
-						if err != nil {
-							return err
-						}
-						queue = append(queue, childGroup)
-					}
 				}
 			}
 			this.ExternalID = group.ExternalID
@@ -80,6 +80,20 @@ func TestDataSourceGroup(t *testing.T) {
 					},
 				},
 			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Groups/1114?attributes=members,roles,entitlements,externalId",
+				Response: Group{
+					DisplayName: "engineering",
+					ID:          "1114",
+					Members: []ComplexValue{
+						{
+							Ref:   "Users/1115",
+							Value: "1115",
+						},
+					},
+				},
+			},
 		},
 		Read:        true,
 		NonWritable: true,
@@ -103,4 +117,123 @@ func TestDataSourceGroup(t *testing.T) {
 	assertContains(t, d.Get("users"), "1112")
 	assertContains(t, d.Get("service_principals"), "1113")
 	assertContains(t, d.Get("child_groups"), "1114")
+	assertContains(t, d.Get("users"), "1115")
+}
+
+func TestDataSourceGroup_Cycle(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: `/api/2.0/preview/scim/v2/Groups?filter=displayName%20eq%20%22ds%22`,
+				Response: GroupList{
+					Resources: []Group{
+						{
+							DisplayName: "ds",
+							ID:          "eerste",
+							Members: []ComplexValue{
+								{Ref: "Users/1112", Value: "1112"},
+								{Ref: "Groups/abc", Value: "abc"},
+							},
+							Groups: []ComplexValue{{Value: "abc"}},
+						},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Groups/abc?attributes=members,roles,entitlements,externalId",
+				Response: Group{
+					DisplayName: "product",
+					ID:          "abc",
+					Members: []ComplexValue{
+						{Ref: "Groups/eerste", Value: "eerste"},
+						{Ref: "Users/1112", Value: "1112"},
+						{Ref: "ServicePrincipals/1113", Value: "1113"},
+					},
+					Groups: []ComplexValue{{Value: "eerste"}},
+				},
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceGroup(),
+		ID:          ".",
+		HCL:         `display_name = "ds"`,
+	}.ApplyAndExpectData(t, map[string]any{
+		"id": "eerste",
+		// the root group isn't listed as its own member
+		"membership.#":      3,
+		"membership.0.id":   "1112",
+		"membership.0.type": "user",
+		"membership.0.path": []any{"ds"},
+		"membership.1.id":   "1113",
+		"membership.1.type": "service_principal",
+		"membership.1.path": []any{"ds", "product"},
+		"membership.2.id":   "abc",
+		"membership.2.path": []any{"ds"},
+	})
+}
+
+func TestDataSourceGroup_NestedMembers(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: `/api/2.0/preview/scim/v2/Groups?filter=displayName%20eq%20%22ds%22`,
+				Response: GroupList{
+					Resources: []Group{
+						{
+							DisplayName: "ds",
+							ID:          "1",
+							Members: []ComplexValue{
+								{Ref: "Groups/2", Value: "2"},
+							},
+							Groups: []ComplexValue{{Value: "3"}},
+						},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Groups/2?attributes=members,roles,entitlements,externalId",
+				Response: Group{
+					DisplayName: "ml",
+					ID:          "2",
+					Members: []ComplexValue{
+						{Ref: "Users/u1", Value: "u1"},
+					},
+					Groups: []ComplexValue{{Value: "1"}},
+				},
+			},
+			{
+				// members of the parent group aren't members of the root group
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Groups/3?attributes=members,roles,entitlements,externalId",
+				Response: Group{
+					DisplayName: "data",
+					ID:          "3",
+					Members: []ComplexValue{
+						{Ref: "Groups/1", Value: "1"},
+						{Ref: "Users/u2", Value: "u2"},
+					},
+					Roles: []ComplexValue{{Value: "arn"}},
+				},
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceGroup(),
+		ID:          ".",
+		HCL:         `display_name = "ds"`,
+	}.Apply(t)
+	require.NoError(t, err)
+	assert.Equal(t, []any{"u1"}, d.Get("users").(*schema.Set).List())
+	assert.Equal(t, []any{"2"}, d.Get("child_groups").(*schema.Set).List())
+	assert.Equal(t, []any{"3"}, d.Get("groups").(*schema.Set).List())
+	assert.Equal(t, []any{"arn"}, d.Get("instance_profiles").(*schema.Set).List())
+	assert.Equal(t, []any{
+		map[string]any{"id": "2", "type": "group", "path": []any{"ds"}},
+		map[string]any{"id": "u1", "type": "user", "path": []any{"ds", "ml"}},
+	}, d.Get("membership"))
 }
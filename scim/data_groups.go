@@ -0,0 +1,134 @@
+package scim
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/databricks/terraform-provider-databricks/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// number of display names in a single filter expression
+const groupsFilterChunkSize = 20
+
+type groupInfo struct {
+	DisplayName       string            `json:"display_name"`
+	ID                string            `json:"id"`
+	ExternalID        string            `json:"external_id,omitempty"`
+	AclPrincipalID    string            `json:"acl_principal_id"`
+	Users             []string          `json:"users,omitempty"`
+	ServicePrincipals []string          `json:"service_principals,omitempty"`
+	ChildGroups       []string          `json:"child_groups,omitempty"`
+	Groups            []string          `json:"groups,omitempty"`
+	InstanceProfiles  []string          `json:"instance_profiles,omitempty"`
+	Membership        []GroupMembership `json:"membership,omitempty"`
+}
+
+// newGroupInfo describes the root group with members from its child groups and with instance profiles
+// and groups from its parent groups
+func newGroupInfo(children, parents []expandedGroup) groupInfo {
+	root := children[0]
+	info := groupInfo{
+		DisplayName:    root.DisplayName,
+		ID:             root.ID,
+		ExternalID:     root.ExternalID,
+		AclPrincipalID: fmt.Sprintf("groups/%s", root.DisplayName),
+		Membership:     membership(children),
+	}
+	for _, m := range info.Membership {
+		switch m.Type {
+		case "user":
+			info.Users = append(info.Users, m.ID)
+		case "service_principal":
+			info.ServicePrincipals = append(info.ServicePrincipals, m.ID)
+		case "group":
+			info.ChildGroups = append(info.ChildGroups, m.ID)
+		}
+	}
+	seen := map[string]bool{}
+	for _, g := range parents {
+		for _, x := range g.Roles {
+			if !seen["role:"+x.Value] {
+				seen["role:"+x.Value] = true
+				info.InstanceProfiles = append(info.InstanceProfiles, x.Value)
+			}
+		}
+		for _, x := range g.Groups {
+			if !seen["group:"+x.Value] {
+				seen["group:"+x.Value] = true
+				info.Groups = append(info.Groups, x.Value)
+			}
+		}
+	}
+	sort.Strings(info.InstanceProfiles)
+	sort.Strings(info.Groups)
+	return info
+}
+
+// DataSourceGroups returns information about multiple groups, resolving nested groups shared between them only once
+func DataSourceGroups() common.Resource {
+	type entity struct {
+		DisplayNames []string    `json:"display_names"`
+		Recursive    bool        `json:"recursive,omitempty"`
+		Groups       []groupInfo `json:"groups,omitempty" tf:"computed"`
+	}
+	s := common.StructToSchema(entity{}, func(
+		s map[string]*schema.Schema) map[string]*schema.Schema {
+		// the same default as in databricks_group
+		s["recursive"].Default = true
+		return s
+	})
+	return common.Resource{
+		Schema: s,
+		Read: func(ctx context.Context, d *schema.ResourceData, m *common.DatabricksClient) error {
+			var this entity
+			common.DataToStructPointer(d, s, &this)
+			groupsAPI := NewGroupsAPI(ctx, m)
+			ids := map[string]string{}
+			for start := 0; start < len(this.DisplayNames); start += groupsFilterChunkSize {
+				conditions := []string{}
+				for _, name := range this.DisplayNames[start:min(start+groupsFilterChunkSize, len(this.DisplayNames))] {
+					conditions = append(conditions, "displayName eq "+filterValue(name))
+				}
+				groupList, err := groupsAPI.Filter(strings.Join(conditions, " or "))
+				if err != nil {
+					return err
+				}
+				for _, g := range groupList.Resources {
+					ids[g.DisplayName] = g.ID
+				}
+			}
+			roots := []expandedGroup{}
+			for _, name := range this.DisplayNames {
+				id, ok := ids[name]
+				if !ok {
+					return fmt.Errorf("cannot find group: %s", name)
+				}
+				roots = append(roots, expandedGroup{Group: Group{ID: id}})
+			}
+			// filter results may not have all members of the group, so root groups are read in full.
+			// they are cached, as root groups may be members of each other.
+			expander := newGroupExpander(groupsAPI)
+			err := expander.readAll(roots)
+			if err != nil {
+				return err
+			}
+			this.Groups = []groupInfo{}
+			for _, root := range roots {
+				children, err := expander.expand(root.Group, this.Recursive, childGroups)
+				if err != nil {
+					return err
+				}
+				parents, err := expander.expand(root.Group, this.Recursive, parentGroups)
+				if err != nil {
+					return err
+				}
+				this.Groups = append(this.Groups, newGroupInfo(children, parents))
+			}
+			d.SetId("_")
+			return common.StructToData(this, s, d)
+		},
+	}
+}
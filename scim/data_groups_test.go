@@ -0,0 +1,161 @@
+package scim
+
+import (
+	"testing"
+
+	"github.com/databricks/terraform-provider-databricks/qa"
+)
+
+func TestDataSourceGroups(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: `/api/2.0/preview/scim/v2/Groups?filter=displayName%20eq%20%22ds%22%20or%20displayName%20eq%20%22de%22`,
+				// filter results may not have all members
+				Response: GroupList{
+					Resources: []Group{
+						{DisplayName: "ds", ID: "1"},
+						{DisplayName: "de", ID: "2"},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Groups/1?attributes=members,roles,entitlements,externalId",
+				Response: Group{
+					DisplayName: "ds",
+					ID:          "1",
+					Members: []ComplexValue{
+						{Ref: "Users/u1", Value: "u1"},
+						{Ref: "Groups/4", Value: "4"},
+					},
+					Groups: []ComplexValue{{Value: "3"}},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Groups/2?attributes=members,roles,entitlements,externalId",
+				Response: Group{
+					DisplayName: "de",
+					ID:          "2",
+					Members: []ComplexValue{
+						{Ref: "ServicePrincipals/s1", Value: "s1"},
+						{Ref: "Groups/4", Value: "4"},
+					},
+					Groups: []ComplexValue{{Value: "3"}},
+				},
+			},
+			{
+				// shared child group is read only once
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Groups/4?attributes=members,roles,entitlements,externalId",
+				Response: Group{
+					DisplayName: "shared",
+					ID:          "4",
+					Members: []ComplexValue{
+						{Ref: "Users/u2", Value: "u2"},
+					},
+					Groups: []ComplexValue{{Value: "1"}, {Value: "2"}},
+				},
+			},
+			{
+				// shared parent group is read only once, and its members aren't members of the root groups
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Groups/3?attributes=members,roles,entitlements,externalId",
+				Response: Group{
+					DisplayName: "data",
+					ID:          "3",
+					Members: []ComplexValue{
+						{Ref: "Groups/1", Value: "1"},
+						{Ref: "Groups/2", Value: "2"},
+						{Ref: "Users/u3", Value: "u3"},
+					},
+					Roles: []ComplexValue{{Value: "arn"}},
+					// cycle back to the first group
+					Groups: []ComplexValue{{Value: "1"}},
+				},
+			},
+		},
+		Resource:    DataSourceGroups(),
+		HCL:         `display_names = ["ds", "de"]`,
+		Read:        true,
+		NonWritable: true,
+		ID:          "_",
+	}.ApplyAndExpectData(t, map[string]any{
+		"groups.#":                    2,
+		"groups.0.id":                 "1",
+		"groups.0.acl_principal_id":   "groups/ds",
+		"groups.0.users":              []any{"u1", "u2"},
+		"groups.0.child_groups":       []any{"4"},
+		"groups.0.groups":             []any{"1", "3"},
+		"groups.0.instance_profiles":  []any{"arn"},
+		"groups.0.membership.#":       3,
+		"groups.0.membership.0.id":    "4",
+		"groups.0.membership.0.type":  "group",
+		"groups.0.membership.0.path":  []any{"ds"},
+		"groups.0.membership.2.id":    "u2",
+		"groups.0.membership.2.path":  []any{"ds", "shared"},
+		"groups.1.id":                 "2",
+		"groups.1.service_principals": []any{"s1"},
+		"groups.1.users":              []any{"u2"},
+		"groups.1.membership.#":       3,
+		"groups.1.membership.1.id":    "s1",
+		"groups.1.membership.1.type":  "service_principal",
+		"groups.1.membership.1.path":  []any{"de"},
+		"groups.1.membership.2.path":  []any{"de", "shared"},
+	})
+}
+
+func TestDataSourceGroups_NotFound(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: `/api/2.0/preview/scim/v2/Groups?filter=displayName%20eq%20%22ds%22`,
+				Response: GroupList{},
+			},
+		},
+		Resource:    DataSourceGroups(),
+		HCL:         `display_names = ["ds"]`,
+		Read:        true,
+		NonWritable: true,
+		ID:          "_",
+	}.ExpectError(t, "cannot find group: ds")
+}
+
+func TestDataSourceGroups_NotRecursive(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: `/api/2.0/preview/scim/v2/Groups?filter=displayName%20eq%20%22a%5C%22b%22`,
+				Response: GroupList{
+					Resources: []Group{{DisplayName: `a"b`, ID: "1"}},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Groups/1?attributes=members,roles,entitlements,externalId",
+				Response: Group{
+					DisplayName: `a"b`,
+					ID:          "1",
+					Members: []ComplexValue{
+						{Ref: "Users/u1", Value: "u1"},
+					},
+					Groups: []ComplexValue{{Value: "3"}},
+				},
+			},
+		},
+		Resource: DataSourceGroups(),
+		HCL: `display_names = ["a\"b"]
+		recursive = false`,
+		Read:        true,
+		NonWritable: true,
+		ID:          "_",
+	}.ApplyAndExpectData(t, map[string]any{
+		"groups.#":        1,
+		"groups.0.users":  []any{"u1"},
+		"groups.0.groups": []any{"3"},
+	})
+}
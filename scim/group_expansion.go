@@ -0,0 +1,223 @@
+package scim
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/databricks/terraform-provider-databricks/common"
+)
+
+const (
+	groupAttributes           = "members,roles,entitlements,externalId"
+	groupExpansionParallelism = 10
+)
+
+// expandedGroup is a group together with the display names of groups on the way from the root group to it
+type expandedGroup struct {
+	Group
+	path []string
+}
+
+// GroupMembership describes a member and the group, through which it's inherited
+type GroupMembership struct {
+	ID   string   `json:"id"`
+	Type string   `json:"type"`
+	Path []string `json:"path"`
+}
+
+// groupExpander reads groups in parallel and caches them, so that the same group is read only once,
+// even if it's reachable from multiple root groups.
+type groupExpander struct {
+	groupsAPI GroupsAPI
+
+	mu    sync.Mutex
+	cache map[string]Group
+}
+
+func newGroupExpander(groupsAPI GroupsAPI) *groupExpander {
+	return &groupExpander{
+		groupsAPI: groupsAPI,
+		cache:     map[string]Group{},
+	}
+}
+
+func (e *groupExpander) read(id string) (Group, error) {
+	e.mu.Lock()
+	group, ok := e.cache[id]
+	e.mu.Unlock()
+	if ok {
+		return group, nil
+	}
+	group, err := e.groupsAPI.Read(id, groupAttributes)
+	if err != nil {
+		return group, err
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.cache[id] = group
+	return group, nil
+}
+
+// readAll reads the groups of the same level concurrently
+func (e *groupExpander) readAll(level []expandedGroup) error {
+	errs := make([]error, len(level))
+	semaphore := make(chan struct{}, groupExpansionParallelism)
+	var wg sync.WaitGroup
+	for i := range level {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			group, err := e.read(level[i].ID)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			level[i].Group = group
+			level[i].path = append(level[i].path, group.DisplayName)
+		}(i)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parentGroups returns IDs of groups, that the group is a direct member of
+func parentGroups(g Group) (ids []string) {
+	for _, x := range g.Groups {
+		ids = append(ids, x.Value)
+	}
+	return
+}
+
+// childGroups returns IDs of groups, that are direct members of the group
+func childGroups(g Group) (ids []string) {
+	for _, x := range g.Members {
+		if memberType(x) == "group" {
+			ids = append(ids, x.Value)
+		}
+	}
+	return
+}
+
+// expand returns the root group and, if recursive, all groups that are transitively reachable from it with
+// follow in the breadth-first order: parentGroups for inherited entitlements, roles and instance profiles,
+// and childGroups for inherited members. Every group is visited once, so cycles in the hierarchy are safe.
+func (e *groupExpander) expand(root Group, recursive bool, follow func(Group) []string) ([]expandedGroup, error) {
+	visited := map[string]bool{root.ID: true}
+	level := []expandedGroup{{root, []string{root.DisplayName}}}
+	result := level
+	for recursive && len(level) > 0 {
+		next := []expandedGroup{}
+		for _, g := range level {
+			for _, id := range follow(g.Group) {
+				if visited[id] {
+					log.Printf("[DEBUG] Group %s is already visited, skipping it from %s",
+						id, strings.Join(g.path, " > "))
+					continue
+				}
+				visited[id] = true
+				next = append(next, expandedGroup{Group{ID: id}, slices.Clone(g.path)})
+			}
+		}
+		err := e.readAll(next)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, next...)
+		level = next
+	}
+	return result, nil
+}
+
+func memberType(member ComplexValue) string {
+	switch {
+	case strings.HasPrefix(member.Ref, "Users/"):
+		return "user"
+	case strings.HasPrefix(member.Ref, "ServicePrincipals/"):
+		return "service_principal"
+	case strings.HasPrefix(member.Ref, "Groups/"):
+		return "group"
+	}
+	return ""
+}
+
+// membership lists every member of the root group and its child groups once with the shortest path
+// of child groups, through which it's inherited
+func membership(children []expandedGroup) []GroupMembership {
+	// the root group may be a member of its child groups in a cycle, but it's not listed as its own member
+	seen := map[string]bool{children[0].ID: true}
+	result := []GroupMembership{}
+	for _, g := range children {
+		for _, x := range g.Members {
+			if seen[x.Value] {
+				continue
+			}
+			seen[x.Value] = true
+			result = append(result, GroupMembership{
+				ID:   x.Value,
+				Type: memberType(x),
+				Path: g.path,
+			})
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+	return result
+}
+
+// findPrincipal returns the user, service principal or group with the given user name, application ID
+// or display name, with groups, that it's a direct member of
+func findPrincipal(ctx context.Context, c *common.DatabricksClient, principal string) (Group, error) {
+	users, err := NewUsersAPI(ctx, c).Filter("userName eq "+filterValue(principal), true)
+	if err != nil {
+		return Group{}, err
+	}
+	if len(users) == 0 {
+		users, err = NewServicePrincipalsAPI(ctx, c).Filter("applicationId eq "+filterValue(principal), true)
+		if err != nil {
+			return Group{}, err
+		}
+	}
+	if len(users) > 0 {
+		return Group{ID: users[0].ID, DisplayName: principal, Groups: users[0].Groups}, nil
+	}
+	groups, err := NewGroupsAPI(ctx, c).Filter("displayName eq " + filterValue(principal))
+	if err != nil {
+		return Group{}, err
+	}
+	if len(groups.Resources) == 0 {
+		return Group{}, fmt.Errorf("cannot find user, service principal or group: %s", principal)
+	}
+	return groups.Resources[0], nil
+}
+
+// PrincipalGroups returns sorted display names of groups, that the user, service principal or group
+// is a direct or transitive member of
+func PrincipalGroups(ctx context.Context, c *common.DatabricksClient, principal string) ([]string, error) {
+	root, err := findPrincipal(ctx, c, principal)
+	if err != nil {
+		return nil, err
+	}
+	groups, err := newGroupExpander(NewGroupsAPI(ctx, c)).expand(root, true, parentGroups)
+	if err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for _, g := range groups[1:] {
+		names = append(names, g.DisplayName)
+	}
+	sort.Strings(names)
+	return names, nil
+}
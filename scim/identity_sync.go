@@ -0,0 +1,672 @@
+package scim
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/databricks/databricks-sdk-go/client"
+	"github.com/databricks/databricks-sdk-go/config"
+	"github.com/databricks/terraform-provider-databricks/common"
+)
+
+// IdentitySource is the declarative list of principals and group memberships, that should exist
+type IdentitySource struct {
+	Users             []SourceUser             `json:"users,omitempty"`
+	ServicePrincipals []SourceServicePrincipal `json:"service_principals,omitempty"`
+	Groups            []SourceGroup            `json:"groups,omitempty"`
+}
+
+type SourceUser struct {
+	UserName    string `json:"user_name"`
+	DisplayName string `json:"display_name,omitempty"`
+	ExternalID  string `json:"external_id,omitempty"`
+}
+
+// SourceServicePrincipal is matched by application ID, or by display name, if application ID isn't known
+type SourceServicePrincipal struct {
+	ApplicationID string `json:"application_id,omitempty"`
+	DisplayName   string `json:"display_name,omitempty"`
+	ExternalID    string `json:"external_id,omitempty"`
+}
+
+func (sp SourceServicePrincipal) key() string {
+	if sp.ApplicationID != "" {
+		return sp.ApplicationID
+	}
+	return sp.DisplayName
+}
+
+// SourceGroup has members referenced by user names, application IDs or display names of service
+// principals, and display names of groups. Members of the group are not managed, if they are not
+// specified, while an empty list removes all members.
+type SourceGroup struct {
+	DisplayName string   `json:"display_name"`
+	ExternalID  string   `json:"external_id,omitempty"`
+	Members     []string `json:"members,omitempty"`
+}
+
+// ParseIdentitySource reads either JSON document, or CSV with `type,name,display_name,external_id,groups`
+// columns, where type is one of `user`, `service_principal` or `group`, and groups is semicolon-separated
+// list of groups, that the principal is member of. Members of groups, that no principal is member of,
+// are not managed.
+func ParseIdentitySource(content []byte, format string) (source IdentitySource, err error) {
+	switch format {
+	case "json":
+		err = json.Unmarshal(content, &source)
+		if err != nil {
+			err = fmt.Errorf("cannot parse identity source: %w", err)
+		}
+	case "csv":
+		source, err = parseIdentitySourceCSV(content)
+	default:
+		err = fmt.Errorf("unsupported identity source format: %s", format)
+	}
+	if err != nil {
+		return
+	}
+	err = source.validate()
+	return
+}
+
+func parseIdentitySourceCSV(content []byte) (IdentitySource, error) {
+	source := IdentitySource{}
+	r := csv.NewReader(bytes.NewReader(content))
+	r.FieldsPerRecord = -1
+	header, err := r.Read()
+	if err != nil {
+		return source, fmt.Errorf("cannot read CSV header: %w", err)
+	}
+	columns := map[string]int{}
+	for i, h := range header {
+		columns[strings.TrimSpace(h)] = i
+	}
+	for _, required := range []string{"type", "name"} {
+		if _, ok := columns[required]; !ok {
+			return source, fmt.Errorf("CSV must have `%s` column", required)
+		}
+	}
+	groupIndex := map[string]int{}
+	addToGroup := func(group, member string) {
+		i, ok := groupIndex[group]
+		if !ok {
+			i = len(source.Groups)
+			groupIndex[group] = i
+			source.Groups = append(source.Groups, SourceGroup{DisplayName: group})
+		}
+		if member != "" {
+			source.Groups[i].Members = append(source.Groups[i].Members, member)
+		}
+	}
+	for line := 2; ; line++ {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return source, err
+		}
+		value := func(column string) string {
+			i, ok := columns[column]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+		name := value("name")
+		switch value("type") {
+		case "user":
+			source.Users = append(source.Users, SourceUser{
+				UserName:    name,
+				DisplayName: value("display_name"),
+				ExternalID:  value("external_id"),
+			})
+		case "service_principal":
+			sp := SourceServicePrincipal{
+				DisplayName: value("display_name"),
+				ExternalID:  value("external_id"),
+			}
+			if common.StringIsUUID(name) {
+				sp.ApplicationID = name
+			} else if sp.DisplayName == "" {
+				sp.DisplayName = name
+			}
+			source.ServicePrincipals = append(source.ServicePrincipals, sp)
+		case "group":
+			addToGroup(name, "")
+			source.Groups[groupIndex[name]].ExternalID = value("external_id")
+		default:
+			return source, fmt.Errorf("line %d: unknown type: %s", line, value("type"))
+		}
+		for _, group := range strings.Split(value("groups"), ";") {
+			if strings.TrimSpace(group) != "" {
+				addToGroup(strings.TrimSpace(group), name)
+			}
+		}
+	}
+	return source, nil
+}
+
+func (source IdentitySource) validate() error {
+	for _, u := range source.Users {
+		if u.UserName == "" {
+			return fmt.Errorf("user_name is required for users")
+		}
+	}
+	for _, sp := range source.ServicePrincipals {
+		if sp.key() == "" {
+			return fmt.Errorf("either application_id or display_name is required for service principals")
+		}
+	}
+	for _, g := range source.Groups {
+		if g.DisplayName == "" {
+			return fmt.Errorf("display_name is required for groups")
+		}
+	}
+	return nil
+}
+
+// IdentitySyncReport lists principals, that were changed by the sync
+type IdentitySyncReport struct {
+	Created     []string `json:"created"`
+	Updated     []string `json:"updated"`
+	Deactivated []string `json:"deactivated"`
+}
+
+type identityChange struct {
+	action    string
+	principal string
+	apply     func() error
+}
+
+// IdentitySync brings principals and group memberships in the workspace or account to the state
+// described by the source with the minimal number of SCIM requests.
+type IdentitySync struct {
+	Source            IdentitySource
+	DeactivateMissing bool
+	// Keep has user names and application IDs of principals, that are never deactivated, like the ones
+	// running the sync. It's required to deactivate missing principals on the account level, where the
+	// current principal can't be detected.
+	Keep        []string
+	DryRun      bool
+	Parallelism int
+
+	ctx    context.Context
+	client *common.DatabricksClient
+	keep   map[string]bool
+
+	mu                sync.Mutex
+	users             map[string]User
+	servicePrincipals map[string]User
+	groups            map[string]Group
+	report            IdentitySyncReport
+}
+
+// NewIdentitySync creates IdentitySync for the given source
+func NewIdentitySync(ctx context.Context, c *common.DatabricksClient, source IdentitySource) *IdentitySync {
+	return &IdentitySync{
+		Source:      source,
+		Parallelism: 10,
+		ctx:         ctx,
+		client:      c,
+	}
+}
+
+const identitySyncPageSize = 100
+
+// listAll reads all pages of SCIM resources
+func (s *IdentitySync) listAll(path, attributes string, visit func(raw json.RawMessage) error) error {
+	for startIndex := 1; ; startIndex += identitySyncPageSize {
+		var page struct {
+			Resources []json.RawMessage `json:"resources,omitempty"`
+		}
+		err := s.client.Scim(s.ctx, http.MethodGet, path, map[string]string{
+			"attributes": attributes,
+			"startIndex": strconv.Itoa(startIndex),
+			"count":      strconv.Itoa(identitySyncPageSize),
+		}, &page)
+		if err != nil {
+			return err
+		}
+		for _, raw := range page.Resources {
+			err = visit(raw)
+			if err != nil {
+				return err
+			}
+		}
+		if len(page.Resources) < identitySyncPageSize {
+			return nil
+		}
+	}
+}
+
+func (s *IdentitySync) load() error {
+	s.users = map[string]User{}
+	s.servicePrincipals = map[string]User{}
+	s.groups = map[string]Group{}
+	s.keep = map[string]bool{}
+	for _, k := range s.Keep {
+		s.keep[strings.ToLower(k)] = true
+	}
+	if s.DeactivateMissing && s.client.Config.IsAccountClient() && len(s.Keep) == 0 {
+		// there's no API to get the current principal on the account level
+		return fmt.Errorf("deactivating missing principals on the account level requires the principals " +
+			"to keep, like the one running the sync")
+	}
+	if s.DeactivateMissing && !s.client.Config.IsAccountClient() {
+		me, err := NewUsersAPI(s.ctx, s.client).Me()
+		if err != nil {
+			return err
+		}
+		s.keep[strings.ToLower(me.UserName)] = true
+	}
+	err := s.listAll("/preview/scim/v2/Users", "id,userName,displayName,externalId,active",
+		func(raw json.RawMessage) error {
+			var u User
+			err := json.Unmarshal(raw, &u)
+			if err != nil {
+				return err
+			}
+			s.users[strings.ToLower(u.UserName)] = u
+			return nil
+		})
+	if err != nil {
+		return err
+	}
+	err = s.listAll("/preview/scim/v2/ServicePrincipals", "id,applicationId,displayName,externalId,active",
+		func(raw json.RawMessage) error {
+			var sp User
+			err := json.Unmarshal(raw, &sp)
+			if err != nil {
+				return err
+			}
+			s.servicePrincipals[sp.ApplicationID] = sp
+			if _, ok := s.servicePrincipals[sp.DisplayName]; !ok {
+				s.servicePrincipals[sp.DisplayName] = sp
+			}
+			return nil
+		})
+	if err != nil {
+		return err
+	}
+	return s.listAll("/preview/scim/v2/Groups", "id,displayName,externalId,members",
+		func(raw json.RawMessage) error {
+			var g Group
+			err := json.Unmarshal(raw, &g)
+			if err != nil {
+				return err
+			}
+			s.groups[g.DisplayName] = g
+			return nil
+		})
+}
+
+// principalPatch replaces attributes, that differ from the source, and activates the principal
+func principalPatch(current User, displayName, externalID string) (patchRequest, bool) {
+	operations := []patchOperation{}
+	if displayName != "" && displayName != current.DisplayName {
+		operations = append(operations, patchOperation{"replace", "displayName", displayName})
+	}
+	if externalID != "" && externalID != current.ExternalID {
+		operations = append(operations, patchOperation{"replace", "externalId", externalID})
+	}
+	if !current.Active {
+		operations = append(operations, patchOperation{"replace", "active", "true"})
+	}
+	return PatchRequestComplexValue(operations), len(operations) > 0
+}
+
+func (s *IdentitySync) planUsers() (changes []identityChange) {
+	usersAPI := NewUsersAPI(s.ctx, s.client)
+	desired := map[string]bool{}
+	for _, su := range s.Source.Users {
+		su := su
+		key := strings.ToLower(su.UserName)
+		desired[key] = true
+		principal := "user " + su.UserName
+		current, ok := s.users[key]
+		if !ok {
+			changes = append(changes, identityChange{"create", principal, func() error {
+				u, err := usersAPI.Create(User{
+					UserName:    su.UserName,
+					DisplayName: su.DisplayName,
+					ExternalID:  su.ExternalID,
+					Active:      true,
+				})
+				if err != nil {
+					return err
+				}
+				s.mu.Lock()
+				defer s.mu.Unlock()
+				s.users[key] = u
+				return nil
+			}})
+			continue
+		}
+		if patch, changed := principalPatch(current, su.DisplayName, su.ExternalID); changed {
+			changes = append(changes, identityChange{"update", principal, func() error {
+				return usersAPI.Patch(current.ID, patch)
+			}})
+		}
+	}
+	if !s.DeactivateMissing {
+		return
+	}
+	keys := []string{}
+	for k, u := range s.users {
+		if !desired[k] && u.Active && !s.keep[k] {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		current := s.users[k]
+		changes = append(changes, identityChange{"deactivate", "user " + current.UserName, func() error {
+			return usersAPI.Patch(current.ID, PatchRequestWithValue("replace", "active", "false"))
+		}})
+	}
+	return
+}
+
+func (s *IdentitySync) planServicePrincipals() (changes []identityChange) {
+	spAPI := NewServicePrincipalsAPI(s.ctx, s.client)
+	desired := map[string]bool{}
+	for _, ssp := range s.Source.ServicePrincipals {
+		ssp := ssp
+		key := ssp.key()
+		principal := "service_principal " + key
+		current, ok := s.servicePrincipals[key]
+		if !ok {
+			changes = append(changes, identityChange{"create", principal, func() error {
+				sp, err := spAPI.Create(User{
+					ApplicationID: ssp.ApplicationID,
+					DisplayName:   ssp.DisplayName,
+					ExternalID:    ssp.ExternalID,
+					Active:        true,
+				})
+				if err != nil {
+					return err
+				}
+				s.mu.Lock()
+				defer s.mu.Unlock()
+				s.servicePrincipals[key] = sp
+				return nil
+			}})
+			continue
+		}
+		desired[current.ID] = true
+		if patch, changed := principalPatch(current, ssp.DisplayName, ssp.ExternalID); changed {
+			changes = append(changes, identityChange{"update", principal, func() error {
+				return spAPI.Patch(current.ID, patch)
+			}})
+		}
+	}
+	if !s.DeactivateMissing {
+		return
+	}
+	missing := map[string]User{}
+	for _, sp := range s.servicePrincipals {
+		if !desired[sp.ID] && sp.Active && !s.keep[strings.ToLower(sp.ApplicationID)] {
+			missing[sp.ApplicationID] = sp
+		}
+	}
+	keys := []string{}
+	for k := range missing {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		current := missing[k]
+		changes = append(changes, identityChange{"deactivate", "service_principal " + k, func() error {
+			return spAPI.Patch(current.ID, PatchRequestWithValue("replace", "active", "false"))
+		}})
+	}
+	return
+}
+
+func (s *IdentitySync) planGroups() (changes []identityChange) {
+	groupsAPI := NewGroupsAPI(s.ctx, s.client)
+	for _, sg := range s.Source.Groups {
+		sg := sg
+		principal := "group " + sg.DisplayName
+		current, ok := s.groups[sg.DisplayName]
+		if !ok {
+			changes = append(changes, identityChange{"create", principal, func() error {
+				g, err := groupsAPI.Create(Group{
+					DisplayName: sg.DisplayName,
+					ExternalID:  sg.ExternalID,
+				})
+				if err != nil {
+					return err
+				}
+				s.mu.Lock()
+				defer s.mu.Unlock()
+				s.groups[sg.DisplayName] = g
+				return nil
+			}})
+			continue
+		}
+		if sg.ExternalID != "" && sg.ExternalID != current.ExternalID {
+			changes = append(changes, identityChange{"update", principal, func() error {
+				return groupsAPI.Patch(current.ID, PatchRequestComplexValue([]patchOperation{
+					{"replace", "externalId", sg.ExternalID},
+				}))
+			}})
+		}
+	}
+	return
+}
+
+// resolveMember finds the ID of a user, service principal or group, that is referenced in the source
+func (s *IdentitySync) resolveMember(reference string) (string, bool) {
+	if u, ok := s.users[strings.ToLower(reference)]; ok {
+		return u.ID, true
+	}
+	if sp, ok := s.servicePrincipals[reference]; ok {
+		return sp.ID, true
+	}
+	if g, ok := s.groups[reference]; ok {
+		return g.ID, true
+	}
+	return "", false
+}
+
+// planMemberships must be called after principals are created, so that their IDs are known
+func (s *IdentitySync) planMemberships() (changes []identityChange, err error) {
+	groupsAPI := NewGroupsAPI(s.ctx, s.client)
+	for _, sg := range s.Source.Groups {
+		if sg.Members == nil {
+			continue
+		}
+		group := s.groups[sg.DisplayName]
+		desired := []string{}
+		for _, reference := range sg.Members {
+			id, ok := s.resolveMember(reference)
+			if !ok {
+				return nil, fmt.Errorf("cannot find member %s of group %s", reference, sg.DisplayName)
+			}
+			desired = append(desired, id)
+		}
+		current := []string{}
+		for _, m := range group.Members {
+			current = append(current, m.Value)
+		}
+		add, remove := membersDiff(current, desired)
+		if len(add) == 0 && len(remove) == 0 {
+			continue
+		}
+		principal := fmt.Sprintf("group %s (+%d -%d members)", sg.DisplayName, len(add), len(remove))
+		changes = append(changes, identityChange{"update", principal, func() error {
+			return groupsAPI.Patch(group.ID, membersPatch(add, remove))
+		}})
+	}
+	return
+}
+
+func (s *IdentitySync) record(change identityChange) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch change.action {
+	case "create":
+		s.report.Created = append(s.report.Created, change.principal)
+	case "update":
+		s.report.Updated = append(s.report.Updated, change.principal)
+	case "deactivate":
+		s.report.Deactivated = append(s.report.Deactivated, change.principal)
+	}
+}
+
+// apply runs changes in batches of Parallelism concurrent requests
+func (s *IdentitySync) apply(changes []identityChange) error {
+	semaphore := make(chan struct{}, max(s.Parallelism, 1))
+	failures := []string{}
+	var wg sync.WaitGroup
+	for _, change := range changes {
+		if s.DryRun {
+			log.Printf("[INFO] Would %s %s", change.action, change.principal)
+			s.record(change)
+			continue
+		}
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(change identityChange) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			err := change.apply()
+			if err != nil {
+				s.mu.Lock()
+				failures = append(failures, fmt.Sprintf("cannot %s %s: %s", change.action, change.principal, err))
+				s.mu.Unlock()
+				return
+			}
+			log.Printf("[INFO] %s %s", change.action, change.principal)
+			s.record(change)
+		}(change)
+	}
+	wg.Wait()
+	if len(failures) > 0 {
+		sort.Strings(failures)
+		return fmt.Errorf("%d changes failed:\n%s", len(failures), strings.Join(failures, "\n"))
+	}
+	return nil
+}
+
+// Run computes and applies the difference between the source and the workspace or account
+func (s *IdentitySync) Run() (IdentitySyncReport, error) {
+	err := s.load()
+	if err != nil {
+		return s.report, err
+	}
+	principals := append(s.planUsers(), s.planServicePrincipals()...)
+	principals = append(principals, s.planGroups()...)
+	err = s.apply(principals)
+	if err != nil {
+		return s.report, err
+	}
+	if s.DryRun {
+		// principals, that would be created, don't have IDs yet
+		for _, c := range principals {
+			if c.action == "create" {
+				s.markPlanned(c.principal)
+			}
+		}
+	}
+	memberships, err := s.planMemberships()
+	if err != nil {
+		return s.report, err
+	}
+	err = s.apply(memberships)
+	return s.report, err
+}
+
+func (s *IdentitySync) markPlanned(principal string) {
+	kind, name, _ := strings.Cut(principal, " ")
+	id := "new:" + principal
+	switch kind {
+	case "user":
+		s.users[strings.ToLower(name)] = User{ID: id}
+	case "service_principal":
+		s.servicePrincipals[name] = User{ID: id}
+	case "group":
+		s.groups[name] = Group{ID: id}
+	}
+}
+
+// RunIdentitySync synchronizes principals and group memberships from the source file to the workspace
+// or account, that is configured with the environment variables or the configuration profile.
+func RunIdentitySync(args ...string) error {
+	flags := flag.NewFlagSet("identity-sync", flag.ExitOnError)
+	var sourcePath, format, reportPath, keep string
+	var deactivateMissing, dryRun bool
+	var parallelism int
+	flags.StringVar(&sourcePath, "source", "identities.json",
+		"JSON or CSV file with users, service principals, groups and memberships.")
+	flags.StringVar(&format, "format", "",
+		"Format of the source file: `json` or `csv`. Detected from the file extension by default.")
+	flags.BoolVar(&deactivateMissing, "deactivate-missing", false,
+		"Deactivate users and service principals, that aren't in the source file.")
+	flags.StringVar(&keep, "keep", "",
+		"Comma-separated user names and application IDs of principals, that are never deactivated. "+
+			"Required with -deactivate-missing on the account level.")
+	flags.BoolVar(&dryRun, "dry-run", false, "Only report changes, without applying them.")
+	flags.IntVar(&parallelism, "parallelism", 10, "Number of concurrent SCIM requests.")
+	flags.StringVar(&reportPath, "report", "", "File to write JSON report with changed principals to.")
+	newArgs := args
+	if len(args) > 1 && args[1] == "identity-sync" {
+		newArgs = args[2:]
+	}
+	err := flags.Parse(newArgs)
+	if err != nil {
+		return err
+	}
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(sourcePath)), ".")
+	}
+	content, err := os.ReadFile(sourcePath)
+	if err != nil {
+		return err
+	}
+	source, err := ParseIdentitySource(content, format)
+	if err != nil {
+		return err
+	}
+	c, err := client.New(&config.Config{})
+	if err != nil {
+		return err
+	}
+	s := NewIdentitySync(context.Background(), &common.DatabricksClient{DatabricksClient: c}, source)
+	s.DeactivateMissing = deactivateMissing
+	for _, k := range strings.Split(keep, ",") {
+		if strings.TrimSpace(k) != "" {
+			s.Keep = append(s.Keep, strings.TrimSpace(k))
+		}
+	}
+	s.DryRun = dryRun
+	s.Parallelism = parallelism
+	report, err := s.Run()
+	log.Printf("[INFO] Created %d, updated %d and deactivated %d principals",
+		len(report.Created), len(report.Updated), len(report.Deactivated))
+	if reportPath != "" {
+		raw, jsonErr := json.MarshalIndent(report, "", "  ")
+		if jsonErr != nil {
+			return jsonErr
+		}
+		writeErr := os.WriteFile(reportPath, raw, 0644)
+		if writeErr != nil {
+			return writeErr
+		}
+	}
+	return err
+}
@@ -0,0 +1,265 @@
+package scim
+
+import (
+	"context"
+	"testing"
+
+	"github.com/databricks/terraform-provider-databricks/common"
+	"github.com/databricks/terraform-provider-databricks/qa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseIdentitySourceCSV(t *testing.T) {
+	source, err := ParseIdentitySource([]byte(`type,name,display_name,external_id,groups
+user,alice@example.com,Alice,e1,data;admins
+service_principal,00000000-0000-0000-0000-000000000001,etl,,data
+service_principal,reporting,,,
+group,data,,g1,
+group,ops,,,
+`), "csv")
+	require.NoError(t, err)
+	assert.Equal(t, IdentitySource{
+		Users: []SourceUser{
+			{UserName: "alice@example.com", DisplayName: "Alice", ExternalID: "e1"},
+		},
+		ServicePrincipals: []SourceServicePrincipal{
+			{ApplicationID: "00000000-0000-0000-0000-000000000001", DisplayName: "etl"},
+			{DisplayName: "reporting"},
+		},
+		Groups: []SourceGroup{
+			{
+				DisplayName: "data",
+				ExternalID:  "g1",
+				Members:     []string{"alice@example.com", "00000000-0000-0000-0000-000000000001"},
+			},
+			{DisplayName: "admins", Members: []string{"alice@example.com"}},
+			// members of the group are not managed
+			{DisplayName: "ops"},
+		},
+	}, source)
+}
+
+func TestParseIdentitySourceJSON(t *testing.T) {
+	source, err := ParseIdentitySource([]byte(`{
+		"users": [{"user_name": "alice@example.com"}],
+		"groups": [{"display_name": "data", "members": ["alice@example.com"]}]
+	}`), "json")
+	require.NoError(t, err)
+	assert.Equal(t, "alice@example.com", source.Users[0].UserName)
+	assert.Equal(t, []string{"alice@example.com"}, source.Groups[0].Members)
+}
+
+func TestParseIdentitySourceErrors(t *testing.T) {
+	for content, message := range map[string]string{
+		"name\nabc\n":                     "CSV must have `type` column",
+		"type,name\nrobot,abc\n":          "line 2: unknown type: robot",
+		"type,name\nuser,\n":              "user_name is required for users",
+		"type,name\nservice_principal,\n": "either application_id or display_name is required for service principals",
+	} {
+		_, err := ParseIdentitySource([]byte(content), "csv")
+		assert.EqualError(t, err, message)
+	}
+	_, err := ParseIdentitySource([]byte(`{"groups": [{}]}`), "json")
+	assert.EqualError(t, err, "display_name is required for groups")
+	_, err = ParseIdentitySource([]byte(`{`), "json")
+	assert.ErrorContains(t, err, "cannot parse identity source")
+	_, err = ParseIdentitySource([]byte(``), "yaml")
+	assert.EqualError(t, err, "unsupported identity source format: yaml")
+}
+
+// fixtures are marked as used by the mock server, so every test needs a fresh copy
+func identitySyncListFixtures() []qa.HTTPFixture {
+	return []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/preview/scim/v2/Users?attributes=id%2CuserName%2CdisplayName%2CexternalId%2Cactive&count=100&startIndex=1",
+			Response: UserList{
+				Resources: []User{
+					{ID: "u1", UserName: "alice@example.com", DisplayName: "Alice", Active: true},
+					{ID: "u2", UserName: "bob@example.com", DisplayName: "Bob", Active: true},
+					{ID: "u3", UserName: "admin@example.com", Active: true},
+				},
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/preview/scim/v2/ServicePrincipals?attributes=id%2CapplicationId%2CdisplayName%2CexternalId%2Cactive&count=100&startIndex=1",
+			Response: UserList{
+				Resources: []User{
+					{ID: "s1", ApplicationID: "00000000-0000-0000-0000-000000000001", DisplayName: "etl", Active: true},
+				},
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/preview/scim/v2/Groups?attributes=id%2CdisplayName%2CexternalId%2Cmembers&count=100&startIndex=1",
+			Response: GroupList{
+				Resources: []Group{
+					{ID: "g1", DisplayName: "data", Members: []ComplexValue{{Value: "u2"}, {Value: "s1"}}},
+				},
+			},
+		},
+	}
+}
+
+var identitySyncSource = IdentitySource{
+	Users: []SourceUser{
+		{UserName: "Alice@example.com", DisplayName: "Alice Smith"},
+		{UserName: "carol@example.com"},
+	},
+	ServicePrincipals: []SourceServicePrincipal{
+		{ApplicationID: "00000000-0000-0000-0000-000000000001", DisplayName: "etl"},
+	},
+	Groups: []SourceGroup{
+		{DisplayName: "data", Members: []string{"alice@example.com", "carol@example.com", "etl"}},
+		{DisplayName: "analysts", Members: []string{"data"}},
+	},
+}
+
+func TestIdentitySync(t *testing.T) {
+	qa.HTTPFixturesApply(t, append([]qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/preview/scim/v2/Me",
+			Response: User{ID: "u3", UserName: "admin@example.com"},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/preview/scim/v2/Users",
+			ExpectedRequest: User{
+				Schemas:  []URN{UserSchema},
+				UserName: "carol@example.com",
+				Active:   true,
+			},
+			Response: User{ID: "u4", UserName: "carol@example.com"},
+		},
+		{
+			Method:   "PATCH",
+			Resource: "/api/2.0/preview/scim/v2/Users/u1",
+			ExpectedRequest: PatchRequestComplexValue([]patchOperation{
+				{"replace", "displayName", "Alice Smith"},
+			}),
+		},
+		{
+			Method:          "PATCH",
+			Resource:        "/api/2.0/preview/scim/v2/Users/u2",
+			ExpectedRequest: PatchRequestWithValue("replace", "active", "false"),
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/preview/scim/v2/Groups",
+			ExpectedRequest: Group{
+				Schemas:     []URN{GroupSchema},
+				DisplayName: "analysts",
+			},
+			Response: Group{ID: "g2", DisplayName: "analysts"},
+		},
+		{
+			Method:          "PATCH",
+			Resource:        "/api/2.0/preview/scim/v2/Groups/g1",
+			ExpectedRequest: membersPatch([]string{"u1", "u4"}, []string{"u2"}),
+		},
+		{
+			Method:          "PATCH",
+			Resource:        "/api/2.0/preview/scim/v2/Groups/g2",
+			ExpectedRequest: membersPatch([]string{"g1"}, nil),
+		},
+	}, identitySyncListFixtures()...), func(ctx context.Context, client *common.DatabricksClient) {
+		s := NewIdentitySync(ctx, client, identitySyncSource)
+		s.DeactivateMissing = true
+		s.Parallelism = 1
+		report, err := s.Run()
+		require.NoError(t, err)
+		assert.Equal(t, IdentitySyncReport{
+			Created: []string{"user carol@example.com", "group analysts"},
+			Updated: []string{
+				"user Alice@example.com",
+				"group data (+2 -1 members)",
+				"group analysts (+1 -0 members)",
+			},
+			Deactivated: []string{"user bob@example.com"},
+		}, report)
+	})
+}
+
+func TestIdentitySyncDryRun(t *testing.T) {
+	qa.HTTPFixturesApply(t, identitySyncListFixtures(), func(ctx context.Context, client *common.DatabricksClient) {
+		s := NewIdentitySync(ctx, client, identitySyncSource)
+		s.DryRun = true
+		report, err := s.Run()
+		require.NoError(t, err)
+		assert.Equal(t, IdentitySyncReport{
+			Created: []string{"user carol@example.com", "group analysts"},
+			Updated: []string{
+				"user Alice@example.com",
+				"group data (+2 -1 members)",
+				"group analysts (+1 -0 members)",
+			},
+		}, report)
+	})
+}
+
+func TestIdentitySyncUnknownMember(t *testing.T) {
+	qa.HTTPFixturesApply(t, identitySyncListFixtures(), func(ctx context.Context, client *common.DatabricksClient) {
+		s := NewIdentitySync(ctx, client, IdentitySource{
+			Groups: []SourceGroup{{DisplayName: "data", Members: []string{"nobody"}}},
+		})
+		_, err := s.Run()
+		assert.EqualError(t, err, "cannot find member nobody of group data")
+	})
+}
+
+func TestIdentitySyncFailures(t *testing.T) {
+	qa.HTTPFixturesApply(t, append([]qa.HTTPFixture{
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/preview/scim/v2/Users",
+			Response: common.APIErrorBody{
+				ErrorCode: "INVALID_REQUEST",
+				Message:   "Invalid user",
+			},
+			Status: 400,
+		},
+	}, identitySyncListFixtures()...), func(ctx context.Context, client *common.DatabricksClient) {
+		s := NewIdentitySync(ctx, client, IdentitySource{
+			Users: []SourceUser{{UserName: "carol@example.com"}},
+		})
+		_, err := s.Run()
+		assert.EqualError(t, err, "1 changes failed:\ncannot create user carol@example.com: Invalid user")
+	})
+}
+
+func TestIdentitySyncKeepAndUnmanagedMembers(t *testing.T) {
+	qa.HTTPFixturesApply(t, append([]qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/preview/scim/v2/Me",
+			Response: User{ID: "u3", UserName: "admin@example.com"},
+		},
+	}, identitySyncListFixtures()...), func(ctx context.Context, client *common.DatabricksClient) {
+		s := NewIdentitySync(ctx, client, IdentitySource{
+			Users: []SourceUser{{UserName: "alice@example.com"}},
+			ServicePrincipals: []SourceServicePrincipal{
+				{ApplicationID: "00000000-0000-0000-0000-000000000001"},
+			},
+			Groups: []SourceGroup{{DisplayName: "data"}},
+		})
+		s.DeactivateMissing = true
+		s.Keep = []string{"Bob@example.com"}
+		report, err := s.Run()
+		require.NoError(t, err)
+		assert.Equal(t, IdentitySyncReport{}, report)
+	})
+}
+
+func TestIdentitySyncAccountRequiresKeep(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{}, func(ctx context.Context, client *common.DatabricksClient) {
+		client.Config.WithTesting().AccountID = "abc"
+		s := NewIdentitySync(ctx, client, identitySyncSource)
+		s.DeactivateMissing = true
+		_, err := s.Run()
+		assert.EqualError(t, err, "deactivating missing principals on the account level requires "+
+			"the principals to keep, like the one running the sync")
+	})
+}
@@ -0,0 +1,177 @@
+package scim
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/databricks/terraform-provider-databricks/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	groupMembersAuthoritative = "authoritative"
+	groupMembersAdditive      = "additive"
+)
+
+// GroupMembers is the set of members of a group, that are managed together
+type GroupMembers struct {
+	GroupID string   `json:"group_id" tf:"force_new"`
+	Members []string `json:"members" tf:"slice_set"`
+	Mode    string   `json:"mode,omitempty" tf:"default:authoritative"`
+}
+
+// membersPatch returns the minimal patch, that adds and removes the given members
+func membersPatch(add, remove []string) patchRequest {
+	operations := []patchOperation{}
+	if len(add) > 0 {
+		values := []ComplexValue{}
+		for _, v := range add {
+			values = append(values, ComplexValue{Value: v})
+		}
+		operations = append(operations, patchOperation{
+			Op:    "add",
+			Path:  "members",
+			Value: values,
+		})
+	}
+	for _, v := range remove {
+		operations = append(operations, patchOperation{
+			Op:   "remove",
+			Path: fmt.Sprintf(`members[value eq "%s"]`, v),
+		})
+	}
+	return PatchRequestComplexValue(operations)
+}
+
+// membersDiff returns sorted members, that are only in the first or only in the second list
+func membersDiff(current, desired []string) (add []string, remove []string) {
+	currentSet := map[string]bool{}
+	for _, v := range current {
+		currentSet[v] = true
+	}
+	desiredSet := map[string]bool{}
+	for _, v := range desired {
+		desiredSet[v] = true
+		if !currentSet[v] {
+			add = append(add, v)
+		}
+	}
+	for _, v := range current {
+		if !desiredSet[v] {
+			remove = append(remove, v)
+		}
+	}
+	sort.Strings(add)
+	sort.Strings(remove)
+	return
+}
+
+func (gm GroupMembers) currentMembers(a GroupsAPI) ([]string, error) {
+	group, err := a.Read(gm.GroupID, "members")
+	if err != nil {
+		return nil, err
+	}
+	members := []string{}
+	for _, m := range group.Members {
+		members = append(members, m.Value)
+	}
+	return members, nil
+}
+
+// reconcile brings group members to the desired state. In the additive mode only the members,
+// that were previously managed by this resource, are removed.
+func (gm GroupMembers) reconcile(a GroupsAPI, previous []string) error {
+	current, err := gm.currentMembers(a)
+	if err != nil {
+		return err
+	}
+	add, remove := membersDiff(current, gm.Members)
+	if gm.Mode == groupMembersAdditive {
+		noLongerManaged, _ := membersDiff(gm.Members, previous)
+		remove = []string{}
+		for _, v := range noLongerManaged {
+			if slices.Contains(current, v) {
+				remove = append(remove, v)
+			}
+		}
+	}
+	if len(add) == 0 && len(remove) == 0 {
+		return nil
+	}
+	return a.Patch(gm.GroupID, membersPatch(add, remove))
+}
+
+func membersFromState(v any) []string {
+	members := []string{}
+	for _, m := range v.(*schema.Set).List() {
+		members = append(members, m.(string))
+	}
+	return members
+}
+
+// ResourceGroupMembers manages the complete set of members of a group
+func ResourceGroupMembers() common.Resource {
+	s := common.StructToSchema(GroupMembers{},
+		func(m map[string]*schema.Schema) map[string]*schema.Schema {
+			m["mode"].ValidateFunc = validation.StringInSlice([]string{
+				groupMembersAuthoritative, groupMembersAdditive}, false)
+			return m
+		})
+	return common.Resource{
+		Schema: s,
+		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			var gm GroupMembers
+			common.DataToStructPointer(d, s, &gm)
+			err := gm.reconcile(NewGroupsAPI(ctx, c), []string{})
+			if err != nil {
+				return err
+			}
+			d.SetId(gm.GroupID)
+			return nil
+		},
+		Read: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			var gm GroupMembers
+			common.DataToStructPointer(d, s, &gm)
+			gm.GroupID = d.Id()
+			if gm.Mode == "" {
+				// imported resource
+				gm.Mode = groupMembersAuthoritative
+			}
+			current, err := gm.currentMembers(NewGroupsAPI(ctx, c))
+			if err != nil {
+				return err
+			}
+			if gm.Mode == groupMembersAdditive {
+				// members added outside of Terraform are not managed in the additive mode
+				managed := []string{}
+				for _, v := range current {
+					if slices.Contains(gm.Members, v) {
+						managed = append(managed, v)
+					}
+				}
+				current = managed
+			}
+			gm.Members = current
+			return common.StructToData(gm, s, d)
+		},
+		Update: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			var gm GroupMembers
+			common.DataToStructPointer(d, s, &gm)
+			old, _ := d.GetChange("members")
+			return gm.reconcile(NewGroupsAPI(ctx, c), membersFromState(old))
+		},
+		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			var gm GroupMembers
+			common.DataToStructPointer(d, s, &gm)
+			if len(gm.Members) == 0 {
+				return nil
+			}
+			// only members managed by this resource are removed, regardless of the mode
+			sort.Strings(gm.Members)
+			return NewGroupsAPI(ctx, c).Patch(gm.GroupID, membersPatch(nil, gm.Members))
+		},
+	}
+}
@@ -0,0 +1,269 @@
+package scim
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/databricks/terraform-provider-databricks/common"
+	"github.com/databricks/terraform-provider-databricks/qa"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func groupWithMembers(members ...string) Group {
+	values := []ComplexValue{}
+	for _, m := range members {
+		values = append(values, ComplexValue{Value: m})
+	}
+	return Group{
+		ID:          "abc",
+		DisplayName: "Data Scientists",
+		Members:     values,
+	}
+}
+
+func TestMembersPatch(t *testing.T) {
+	assert.Equal(t, PatchRequestComplexValue([]patchOperation{
+		{"add", "members", []ComplexValue{{Value: "a"}, {Value: "b"}}},
+		{"remove", `members[value eq "c"]`, nil},
+	}), membersPatch([]string{"a", "b"}, []string{"c"}))
+	assert.Equal(t, PatchRequestComplexValue([]patchOperation{}), membersPatch(nil, nil))
+}
+
+func TestMembersDiff(t *testing.T) {
+	add, remove := membersDiff([]string{"d", "a", "b"}, []string{"c", "b", "e"})
+	assert.Equal(t, []string{"c", "e"}, add)
+	assert.Equal(t, []string{"a", "d"}, remove)
+}
+
+func TestResourceGroupMembersCornerCases(t *testing.T) {
+	qa.ResourceCornerCases(t, ResourceGroupMembers(), qa.CornerCaseID("abc"),
+		// nothing is removed from the group without members in the state
+		qa.CornerCaseSkipCRUD("delete"))
+}
+
+func TestResourceGroupMembersCreate_Authoritative(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Groups/abc?attributes=members",
+				Response: groupWithMembers("a", "x"),
+			},
+			{
+				Method:          "PATCH",
+				Resource:        "/api/2.0/preview/scim/v2/Groups/abc",
+				ExpectedRequest: membersPatch([]string{"b", "c"}, []string{"x"}),
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Groups/abc?attributes=members",
+				Response: groupWithMembers("a", "b", "c"),
+			},
+		},
+		Resource: ResourceGroupMembers(),
+		HCL: `
+		group_id = "abc"
+		members = ["a", "b", "c"]
+		`,
+		Create: true,
+	}.ApplyAndExpectData(t, map[string]any{
+		"id":        "abc",
+		"mode":      "authoritative",
+		"members.#": 3,
+	})
+}
+
+func TestResourceGroupMembersCreate_Additive(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Groups/abc?attributes=members",
+				Response: groupWithMembers("a", "x"),
+			},
+			{
+				Method:          "PATCH",
+				Resource:        "/api/2.0/preview/scim/v2/Groups/abc",
+				ExpectedRequest: membersPatch([]string{"b"}, nil),
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Groups/abc?attributes=members",
+				Response: groupWithMembers("a", "b", "x"),
+			},
+		},
+		Resource: ResourceGroupMembers(),
+		HCL: `
+		group_id = "abc"
+		members = ["a", "b"]
+		mode = "additive"
+		`,
+		Create: true,
+	}.ApplyAndExpectData(t, map[string]any{
+		"id":        "abc",
+		"members.#": 2,
+	})
+}
+
+func TestResourceGroupMembersCreate_NoChanges(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:       "GET",
+				Resource:     "/api/2.0/preview/scim/v2/Groups/abc?attributes=members",
+				Response:     groupWithMembers("a"),
+				ReuseRequest: true,
+			},
+		},
+		Resource: ResourceGroupMembers(),
+		HCL: `
+		group_id = "abc"
+		members = ["a"]
+		`,
+		Create: true,
+	}.ApplyNoError(t)
+}
+
+func TestResourceGroupMembersRead_Authoritative(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Groups/abc?attributes=members",
+				Response: groupWithMembers("a", "x"),
+			},
+		},
+		Resource: ResourceGroupMembers(),
+		Read:     true,
+		New:      true,
+		ID:       "abc",
+	}.ApplyAndExpectData(t, map[string]any{
+		"group_id":  "abc",
+		"mode":      "authoritative",
+		"members.#": 2,
+	})
+}
+
+func TestResourceGroupMembersRead_Additive(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Groups/abc?attributes=members",
+				Response: groupWithMembers("a", "x"),
+			},
+		},
+		Resource: ResourceGroupMembers(),
+		Read:     true,
+		ID:       "abc",
+		HCL: `
+		group_id = "abc"
+		members = ["a", "b"]
+		mode = "additive"
+		`,
+	}.ApplyAndExpectData(t, map[string]any{
+		"members.#": 1,
+	})
+}
+
+func TestResourceGroupMembersRead_NotFound(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Groups/abc?attributes=members",
+				Response: map[string]any{
+					"detail": "Group with id abc not found.",
+					"status": "404",
+				},
+				Status: 404,
+			},
+		},
+		Resource: ResourceGroupMembers(),
+		Read:     true,
+		Removed:  true,
+		ID:       "abc",
+	}.ApplyNoError(t)
+}
+
+func TestResourceGroupMembersUpdate_Additive(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Groups/abc?attributes=members",
+				Response: groupWithMembers("a", "x"),
+			},
+			{
+				Method:          "PATCH",
+				Resource:        "/api/2.0/preview/scim/v2/Groups/abc",
+				ExpectedRequest: membersPatch([]string{"c"}, nil),
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Groups/abc?attributes=members",
+				Response: groupWithMembers("a", "c", "x"),
+			},
+		},
+		Resource: ResourceGroupMembers(),
+		Update:   true,
+		ID:       "abc",
+		InstanceState: map[string]string{
+			"group_id":  "abc",
+			"mode":      "additive",
+			"members.#": "1",
+			fmt.Sprintf("members.%d", schema.HashString("a")): "a",
+		},
+		HCL: `
+		group_id = "abc"
+		members = ["a", "c"]
+		mode = "additive"
+		`,
+	}.ApplyAndExpectData(t, map[string]any{
+		"members.#": 2,
+	})
+}
+
+func TestGroupMembersReconcile_AdditiveRemovesOnlyPreviouslyManaged(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/preview/scim/v2/Groups/abc?attributes=members",
+			Response: groupWithMembers("a", "b", "x"),
+		},
+		{
+			Method:          "PATCH",
+			Resource:        "/api/2.0/preview/scim/v2/Groups/abc",
+			ExpectedRequest: membersPatch([]string{"c"}, []string{"b"}),
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		gm := GroupMembers{
+			GroupID: "abc",
+			Members: []string{"a", "c"},
+			Mode:    groupMembersAdditive,
+		}
+		err := gm.reconcile(NewGroupsAPI(ctx, client), []string{"a", "b", "d"})
+		assert.NoError(t, err)
+	})
+}
+
+func TestResourceGroupMembersDelete(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:          "PATCH",
+				Resource:        "/api/2.0/preview/scim/v2/Groups/abc",
+				ExpectedRequest: membersPatch(nil, []string{"a", "b"}),
+			},
+		},
+		Resource: ResourceGroupMembers(),
+		Delete:   true,
+		ID:       "abc",
+		HCL: `
+		group_id = "abc"
+		members = ["b", "a"]
+		`,
+	}.ApplyNoError(t)
+}
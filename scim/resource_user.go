@@ -58,6 +58,10 @@ func ResourceUser() common.Resource {
 				Type:     schema.TypeBool,
 				Optional: true,
 			}
+			m["transfer_assets_to"] = &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			}
 			m["disable_as_user_deletion"] = &schema.Schema{
 				Type:     schema.TypeBool,
 				Optional: true,
@@ -124,6 +128,7 @@ func ResourceUser() common.Resource {
 			isAccount := c.Config.IsAccountClient() && c.Config.AccountID != ""
 			isForceDeleteRepos := d.Get("force_delete_repos").(bool)
 			isForceDeleteHomeDir := d.Get("force_delete_home_dir").(bool)
+			transferAssetsTo := d.Get("transfer_assets_to").(string)
 			// Determine if disable or delete
 			var isDisable bool
 			if isDisableP, exists := d.GetOkExists("disable_as_user_deletion"); exists {
@@ -139,6 +144,20 @@ func ResourceUser() common.Resource {
 			if !isAccount && isDisable && isForceDeleteHomeDir {
 				return fmt.Errorf("force_delete_home_dir: cannot force delete if disable_as_user_deletion is set")
 			}
+			if transferAssetsTo != "" && isAccount {
+				return fmt.Errorf("transfer_assets_to: cannot transfer assets in the accounts SCIM API")
+			}
+			if transferAssetsTo != "" && isForceDeleteHomeDir {
+				return fmt.Errorf("force_delete_home_dir: cannot force delete if transfer_assets_to is set")
+			}
+			// Transfer assets while the user still exists
+			var transferReport string
+			if transferAssetsTo != "" {
+				transferReport, err = transferUserAssets(ctx, c, userName, transferAssetsTo)
+				if err != nil {
+					return fmt.Errorf("transfer_assets_to: %w", err)
+				}
+			}
 			// Disable or delete
 			if isDisable {
 				r := PatchRequestWithValue("replace", "active", "false")
@@ -164,6 +183,12 @@ func ResourceUser() common.Resource {
 					}
 				}
 			}
+			if transferReport != "" {
+				return common.Warning{
+					Summary: fmt.Sprintf("Transferred assets of %s to %s", userName, transferAssetsTo),
+					Detail:  transferReport,
+				}
+			}
 			return nil
 		},
 	}
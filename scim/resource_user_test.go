@@ -6,6 +6,10 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/databricks/databricks-sdk-go/service/iam"
+	"github.com/databricks/databricks-sdk-go/service/jobs"
+	"github.com/databricks/databricks-sdk-go/service/pipelines"
+	"github.com/databricks/databricks-sdk-go/service/sql"
 	"github.com/databricks/terraform-provider-databricks/common"
 	"github.com/databricks/terraform-provider-databricks/workspace"
 
@@ -759,3 +763,350 @@ func TestUserResource_SparkConfDiffSuppress(t *testing.T) {
 	assert.True(t, scs.DiffSuppressFunc("user_name", "abcdef@example.com", "AbcDef@example.com", nil))
 	assert.False(t, scs.DiffSuppressFunc("user_name", "abcdef@example.com", "abcdef2@example.com", nil))
 }
+
+func TestResourceUserDelete_TransferAssets(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/workspace/list?path=%2FUsers%2Fabc",
+				Response: workspace.ObjectList{
+					Objects: []workspace.ObjectStatus{
+						{Path: "/Users/abc/dir", ObjectType: workspace.Directory},
+						{Path: "/Users/abc/file.txt", ObjectType: workspace.File},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/workspace/list?path=%2FUsers%2Fabc%2Fdir",
+				Response: workspace.ObjectList{
+					Objects: []workspace.ObjectStatus{
+						{Path: "/Users/abc/dir/nb", ObjectType: workspace.Notebook, Language: workspace.Python},
+					},
+				},
+			},
+			{
+				Method:          "POST",
+				Resource:        "/api/2.0/workspace/mkdirs",
+				ExpectedRequest: map[string]string{"path": "/Users/bcd/archive/abc"},
+			},
+			{
+				Method:          "POST",
+				Resource:        "/api/2.0/workspace/mkdirs",
+				ExpectedRequest: map[string]string{"path": "/Users/bcd/archive/abc/dir"},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/workspace/export?format=SOURCE&path=%2FUsers%2Fabc%2Fdir%2Fnb",
+				Response: workspace.ExportPath{Content: "cHJpbnQoMSk="},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/workspace/import",
+				ExpectedRequest: workspace.ImportPath{
+					Content:   "cHJpbnQoMSk=",
+					Path:      "/Users/bcd/archive/abc/dir/nb",
+					Language:  workspace.Python,
+					Format:    "SOURCE",
+					Overwrite: true,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/workspace/export?format=AUTO&path=%2FUsers%2Fabc%2Ffile.txt",
+				Response: workspace.ExportPath{Content: "YQ=="},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/workspace/import",
+				ExpectedRequest: workspace.ImportPath{
+					Content:   "YQ==",
+					Path:      "/Users/bcd/archive/abc/file.txt",
+					Format:    workspace.Auto,
+					Overwrite: true,
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/workspace/delete",
+				ExpectedRequest: workspace.DeletePath{
+					Path:      "/Users/abc",
+					Recursive: true,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/jobs/list?",
+				Response: jobs.ListJobsResponse{
+					Jobs: []jobs.BaseJob{
+						{
+							JobId: 1,
+							Settings: &jobs.JobSettings{
+								RunAs: &jobs.JobRunAs{UserName: "abc"},
+							},
+						},
+						{
+							JobId: 2,
+						},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/permissions/jobs/1?",
+				Response: iam.ObjectPermissions{
+					AccessControlList: []iam.AccessControlResponse{
+						{
+							UserName: "abc",
+							AllPermissions: []iam.Permission{
+								{PermissionLevel: iam.PermissionLevelIsOwner},
+							},
+						},
+					},
+				},
+			},
+			{
+				Method:   "PATCH",
+				Resource: "/api/2.0/permissions/jobs/1",
+				ExpectedRequest: iam.PermissionsRequest{
+					AccessControlList: []iam.AccessControlRequest{
+						{UserName: "bcd", PermissionLevel: iam.PermissionLevelIsOwner},
+					},
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.1/jobs/update",
+				ExpectedRequest: jobs.UpdateJob{
+					JobId: 1,
+					NewSettings: &jobs.JobSettings{
+						RunAs: &jobs.JobRunAs{UserName: "bcd"},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/permissions/jobs/2?",
+				Response: iam.ObjectPermissions{
+					AccessControlList: []iam.AccessControlResponse{
+						{
+							UserName: "abc",
+							AllPermissions: []iam.Permission{
+								{PermissionLevel: iam.PermissionLevelCanManage},
+							},
+						},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/pipelines?",
+				Response: pipelines.ListPipelinesResponse{
+					Statuses: []pipelines.PipelineStateInfo{
+						{PipelineId: "p1", RunAsUserName: "abc"},
+						{PipelineId: "p2", RunAsUserName: "other"},
+					},
+				},
+			},
+			{
+				Method:   "PATCH",
+				Resource: "/api/2.0/permissions/pipelines/p1",
+				ExpectedRequest: iam.PermissionsRequest{
+					AccessControlList: []iam.AccessControlRequest{
+						{UserName: "bcd", PermissionLevel: iam.PermissionLevelIsOwner},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/sql/warehouses?",
+				Response: sql.ListWarehousesResponse{
+					Warehouses: []sql.EndpointInfo{{Id: "w1"}},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/permissions/warehouses/w1?",
+				Response: iam.ObjectPermissions{},
+			},
+			{
+				Method:   "DELETE",
+				Resource: "/api/2.0/preview/scim/v2/Users/abc",
+			},
+		},
+		Resource: ResourceUser(),
+		Delete:   true,
+		ID:       "abc",
+		HCL: `
+			user_name = "abc"
+			transfer_assets_to = "bcd"
+		`,
+	}.ApplyNoError(t)
+}
+
+func TestTransferUserAssets_KeepsHomeDirWithObjectsThatCantBeMoved(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/workspace/list?path=%2FUsers%2Fabc",
+			Response: workspace.ObjectList{
+				Objects: []workspace.ObjectStatus{
+					{Path: "/Users/abc/project", ObjectType: "REPO"},
+					{Path: "/Users/abc/file.txt", ObjectType: workspace.File},
+				},
+			},
+		},
+		{
+			Method:          "POST",
+			Resource:        "/api/2.0/workspace/mkdirs",
+			ExpectedRequest: map[string]string{"path": "/Users/bcd/archive/abc"},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/workspace/export?format=AUTO&path=%2FUsers%2Fabc%2Ffile.txt",
+			Response: workspace.ExportPath{Content: "YQ=="},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/workspace/import",
+			ExpectedRequest: workspace.ImportPath{
+				Content:   "YQ==",
+				Path:      "/Users/bcd/archive/abc/file.txt",
+				Format:    workspace.Auto,
+				Overwrite: true,
+			},
+		},
+		{
+			// only the copied object is removed
+			Method:   "POST",
+			Resource: "/api/2.0/workspace/delete",
+			ExpectedRequest: workspace.DeletePath{
+				Path: "/Users/abc/file.txt",
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.1/jobs/list?",
+			Response: jobs.ListJobsResponse{},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/pipelines?",
+			Response: pipelines.ListPipelinesResponse{},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/sql/warehouses?",
+			Response: sql.ListWarehousesResponse{},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		report, err := transferUserAssets(ctx, client, "abc", "bcd")
+		require.NoError(t, err)
+		assert.Equal(t, "moved 1 objects from home directory of abc to /Users/bcd/archive/abc, "+
+			"changed owner of jobs [], run_as of jobs [], owner of pipelines [] and warehouses [] to bcd. "+
+			"Objects [/Users/abc/project] can't be moved and were kept in the home directory", report)
+	})
+}
+
+func TestResourceUserDelete_TransferAssetsToServicePrincipal(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/workspace/list?path=%2FUsers%2Fabc",
+				Response: common.APIErrorBody{
+					ErrorCode: "RESOURCE_DOES_NOT_EXIST",
+					Message:   "Path (/Users/abc) doesn't exist.",
+				},
+				Status: 404,
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/jobs/list?",
+				Response: jobs.ListJobsResponse{
+					Jobs: []jobs.BaseJob{
+						{
+							JobId: 1,
+							Settings: &jobs.JobSettings{
+								RunAs: &jobs.JobRunAs{UserName: "abc"},
+							},
+						},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/permissions/jobs/1?",
+				Response: iam.ObjectPermissions{},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.1/jobs/update",
+				ExpectedRequest: jobs.UpdateJob{
+					JobId: 1,
+					NewSettings: &jobs.JobSettings{
+						RunAs: &jobs.JobRunAs{ServicePrincipalName: "00000000-0000-0000-0000-000000000001"},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/pipelines?",
+				Response: pipelines.ListPipelinesResponse{},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/sql/warehouses?",
+				Response: sql.ListWarehousesResponse{},
+			},
+			{
+				Method:   "DELETE",
+				Resource: "/api/2.0/preview/scim/v2/Users/abc",
+			},
+		},
+		Resource: ResourceUser(),
+		Delete:   true,
+		ID:       "abc",
+		HCL: `
+			user_name = "abc"
+			transfer_assets_to = "00000000-0000-0000-0000-000000000001"
+		`,
+	}.ApplyNoError(t)
+}
+
+func TestResourceUserDelete_TransferAssetsError(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/workspace/list?path=%2FUsers%2Fabc",
+				Response: common.APIErrorBody{
+					ErrorCode: "PERMISSION_DENIED",
+					Message:   "Access denied",
+				},
+				Status: 403,
+			},
+		},
+		Resource: ResourceUser(),
+		Delete:   true,
+		ID:       "abc",
+		HCL: `
+			user_name = "abc"
+			transfer_assets_to = "bcd"
+		`,
+	}.ExpectError(t, "transfer_assets_to: Access denied")
+}
+
+func TestResourceUserDelete_TransferAssetsAndForceDeleteHomeDir(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceUser(),
+		Delete:   true,
+		ID:       "abc",
+		HCL: `
+			user_name = "abc"
+			transfer_assets_to = "bcd"
+			force_delete_home_dir = true
+		`,
+	}.ExpectError(t, "force_delete_home_dir: cannot force delete if transfer_assets_to is set")
+}
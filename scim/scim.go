@@ -1,6 +1,8 @@
 package scim
 
 import (
+	"strings"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -188,3 +190,10 @@ func PatchRequestComplexValue(operations []patchOperation) patchRequest {
 		Operations: operations,
 	}
 }
+
+// filterValue quotes the value for SCIM filter expressions, like `userName eq "a@example.com"`,
+// so that quotes and backslashes in names can't change the expression
+func filterValue(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	return `"` + strings.ReplaceAll(value, `"`, `\"`) + `"`
+}
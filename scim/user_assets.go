@@ -0,0 +1,286 @@
+package scim
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/databricks/databricks-sdk-go"
+	"github.com/databricks/databricks-sdk-go/apierr"
+	"github.com/databricks/databricks-sdk-go/service/iam"
+	"github.com/databricks/databricks-sdk-go/service/jobs"
+	"github.com/databricks/databricks-sdk-go/service/pipelines"
+	"github.com/databricks/databricks-sdk-go/service/sql"
+	"github.com/databricks/terraform-provider-databricks/common"
+	"github.com/databricks/terraform-provider-databricks/workspace"
+)
+
+// userAssetsTransfer moves workspace objects and reassigns ownership of the objects of a user,
+// who is going to be deleted, to another user or service principal.
+type userAssetsTransfer struct {
+	ctx      context.Context
+	c        *common.DatabricksClient
+	w        *databricks.WorkspaceClient
+	userName string
+	to       string
+
+	movedObjects int
+	keptObjects  []string
+	archivePath  string
+	jobs         []string
+	jobsRunAs    []string
+	pipelines    []string
+	warehouses   []string
+}
+
+func newUserAssetsTransfer(ctx context.Context, c *common.DatabricksClient,
+	userName, to string) (*userAssetsTransfer, error) {
+	w, err := c.WorkspaceClient()
+	if err != nil {
+		return nil, err
+	}
+	return &userAssetsTransfer{
+		ctx:         ctx,
+		c:           c,
+		w:           w,
+		userName:    userName,
+		to:          to,
+		archivePath: fmt.Sprintf("/Users/%s/archive/%s", to, userName),
+	}, nil
+}
+
+// service principals are referenced by application ID
+func (t *userAssetsTransfer) isServicePrincipal() bool {
+	return common.StringIsUUID(t.to)
+}
+
+func (t *userAssetsTransfer) owner() iam.AccessControlRequest {
+	if t.isServicePrincipal() {
+		return iam.AccessControlRequest{
+			ServicePrincipalName: t.to,
+			PermissionLevel:      iam.PermissionLevelIsOwner,
+		}
+	}
+	return iam.AccessControlRequest{
+		UserName:        t.to,
+		PermissionLevel: iam.PermissionLevelIsOwner,
+	}
+}
+
+func (t *userAssetsTransfer) runAs() *jobs.JobRunAs {
+	if t.isServicePrincipal() {
+		return &jobs.JobRunAs{ServicePrincipalName: t.to}
+	}
+	return &jobs.JobRunAs{UserName: t.to}
+}
+
+func (t *userAssetsTransfer) isOwner(requestObjectType, id string) (bool, error) {
+	permissions, err := t.w.Permissions.Get(t.ctx, iam.GetPermissionRequest{
+		RequestObjectType: requestObjectType,
+		RequestObjectId:   id,
+	})
+	if err != nil {
+		return false, err
+	}
+	for _, ac := range permissions.AccessControlList {
+		if ac.UserName != t.userName {
+			continue
+		}
+		for _, p := range ac.AllPermissions {
+			if p.PermissionLevel == iam.PermissionLevelIsOwner && !p.Inherited {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+func (t *userAssetsTransfer) changeOwner(requestObjectType, id string) error {
+	_, err := t.w.Permissions.Update(t.ctx, iam.PermissionsRequest{
+		RequestObjectType: requestObjectType,
+		RequestObjectId:   id,
+		AccessControlList: []iam.AccessControlRequest{t.owner()},
+	})
+	return err
+}
+
+// moveHomeDir copies the content of the home directory to the archive path and removes the original.
+// Objects, that can't be exported, like Git folders, are kept, and so is the home directory with them,
+// while all copied objects are removed from it.
+func (t *userAssetsTransfer) moveHomeDir() error {
+	notebooksAPI := workspace.NewNotebooksAPI(t.ctx, t.c)
+	home := fmt.Sprintf("/Users/%s", t.userName)
+	objects, err := notebooksAPI.List(home, true, false)
+	if apierr.IsMissing(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if len(objects) == 0 {
+		return nil
+	}
+	err = notebooksAPI.Mkdirs(t.archivePath)
+	if err != nil {
+		return err
+	}
+	copied := []string{}
+	for _, object := range objects {
+		target := t.archivePath + strings.TrimPrefix(object.Path, home)
+		switch object.ObjectType {
+		case workspace.Directory:
+			err = notebooksAPI.Mkdirs(target)
+		case workspace.Notebook:
+			err = t.copyObject(notebooksAPI, object, target, "SOURCE")
+			copied = append(copied, object.Path)
+		case workspace.File:
+			err = t.copyObject(notebooksAPI, object, target, workspace.Auto)
+			copied = append(copied, object.Path)
+		default:
+			log.Printf("[WARN] Keeping %s %s", object.ObjectType, object.Path)
+			t.keptObjects = append(t.keptObjects, object.Path)
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("cannot move %s: %w", object.Path, err)
+		}
+		t.movedObjects++
+	}
+	if len(t.keptObjects) == 0 {
+		return notebooksAPI.Delete(home, true)
+	}
+	for _, path := range copied {
+		err = notebooksAPI.Delete(path, false)
+		if err != nil {
+			return fmt.Errorf("cannot remove moved %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+func (t *userAssetsTransfer) copyObject(notebooksAPI workspace.NotebooksAPI, object workspace.ObjectStatus,
+	target, format string) error {
+	content, err := notebooksAPI.Export(object.Path, format)
+	if err != nil {
+		return err
+	}
+	return notebooksAPI.Create(workspace.ImportPath{
+		Content:   content,
+		Path:      target,
+		Language:  object.Language,
+		Format:    format,
+		Overwrite: true,
+	})
+}
+
+func (t *userAssetsTransfer) transferJobs() error {
+	all, err := t.w.Jobs.ListAll(t.ctx, jobs.ListJobsRequest{})
+	if err != nil {
+		return err
+	}
+	for _, job := range all {
+		id := strconv.FormatInt(job.JobId, 10)
+		owner, err := t.isOwner("jobs", id)
+		if err != nil {
+			return fmt.Errorf("job %s: %w", id, err)
+		}
+		if owner {
+			err = t.changeOwner("jobs", id)
+			if err != nil {
+				return fmt.Errorf("job %s: %w", id, err)
+			}
+			t.jobs = append(t.jobs, id)
+		}
+		if job.Settings == nil || job.Settings.RunAs == nil || job.Settings.RunAs.UserName != t.userName {
+			continue
+		}
+		err = t.w.Jobs.Update(t.ctx, jobs.UpdateJob{
+			JobId: job.JobId,
+			NewSettings: &jobs.JobSettings{
+				RunAs: t.runAs(),
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("job %s: %w", id, err)
+		}
+		t.jobsRunAs = append(t.jobsRunAs, id)
+	}
+	return nil
+}
+
+// pipelines always run as their owner
+func (t *userAssetsTransfer) transferPipelines() error {
+	all, err := t.w.Pipelines.ListPipelinesAll(t.ctx, pipelines.ListPipelinesRequest{})
+	if err != nil {
+		return err
+	}
+	for _, pipeline := range all {
+		if pipeline.RunAsUserName != t.userName {
+			continue
+		}
+		err = t.changeOwner("pipelines", pipeline.PipelineId)
+		if err != nil {
+			return fmt.Errorf("pipeline %s: %w", pipeline.PipelineId, err)
+		}
+		t.pipelines = append(t.pipelines, pipeline.PipelineId)
+	}
+	return nil
+}
+
+func (t *userAssetsTransfer) transferWarehouses() error {
+	all, err := t.w.Warehouses.ListAll(t.ctx, sql.ListWarehousesRequest{})
+	if err != nil {
+		return err
+	}
+	for _, warehouse := range all {
+		owner, err := t.isOwner("warehouses", warehouse.Id)
+		if err != nil {
+			return fmt.Errorf("warehouse %s: %w", warehouse.Id, err)
+		}
+		if !owner {
+			continue
+		}
+		err = t.changeOwner("warehouses", warehouse.Id)
+		if err != nil {
+			return fmt.Errorf("warehouse %s: %w", warehouse.Id, err)
+		}
+		t.warehouses = append(t.warehouses, warehouse.Id)
+	}
+	return nil
+}
+
+func (t *userAssetsTransfer) report() string {
+	report := fmt.Sprintf("moved %d objects from home directory of %s to %s, "+
+		"changed owner of jobs %v, run_as of jobs %v, owner of pipelines %v and warehouses %v to %s",
+		t.movedObjects, t.userName, t.archivePath, t.jobs, t.jobsRunAs, t.pipelines, t.warehouses, t.to)
+	if len(t.keptObjects) > 0 {
+		report += fmt.Sprintf(". Objects %v can't be moved and were kept in the home directory", t.keptObjects)
+	}
+	return report
+}
+
+// transferUserAssets moves home directory of the user to the archive folder in the home directory
+// of the new owner and reassigns ownership and run_as of jobs, pipelines and SQL warehouses.
+// It returns the report of the transferred assets.
+func transferUserAssets(ctx context.Context, c *common.DatabricksClient, userName, to string) (string, error) {
+	t, err := newUserAssetsTransfer(ctx, c, userName, to)
+	if err != nil {
+		return "", err
+	}
+	for _, step := range []func() error{
+		t.moveHomeDir,
+		t.transferJobs,
+		t.transferPipelines,
+		t.transferWarehouses,
+	} {
+		err = step()
+		if err != nil {
+			log.Printf("[WARN] Partially transferred assets: %s", t.report())
+			return "", err
+		}
+	}
+	log.Printf("[INFO] Transferred assets: %s", t.report())
+	return t.report(), nil
+}
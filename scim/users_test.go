@@ -40,3 +40,8 @@ func TestUsersFilter(t *testing.T) {
 	require.NoError(t, err)
 	assert.Len(t, users, 0)
 }
+
+func TestFilterValue(t *testing.T) {
+	assert.Equal(t, `"a@example.com"`, filterValue("a@example.com"))
+	assert.Equal(t, `"a\"b\\c"`, filterValue(`a"b\c`))
+}
@@ -0,0 +1,28 @@
+package tokens
+
+import (
+	"context"
+	"errors"
+
+	"github.com/databricks/terraform-provider-databricks/common"
+)
+
+// DataSourceServicePrincipalFederationPolicies lists federation policies of a service principal
+func DataSourceServicePrincipalFederationPolicies() common.Resource {
+	type federationPoliciesData struct {
+		ServicePrincipalID string             `json:"service_principal_id"`
+		Policies           []FederationPolicy `json:"policies,omitempty" tf:"computed"`
+	}
+	return common.DataResource(federationPoliciesData{}, func(ctx context.Context, e any, c *common.DatabricksClient) error {
+		if c.Config.AccountID == "" {
+			return errors.New("must have `account_id` on provider")
+		}
+		data := e.(*federationPoliciesData)
+		policies, err := NewServicePrincipalFederationPolicyAPI(ctx, c).List(data.ServicePrincipalID)
+		if err != nil {
+			return err
+		}
+		data.Policies = policies
+		return nil
+	})
+}
@@ -0,0 +1,174 @@
+package tokens
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/databricks/terraform-provider-databricks/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// OidcFederationPolicy specifies, which tokens of an external identity provider could be exchanged for Databricks tokens
+type OidcFederationPolicy struct {
+	Issuer       string   `json:"issuer"`
+	Audiences    []string `json:"audiences,omitempty"`
+	Subject      string   `json:"subject,omitempty"`
+	SubjectClaim string   `json:"subject_claim,omitempty" tf:"computed"`
+	JwksJSON     string   `json:"jwks_json,omitempty"`
+}
+
+// FederationPolicy allows workloads to authenticate as a service principal without client secrets
+type FederationPolicy struct {
+	PolicyID    string                `json:"policy_id,omitempty" tf:"computed"`
+	Name        string                `json:"name,omitempty" tf:"computed"`
+	UID         string                `json:"uid,omitempty" tf:"computed"`
+	Description string                `json:"description,omitempty"`
+	OidcPolicy  *OidcFederationPolicy `json:"oidc_policy"`
+	CreateTime  string                `json:"create_time,omitempty" tf:"computed"`
+	UpdateTime  string                `json:"update_time,omitempty" tf:"computed"`
+}
+
+// policyID extracts the last segment of accounts/<account_id>/servicePrincipals/<id>/federationPolicies/<policy_id>
+func (p FederationPolicy) policyID() string {
+	return p.Name[strings.LastIndex(p.Name, "/")+1:]
+}
+
+type ListFederationPolicies struct {
+	Policies      []FederationPolicy `json:"policies"`
+	NextPageToken string             `json:"next_page_token,omitempty"`
+}
+
+// NewServicePrincipalFederationPolicyAPI creates ServicePrincipalFederationPolicyAPI instance from provider meta
+func NewServicePrincipalFederationPolicyAPI(ctx context.Context, m any) ServicePrincipalFederationPolicyAPI {
+	return ServicePrincipalFederationPolicyAPI{m.(*common.DatabricksClient), ctx}
+}
+
+// ServicePrincipalFederationPolicyAPI exposes the API to manage federation policies of service principals
+type ServicePrincipalFederationPolicyAPI struct {
+	client  *common.DatabricksClient
+	context context.Context
+}
+
+func (a ServicePrincipalFederationPolicyAPI) path(spnID string) string {
+	return fmt.Sprintf("/accounts/%s/servicePrincipals/%s/federationPolicies", a.client.Config.AccountID, spnID)
+}
+
+func (a ServicePrincipalFederationPolicyAPI) Create(spnID string, policy FederationPolicy) (created FederationPolicy, err error) {
+	path := a.path(spnID)
+	if policy.PolicyID != "" {
+		path = fmt.Sprintf("%s?policy_id=%s", path, url.QueryEscape(policy.PolicyID))
+	}
+	err = a.client.Post(a.context, path, policy, &created)
+	return
+}
+
+func (a ServicePrincipalFederationPolicyAPI) Read(spnID, policyID string) (policy FederationPolicy, err error) {
+	err = a.client.Get(a.context, fmt.Sprintf("%s/%s", a.path(spnID), policyID), nil, &policy)
+	return
+}
+
+func (a ServicePrincipalFederationPolicyAPI) Update(spnID, policyID string, policy FederationPolicy) error {
+	path := fmt.Sprintf("%s/%s?update_mask=description,oidc_policy", a.path(spnID), policyID)
+	return a.client.Patch(a.context, path, policy)
+}
+
+func (a ServicePrincipalFederationPolicyAPI) Delete(spnID, policyID string) error {
+	return a.client.Delete(a.context, fmt.Sprintf("%s/%s", a.path(spnID), policyID), nil)
+}
+
+// List returns all federation policies of the service principal, following the pagination
+func (a ServicePrincipalFederationPolicyAPI) List(spnID string) ([]FederationPolicy, error) {
+	policies := []FederationPolicy{}
+	request := map[string]string{}
+	for {
+		var page ListFederationPolicies
+		err := a.client.Get(a.context, a.path(spnID), request, &page)
+		if err != nil {
+			return nil, err
+		}
+		for _, policy := range page.Policies {
+			policy.PolicyID = policy.policyID()
+			policies = append(policies, policy)
+		}
+		if page.NextPageToken == "" {
+			return policies, nil
+		}
+		request["page_token"] = page.NextPageToken
+	}
+}
+
+func ResourceServicePrincipalFederationPolicy() common.Resource {
+	s := common.StructToSchema(FederationPolicy{},
+		func(m map[string]*schema.Schema) map[string]*schema.Schema {
+			m["service_principal_id"] = &schema.Schema{
+				Type:     schema.TypeString,
+				ForceNew: true,
+				Required: true,
+			}
+			common.CustomizeSchemaPath(m, "policy_id").SetOptional().SetForceNew()
+			common.CustomizeSchemaPath(m, "oidc_policy", "issuer").SetForceNew()
+			return m
+		})
+	p := common.NewPairID("service_principal_id", "policy_id").Schema(
+		func(m map[string]*schema.Schema) map[string]*schema.Schema {
+			return s
+		})
+	return common.Resource{
+		Schema: s,
+		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			if c.Config.AccountID == "" {
+				return errors.New("must have `account_id` on provider")
+			}
+			var policy FederationPolicy
+			common.DataToStructPointer(d, s, &policy)
+			created, err := NewServicePrincipalFederationPolicyAPI(ctx, c).Create(
+				d.Get("service_principal_id").(string), policy)
+			if err != nil {
+				return err
+			}
+			d.Set("policy_id", created.policyID())
+			p.Pack(d)
+			return nil
+		},
+		Read: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			if c.Config.AccountID == "" {
+				return errors.New("must have `account_id` on provider")
+			}
+			spnID, policyID, err := p.Unpack(d)
+			if err != nil {
+				return err
+			}
+			policy, err := NewServicePrincipalFederationPolicyAPI(ctx, c).Read(spnID, policyID)
+			if err != nil {
+				return err
+			}
+			policy.PolicyID = policyID
+			return common.StructToData(policy, s, d)
+		},
+		Update: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			if c.Config.AccountID == "" {
+				return errors.New("must have `account_id` on provider")
+			}
+			spnID, policyID, err := p.Unpack(d)
+			if err != nil {
+				return err
+			}
+			var policy FederationPolicy
+			common.DataToStructPointer(d, s, &policy)
+			return NewServicePrincipalFederationPolicyAPI(ctx, c).Update(spnID, policyID, policy)
+		},
+		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			if c.Config.AccountID == "" {
+				return errors.New("must have `account_id` on provider")
+			}
+			spnID, policyID, err := p.Unpack(d)
+			if err != nil {
+				return err
+			}
+			return NewServicePrincipalFederationPolicyAPI(ctx, c).Delete(spnID, policyID)
+		},
+	}
+}
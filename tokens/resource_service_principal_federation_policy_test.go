@@ -0,0 +1,272 @@
+package tokens
+
+import (
+	"testing"
+
+	"github.com/databricks/terraform-provider-databricks/common"
+	"github.com/databricks/terraform-provider-databricks/qa"
+)
+
+const testFederationPolicyName = "accounts/xyz/servicePrincipals/123/federationPolicies/github"
+
+var testFederationPolicy = FederationPolicy{
+	Name:        testFederationPolicyName,
+	UID:         "00000000-0000-0000-0000-000000000001",
+	Description: "GitHub Actions",
+	OidcPolicy: &OidcFederationPolicy{
+		Issuer:       "https://token.actions.githubusercontent.com",
+		Audiences:    []string{"https://github.com/org"},
+		Subject:      "repo:org/repo:environment:prod",
+		SubjectClaim: "sub",
+	},
+	CreateTime: "2024-10-01T00:00:00Z",
+	UpdateTime: "2024-10-01T00:00:00Z",
+}
+
+func TestServicePrincipalFederationPolicyCreate(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/accounts/xyz/servicePrincipals/123/federationPolicies?policy_id=github",
+				ExpectedRequest: FederationPolicy{
+					PolicyID:    "github",
+					Description: "GitHub Actions",
+					OidcPolicy: &OidcFederationPolicy{
+						Issuer:    "https://token.actions.githubusercontent.com",
+						Audiences: []string{"https://github.com/org"},
+						Subject:   "repo:org/repo:environment:prod",
+					},
+				},
+				Response: testFederationPolicy,
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/xyz/servicePrincipals/123/federationPolicies/github",
+				Response: testFederationPolicy,
+			},
+		},
+		Resource:  ResourceServicePrincipalFederationPolicy(),
+		Create:    true,
+		AccountID: "xyz",
+		HCL: `
+		service_principal_id = "123"
+		policy_id            = "github"
+		description          = "GitHub Actions"
+		oidc_policy {
+			issuer    = "https://token.actions.githubusercontent.com"
+			audiences = ["https://github.com/org"]
+			subject   = "repo:org/repo:environment:prod"
+		}
+		`,
+	}.ApplyAndExpectData(t, map[string]any{
+		"id":                          "123|github",
+		"name":                        testFederationPolicyName,
+		"oidc_policy.0.subject_claim": "sub",
+	})
+}
+
+func TestServicePrincipalFederationPolicyCreate_GeneratedID(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/accounts/xyz/servicePrincipals/123/federationPolicies",
+				ExpectedRequest: FederationPolicy{
+					OidcPolicy: &OidcFederationPolicy{
+						Issuer: "https://kubernetes.default.svc",
+					},
+				},
+				Response: FederationPolicy{
+					Name: "accounts/xyz/servicePrincipals/123/federationPolicies/abc",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/xyz/servicePrincipals/123/federationPolicies/abc",
+				Response: FederationPolicy{
+					Name: "accounts/xyz/servicePrincipals/123/federationPolicies/abc",
+					OidcPolicy: &OidcFederationPolicy{
+						Issuer: "https://kubernetes.default.svc",
+					},
+				},
+			},
+		},
+		Resource:  ResourceServicePrincipalFederationPolicy(),
+		Create:    true,
+		AccountID: "xyz",
+		HCL: `
+		service_principal_id = "123"
+		oidc_policy {
+			issuer = "https://kubernetes.default.svc"
+		}
+		`,
+	}.ApplyAndExpectData(t, map[string]any{
+		"id":        "123|abc",
+		"policy_id": "abc",
+	})
+}
+
+func TestServicePrincipalFederationPolicyCreate_NoAccountID(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceServicePrincipalFederationPolicy(),
+		Create:   true,
+		HCL: `
+		service_principal_id = "123"
+		oidc_policy {
+			issuer = "https://kubernetes.default.svc"
+		}
+		`,
+	}.ExpectError(t, "must have `account_id` on provider")
+}
+
+func TestServicePrincipalFederationPolicyRead(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/xyz/servicePrincipals/123/federationPolicies/github",
+				Response: testFederationPolicy,
+			},
+		},
+		Resource:  ResourceServicePrincipalFederationPolicy(),
+		Read:      true,
+		New:       true,
+		AccountID: "xyz",
+		ID:        "123|github",
+	}.ApplyAndExpectData(t, map[string]any{
+		"service_principal_id":        "123",
+		"policy_id":                   "github",
+		"description":                 "GitHub Actions",
+		"oidc_policy.0.issuer":        "https://token.actions.githubusercontent.com",
+		"oidc_policy.0.audiences.#":   1,
+		"oidc_policy.0.subject_claim": "sub",
+	})
+}
+
+func TestServicePrincipalFederationPolicyRead_NotFound(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/xyz/servicePrincipals/123/federationPolicies/github",
+				Response: common.APIErrorBody{
+					ErrorCode: "RESOURCE_DOES_NOT_EXIST",
+					Message:   "Policy does not exist",
+				},
+				Status: 404,
+			},
+		},
+		Resource:  ResourceServicePrincipalFederationPolicy(),
+		Read:      true,
+		Removed:   true,
+		AccountID: "xyz",
+		ID:        "123|github",
+	}.ApplyNoError(t)
+}
+
+func TestServicePrincipalFederationPolicyUpdate(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "PATCH",
+				Resource: "/api/2.0/accounts/xyz/servicePrincipals/123/federationPolicies/github?update_mask=description,oidc_policy",
+				ExpectedRequest: FederationPolicy{
+					PolicyID:    "github",
+					Description: "GitHub Actions",
+					OidcPolicy: &OidcFederationPolicy{
+						Issuer:       "https://token.actions.githubusercontent.com",
+						Audiences:    []string{"https://github.com/org"},
+						Subject:      "repo:org/repo:environment:prod",
+						SubjectClaim: "sub",
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/xyz/servicePrincipals/123/federationPolicies/github",
+				Response: testFederationPolicy,
+			},
+		},
+		Resource:  ResourceServicePrincipalFederationPolicy(),
+		Update:    true,
+		AccountID: "xyz",
+		ID:        "123|github",
+		InstanceState: map[string]string{
+			"service_principal_id":        "123",
+			"policy_id":                   "github",
+			"description":                 "GitHub Actions",
+			"oidc_policy.#":               "1",
+			"oidc_policy.0.issuer":        "https://token.actions.githubusercontent.com",
+			"oidc_policy.0.audiences.#":   "1",
+			"oidc_policy.0.audiences.0":   "https://github.com/org",
+			"oidc_policy.0.subject":       "repo:org/repo:environment:main",
+			"oidc_policy.0.subject_claim": "sub",
+		},
+		HCL: `
+		service_principal_id = "123"
+		policy_id            = "github"
+		description          = "GitHub Actions"
+		oidc_policy {
+			issuer    = "https://token.actions.githubusercontent.com"
+			audiences = ["https://github.com/org"]
+			subject   = "repo:org/repo:environment:prod"
+		}
+		`,
+	}.ApplyNoError(t)
+}
+
+func TestServicePrincipalFederationPolicyDelete(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "DELETE",
+				Resource: "/api/2.0/accounts/xyz/servicePrincipals/123/federationPolicies/github",
+			},
+		},
+		Resource:  ResourceServicePrincipalFederationPolicy(),
+		Delete:    true,
+		AccountID: "xyz",
+		ID:        "123|github",
+	}.ApplyNoError(t)
+}
+
+func TestDataSourceServicePrincipalFederationPolicies(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/xyz/servicePrincipals/123/federationPolicies?",
+				Response: ListFederationPolicies{
+					Policies:      []FederationPolicy{testFederationPolicy},
+					NextPageToken: "next",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/xyz/servicePrincipals/123/federationPolicies?page_token=next",
+				Response: ListFederationPolicies{
+					Policies: []FederationPolicy{
+						{
+							Name: "accounts/xyz/servicePrincipals/123/federationPolicies/k8s",
+							OidcPolicy: &OidcFederationPolicy{
+								Issuer: "https://kubernetes.default.svc",
+							},
+						},
+					},
+				},
+			},
+		},
+		Resource:    DataSourceServicePrincipalFederationPolicies(),
+		Read:        true,
+		NonWritable: true,
+		AccountID:   "xyz",
+		ID:          "_",
+		HCL:         `service_principal_id = "123"`,
+	}.ApplyAndExpectData(t, map[string]any{
+		"policies.#":                      2,
+		"policies.0.policy_id":            "github",
+		"policies.1.policy_id":            "k8s",
+		"policies.1.oidc_policy.0.issuer": "https://kubernetes.default.svc",
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/databricks/databricks-sdk-go/apierr"
 	"github.com/databricks/terraform-provider-databricks/common"
@@ -14,6 +15,8 @@ type ServicePrincipalSecret struct {
 	ID     string `json:"id,omitempty"`
 	Secret string `json:"secret,omitempty" tf:"computed,sensitive"`
 	Status string `json:"status,omitempty" tf:"computed"`
+	// UTC time in RFC 3339 format
+	CreateTime string `json:"create_time,omitempty" tf:"computed"`
 }
 
 type ListServicePrincipalSecrets struct {
@@ -48,6 +51,35 @@ func (a ServicePrincipalSecretAPI) deleteServicePrincipalSecret(spnID, secretID
 	return a.client.Delete(a.context, path, nil)
 }
 
+// createSecret returns the ID and the value of a new secret
+func (a ServicePrincipalSecretAPI) createSecret(spnID string) (string, string, error) {
+	idSeen := map[string]bool{}
+	secrets, err := a.listServicePrincipalSecrets(spnID)
+	if err != nil {
+		return "", "", err
+	}
+	for _, v := range secrets.Secrets {
+		idSeen[v.ID] = true
+	}
+	secret, err := a.createServicePrincipalSecret(spnID)
+	if err != nil {
+		return "", "", err
+	}
+	secrets, err = a.listServicePrincipalSecrets(spnID)
+	if err != nil {
+		return "", "", err
+	}
+	// ugly hack because rpc does not return ID of created secret
+	id := ""
+	for _, v := range secrets.Secrets {
+		if len(idSeen) > 0 && idSeen[v.ID] {
+			continue
+		}
+		id = v.ID
+	}
+	return id, secret.Secret, nil
+}
+
 func ResourceServicePrincipalSecret() common.Resource {
 	spnSecretSchema := common.StructToSchema(ServicePrincipalSecret{},
 		func(m map[string]*schema.Schema) map[string]*schema.Schema {
@@ -57,40 +89,46 @@ func ResourceServicePrincipalSecret() common.Resource {
 				ForceNew: true,
 				Required: true,
 			}
+			m["rotation"] = rotationSchema()
+			m["rotation_time"] = &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			}
+			m["previous_secret_id"] = &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			}
+			m["previous_secret"] = &schema.Schema{
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			}
 			return m
 		})
+	rotation := credentialRotation{
+		value:         "secret",
+		previousID:    "previous_secret_id",
+		previousValue: "previous_secret",
+		create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) (string, string, error) {
+			return NewServicePrincipalSecretAPI(ctx, c).createSecret(d.Get("service_principal_id").(string))
+		},
+		delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient, id string) error {
+			return NewServicePrincipalSecretAPI(ctx, c).deleteServicePrincipalSecret(d.Get("service_principal_id").(string), id)
+		},
+	}
 	return common.Resource{
 		Schema: spnSecretSchema,
 		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 			if c.Config.AccountID == "" {
 				return errors.New("must have `account_id` on provider")
 			}
-			idSeen := map[string]bool{}
-			api := NewServicePrincipalSecretAPI(ctx, c)
-			spnID := d.Get("service_principal_id").(string)
-			secrets, err := api.listServicePrincipalSecrets(spnID)
-			if err != nil {
-				return err
-			}
-			for _, v := range secrets.Secrets {
-				idSeen[v.ID] = true
-			}
-			secret, err := api.createServicePrincipalSecret(spnID)
+			id, secret, err := rotation.create(ctx, d, c)
 			if err != nil {
 				return err
 			}
-			secrets, err = api.listServicePrincipalSecrets(spnID)
-			if err != nil {
-				return err
-			}
-			// ugly hack because rpc does not return ID of created secret
-			for _, v := range secrets.Secrets {
-				if len(idSeen) > 0 && idSeen[v.ID] {
-					continue
-				}
-				d.SetId(v.ID)
-			}
-			return d.Set("secret", secret.Secret)
+			d.SetId(id)
+			d.Set("rotation_time", now().Unix())
+			return d.Set("secret", secret)
 		},
 		Read: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 			if c.Config.AccountID == "" {
@@ -106,17 +144,31 @@ func ResourceServicePrincipalSecret() common.Resource {
 				if v.ID != d.Id() {
 					continue
 				}
+				if d.Get("rotation_time").(int) == 0 && v.CreateTime != "" {
+					// secrets created before rotation was introduced are rotated based on their creation time
+					createTime, err := time.Parse(time.RFC3339, v.CreateTime)
+					if err != nil {
+						return fmt.Errorf("invalid create_time of secret %s: %w", v.ID, err)
+					}
+					d.Set("rotation_time", createTime.Unix())
+				}
+				d.Set("create_time", v.CreateTime)
 				return d.Set("status", v.Status)
 			}
 			return apierr.NotFound("client secret not found")
 		},
+		Update: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			if c.Config.AccountID == "" {
+				return errors.New("must have `account_id` on provider")
+			}
+			return rotation.update(ctx, d, c)
+		},
 		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 			if c.Config.AccountID == "" {
 				return errors.New("must have `account_id` on provider")
 			}
-			api := NewServicePrincipalSecretAPI(ctx, c)
-			spnID := d.Get("service_principal_id").(string)
-			return api.deleteServicePrincipalSecret(spnID, d.Id())
+			return rotation.deleteAll(ctx, d, c)
 		},
+		CustomizeDiff: rotation.customizeDiff,
 	}
 }
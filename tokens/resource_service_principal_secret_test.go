@@ -93,3 +93,132 @@ func TestServicePrincipalSecretFuzz(t *testing.T) {
 	qa.ResourceCornerCases(t, ResourceServicePrincipalSecret(),
 		qa.CornerCaseExpectError("must have `account_id` on provider"))
 }
+
+func TestServicePrincipalSecretUpdate_Rotation(t *testing.T) {
+	fixedNow(t)
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "DELETE",
+				Resource: "/api/2.0/accounts/xyz/servicePrincipals/abc/credentials/secrets/001",
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/xyz/servicePrincipals/abc/credentials/secrets",
+				Response: ListServicePrincipalSecrets{
+					Secrets: []ServicePrincipalSecret{
+						{ID: "002"},
+					},
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/accounts/xyz/servicePrincipals/abc/credentials/secrets",
+				Response: ServicePrincipalSecret{
+					Secret: "new",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/xyz/servicePrincipals/abc/credentials/secrets",
+				Response: ListServicePrincipalSecrets{
+					Secrets: []ServicePrincipalSecret{
+						{ID: "002"},
+						{ID: "003"},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/xyz/servicePrincipals/abc/credentials/secrets",
+				Response: ListServicePrincipalSecrets{
+					Secrets: []ServicePrincipalSecret{
+						{ID: "002", Status: "ACTIVE"},
+						{ID: "003", Status: "ACTIVE"},
+					},
+				},
+			},
+		},
+		Resource:  ResourceServicePrincipalSecret(),
+		Update:    true,
+		AccountID: "xyz",
+		ID:        "002",
+		InstanceState: map[string]string{
+			"service_principal_id": "abc",
+			"secret":               "current",
+			"status":               "ACTIVE",
+			"previous_secret_id":   "001",
+			"previous_secret":      "previous",
+			"rotation_time":        daysAgo(90),
+		},
+		HCL: `
+		service_principal_id = "abc"
+		rotation {
+			rotate_after_days = 90
+			overlap_days      = 14
+		}
+		`,
+	}.ApplyAndExpectData(t, map[string]any{
+		"id":                 "003",
+		"secret":             "new",
+		"previous_secret_id": "002",
+		"previous_secret":    "current",
+	})
+}
+
+func TestServicePrincipalSecretDelete_WithPrevious(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "DELETE",
+				Resource: "/api/2.0/accounts/xyz/servicePrincipals/abc/credentials/secrets/003",
+			},
+			{
+				Method:   "DELETE",
+				Resource: "/api/2.0/accounts/xyz/servicePrincipals/abc/credentials/secrets/002",
+			},
+		},
+		Resource:  ResourceServicePrincipalSecret(),
+		ID:        "003",
+		Delete:    true,
+		AccountID: "xyz",
+		InstanceState: map[string]string{
+			"service_principal_id": "abc",
+			"previous_secret_id":   "002",
+		},
+		HCL: `
+		service_principal_id = "abc"
+		`,
+	}.ApplyNoError(t)
+}
+
+func TestServicePrincipalSecretRead_RotationTimeFromCreateTime(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/xyz/servicePrincipals/abc/credentials/secrets",
+				Response: ListServicePrincipalSecrets{
+					Secrets: []ServicePrincipalSecret{
+						{
+							ID:         "002",
+							Status:     "ACTIVE",
+							CreateTime: "2024-01-02T00:00:00Z",
+						},
+					},
+				},
+			},
+		},
+		Resource:  ResourceServicePrincipalSecret(),
+		Read:      true,
+		ID:        "002",
+		AccountID: "xyz",
+		HCL: `
+		service_principal_id = "abc"
+		`,
+	}.ApplyAndExpectData(t, map[string]any{
+		"status":        "ACTIVE",
+		"create_time":   "2024-01-02T00:00:00Z",
+		"rotation_time": 1704153600,
+	})
+}
@@ -128,29 +128,65 @@ func ResourceToken() common.Resource {
 			Optional: true,
 			Computed: true,
 		},
+		"rotation": rotationSchema(),
+		"rotation_time": {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+		"previous_token_id": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"previous_token_value": {
+			Type:      schema.TypeString,
+			Computed:  true,
+			Sensitive: true,
+		},
 	}
-	return common.Resource{
-		Schema: s,
-		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+	rotation := credentialRotation{
+		value:         "token_value",
+		previousID:    "previous_token_id",
+		previousValue: "previous_token_value",
+		create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) (string, string, error) {
 			comment := d.Get("comment").(string)
 			lifeTimeSeconds := d.Get("lifetime_seconds").(int)
 			tokenDuration := time.Duration(lifeTimeSeconds) * time.Second
 			tokenResp, err := NewTokensAPI(ctx, c).Create(tokenDuration, comment)
+			if err != nil {
+				return "", "", err
+			}
+			return tokenResp.TokenInfo.TokenID, tokenResp.TokenValue, nil
+		},
+		delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient, id string) error {
+			return NewTokensAPI(ctx, c).Delete(id)
+		},
+	}
+	return common.Resource{
+		Schema: s,
+		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			tokenID, tokenValue, err := rotation.create(ctx, d, c)
 			if err != nil {
 				return err
 			}
-			d.SetId(tokenResp.TokenInfo.TokenID)
-			return d.Set("token_value", tokenResp.TokenValue)
+			d.SetId(tokenID)
+			d.Set("rotation_time", now().Unix())
+			return d.Set("token_value", tokenValue)
 		},
 		Read: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 			tokenInfo, err := NewTokensAPI(ctx, c).Read(d.Id())
 			if err != nil {
 				return err
 			}
+			if d.Get("rotation_time").(int) == 0 && tokenInfo.CreationTime > 0 {
+				// tokens created before rotation was introduced are rotated based on their creation time
+				d.Set("rotation_time", tokenInfo.CreationTime/1000)
+			}
 			return common.StructToData(tokenInfo, s, d)
 		},
+		Update: rotation.update,
 		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
-			return NewTokensAPI(ctx, c).Delete(d.Id())
+			return rotation.deleteAll(ctx, d, c)
 		},
+		CustomizeDiff: rotation.customizeDiff,
 	}
 }
@@ -1,12 +1,15 @@
 package tokens
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/databricks/databricks-sdk-go/apierr"
 
 	"github.com/databricks/terraform-provider-databricks/common"
 	"github.com/databricks/terraform-provider-databricks/qa"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -253,3 +256,273 @@ func TestResourceTokenDelete_Error(t *testing.T) {
 	qa.AssertErrorStartsWith(t, err, "Internal error happened")
 	assert.Equal(t, "abc", d.Id())
 }
+
+var rotationNow = time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)
+
+func fixedNow(t *testing.T) {
+	now = func() time.Time {
+		return rotationNow
+	}
+	t.Cleanup(func() {
+		now = time.Now
+	})
+}
+
+func daysAgo(days int) string {
+	return fmt.Sprintf("%d", rotationNow.Add(-time.Duration(days)*day).Unix())
+}
+
+func TestResourceTokenUpdate_Rotation(t *testing.T) {
+	fixedNow(t)
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/token/create",
+				ExpectedRequest: TokenRequest{
+					Comment: "CI",
+				},
+				Response: TokenResponse{
+					TokenValue: "dapi-new",
+					TokenInfo: &TokenInfo{
+						TokenID: "new",
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/token/list",
+				Response: TokenList{
+					TokenInfos: []TokenInfo{
+						{TokenID: "old", Comment: "CI"},
+						{TokenID: "new", Comment: "CI"},
+					},
+				},
+			},
+		},
+		Resource: ResourceToken(),
+		Update:   true,
+		ID:       "old",
+		InstanceState: map[string]string{
+			"comment":       "CI",
+			"token_id":      "old",
+			"token_value":   "dapi-old",
+			"rotation_time": daysAgo(31),
+		},
+		HCL: `
+		comment = "CI"
+		rotation {
+			rotate_after_days = 30
+			overlap_days      = 7
+		}
+		`,
+	}.ApplyAndExpectData(t, map[string]any{
+		"id":                   "new",
+		"token_value":          "dapi-new",
+		"previous_token_id":    "old",
+		"previous_token_value": "dapi-old",
+		"rotation_time":        int(rotationNow.Unix()),
+	})
+}
+
+func TestResourceTokenUpdate_RotationWithoutOverlap(t *testing.T) {
+	fixedNow(t)
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/token/create",
+				Response: TokenResponse{
+					TokenValue: "dapi-new",
+					TokenInfo: &TokenInfo{
+						TokenID: "new",
+					},
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/token/delete",
+				ExpectedRequest: map[string]string{
+					"token_id": "old",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/token/list",
+				Response: TokenList{
+					TokenInfos: []TokenInfo{
+						{TokenID: "new"},
+					},
+				},
+			},
+		},
+		Resource: ResourceToken(),
+		Update:   true,
+		ID:       "old",
+		InstanceState: map[string]string{
+			"token_id":      "old",
+			"token_value":   "dapi-old",
+			"rotation_time": daysAgo(30),
+		},
+		HCL: `
+		rotation {
+			rotate_after_days = 30
+		}
+		`,
+	}.ApplyAndExpectData(t, map[string]any{
+		"id":                   "new",
+		"token_value":          "dapi-new",
+		"previous_token_id":    "",
+		"previous_token_value": "",
+	})
+}
+
+func TestResourceTokenUpdate_RotationWithFailedDeleteOfPrevious(t *testing.T) {
+	fixedNow(t)
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/token/create",
+				Response: TokenResponse{
+					TokenValue: "dapi-new",
+					TokenInfo: &TokenInfo{
+						TokenID: "new",
+					},
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/token/delete",
+				ExpectedRequest: map[string]string{
+					"token_id": "old",
+				},
+				Status: 500,
+				Response: apierr.APIError{
+					ErrorCode: "INTERNAL_ERROR",
+					Message:   "nope",
+				},
+			},
+		},
+		Resource: ResourceToken(),
+		Update:   true,
+		ID:       "old",
+		InstanceState: map[string]string{
+			"token_id":      "old",
+			"token_value":   "dapi-old",
+			"rotation_time": daysAgo(30),
+		},
+		HCL: `
+		rotation {
+			rotate_after_days = 30
+		}
+		`,
+	}.Apply(t)
+	assert.ErrorContains(t, err, "nope")
+	// the new token is kept in the state, and the previous one is deleted on the next apply
+	assert.Equal(t, "new", d.Id())
+	assert.Equal(t, "dapi-new", d.Get("token_value"))
+	assert.Equal(t, "old", d.Get("previous_token_id"))
+	assert.Equal(t, "dapi-old", d.Get("previous_token_value"))
+	assert.Equal(t, int(rotationNow.Unix()), d.Get("rotation_time"))
+}
+
+func TestResourceTokenUpdate_OverlapEnded(t *testing.T) {
+	fixedNow(t)
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/token/delete",
+				ExpectedRequest: map[string]string{
+					"token_id": "old",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/token/list",
+				Response: TokenList{
+					TokenInfos: []TokenInfo{
+						{TokenID: "new"},
+					},
+				},
+			},
+		},
+		Resource: ResourceToken(),
+		Update:   true,
+		ID:       "new",
+		InstanceState: map[string]string{
+			"token_id":             "new",
+			"token_value":          "dapi-new",
+			"previous_token_id":    "old",
+			"previous_token_value": "dapi-old",
+			"rotation_time":        daysAgo(7),
+		},
+		HCL: `
+		rotation {
+			rotate_after_days = 30
+			overlap_days      = 7
+		}
+		`,
+	}.ApplyAndExpectData(t, map[string]any{
+		"id":                   "new",
+		"token_value":          "dapi-new",
+		"previous_token_id":    "",
+		"previous_token_value": "",
+	})
+}
+
+func TestResourceTokenRotation_NotDue(t *testing.T) {
+	fixedNow(t)
+	qa.ResourceFixture{
+		Resource: ResourceToken(),
+		ID:       "new",
+		InstanceState: map[string]string{
+			"token_id":                     "new",
+			"token_value":                  "dapi-new",
+			"creation_time":                "10",
+			"expiry_time":                  "20",
+			"previous_token_id":            "old",
+			"previous_token_value":         "dapi-old",
+			"rotation_time":                daysAgo(6),
+			"rotation.#":                   "1",
+			"rotation.0.rotate_after_days": "30",
+			"rotation.0.overlap_days":      "7",
+		},
+		ExpectedDiff: map[string]*terraform.ResourceAttrDiff{},
+		HCL: `
+		rotation {
+			rotate_after_days = 30
+			overlap_days      = 7
+		}
+		`,
+	}.ApplyNoError(t)
+}
+
+func TestResourceTokenDelete_WithPrevious(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/token/delete",
+				ExpectedRequest: map[string]string{
+					"token_id": "new",
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/token/delete",
+				ExpectedRequest: map[string]string{
+					"token_id": "old",
+				},
+			},
+		},
+		Resource: ResourceToken(),
+		Delete:   true,
+		ID:       "new",
+		InstanceState: map[string]string{
+			"token_id":          "new",
+			"previous_token_id": "old",
+		},
+	}.ApplyNoError(t)
+}
@@ -0,0 +1,174 @@
+package tokens
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/databricks/terraform-provider-databricks/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// now is replaced in tests to check rotation thresholds deterministically
+var now = time.Now
+
+const day = 24 * time.Hour
+
+// Rotation configures replacement of a credential, once it's older than the threshold
+type Rotation struct {
+	RotateAfterDays int
+	OverlapDays     int
+}
+
+func rotationSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"rotate_after_days": {
+					Type:         schema.TypeInt,
+					Required:     true,
+					ValidateFunc: validation.IntAtLeast(1),
+				},
+				"overlap_days": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					ValidateFunc: validation.IntAtLeast(0),
+				},
+			},
+		},
+	}
+}
+
+// credentialRotation keeps the current and the previous credential in the state, so that consumers
+// could switch to the new one during the overlap window, before the previous one is deleted.
+type credentialRotation struct {
+	// attribute with the value of the current credential
+	value string
+	// attributes with ID and value of the previous credential
+	previousID    string
+	previousValue string
+	create        func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) (id, value string, err error)
+	delete        func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient, id string) error
+}
+
+type attributeGetter interface {
+	Get(key string) any
+}
+
+func getRotation(d attributeGetter) (rotation Rotation, ok bool) {
+	blocks := d.Get("rotation").([]any)
+	if len(blocks) == 0 || blocks[0] == nil {
+		return
+	}
+	block := blocks[0].(map[string]any)
+	rotation.RotateAfterDays = block["rotate_after_days"].(int)
+	rotation.OverlapDays = block["overlap_days"].(int)
+	return rotation, true
+}
+
+func rotationTime(d attributeGetter) time.Time {
+	return time.Unix(int64(d.Get("rotation_time").(int)), 0)
+}
+
+func (r Rotation) due(rotatedAt time.Time) bool {
+	return !now().Before(rotatedAt.Add(time.Duration(r.RotateAfterDays) * day))
+}
+
+func (r Rotation) overlapEnded(rotatedAt time.Time) bool {
+	return !now().Before(rotatedAt.Add(time.Duration(r.OverlapDays) * day))
+}
+
+// customizeDiff plans the rotation on the first apply after the threshold, and the deletion of
+// the previous credential on the first apply after the overlap window.
+func (cr credentialRotation) customizeDiff(ctx context.Context, d *schema.ResourceDiff) error {
+	if d.Id() == "" {
+		return nil
+	}
+	rotation, ok := getRotation(d)
+	previousID := d.Get(cr.previousID).(string)
+	rotatedAt := rotationTime(d)
+	switch {
+	case ok && rotation.due(rotatedAt):
+		log.Printf("[INFO] Credential %s was created at %s and is due for rotation", d.Id(), rotatedAt)
+		for _, key := range []string{cr.value, cr.previousID, cr.previousValue, "rotation_time"} {
+			if err := d.SetNewComputed(key); err != nil {
+				return err
+			}
+		}
+	case previousID != "" && (!ok || rotation.overlapEnded(rotatedAt)):
+		log.Printf("[INFO] Previous credential %s is due for deletion", previousID)
+		for _, key := range []string{cr.previousID, cr.previousValue} {
+			if err := d.SetNewComputed(key); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// update rotates the credential or deletes the previous one, as planned by customizeDiff. Values of
+// the credentials are taken from the prior state, as they're unknown in the plan. The state is updated
+// after every step, so that credentials aren't lost from it, if a later step fails.
+func (cr credentialRotation) update(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+	rotation, ok := getRotation(d)
+	previousID, _ := d.GetChange(cr.previousID)
+	previousValue, _ := d.GetChange(cr.previousValue)
+	currentValue, _ := d.GetChange(cr.value)
+	rotationTimestamp, _ := d.GetChange("rotation_time")
+	rotatedAt := time.Unix(int64(rotationTimestamp.(int)), 0)
+	setState := func() {
+		d.Set(cr.value, currentValue)
+		d.Set(cr.previousID, previousID)
+		d.Set(cr.previousValue, previousValue)
+		d.Set("rotation_time", rotationTimestamp)
+	}
+	setState()
+	if ok && rotation.due(rotatedAt) {
+		// only one previous credential is kept, even if the overlap is longer than the rotation period
+		if previousID != "" {
+			err := cr.delete(ctx, d, c, previousID.(string))
+			if err != nil {
+				return err
+			}
+			previousID, previousValue = "", ""
+			setState()
+		}
+		id, value, err := cr.create(ctx, d, c)
+		if err != nil {
+			return err
+		}
+		log.Printf("[INFO] Rotated credential %s with %s", d.Id(), id)
+		previousID, previousValue = d.Id(), currentValue
+		d.SetId(id)
+		currentValue = value
+		rotationTimestamp = int(now().Unix())
+		rotatedAt = now()
+		setState()
+	}
+	if previousID != "" && (!ok || rotation.overlapEnded(rotatedAt)) {
+		err := cr.delete(ctx, d, c, previousID.(string))
+		if err != nil {
+			return err
+		}
+		previousID, previousValue = "", ""
+		setState()
+	}
+	return nil
+}
+
+// deleteAll deletes the current and the previous credential
+func (cr credentialRotation) deleteAll(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+	err := cr.delete(ctx, d, c, d.Id())
+	if err != nil {
+		return err
+	}
+	previousID := d.Get(cr.previousID).(string)
+	if previousID == "" {
+		return nil
+	}
+	return cr.delete(ctx, d, c, previousID)
+}
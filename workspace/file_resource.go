@@ -4,13 +4,16 @@ import (
 	"context"
 	"crypto/md5"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"hash/fnv"
 	"log"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 
+	"github.com/databricks/databricks-sdk-go/service/workspace"
 	"github.com/databricks/terraform-provider-databricks/common"
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -177,6 +180,147 @@ func FileContentSchema(extra map[string]*schema.Schema) map[string]*schema.Schem
 	return s
 }
 
+var (
+	notebookHeader = regexp.MustCompile(`^(#|//|--) Databricks notebook source\s*$`)
+	cellMarker     = regexp.MustCompile(`^(#|//|--) COMMAND -{10}\s*$`)
+)
+
+// normalizeLineEndings makes content exported on Windows and Unix comparable
+func normalizeLineEndings(content []byte) []byte {
+	return []byte(strings.ReplaceAll(string(content), "\r\n", "\n"))
+}
+
+// normalizeNotebookSource removes parts of the notebook source, that are added or dropped by the export
+// independently of the user edits: the header, leading and trailing empty lines and trailing cell markers.
+func normalizeNotebookSource(content []byte) []byte {
+	lines := strings.Split(string(normalizeLineEndings(content)), "\n")
+	if len(lines) > 0 && notebookHeader.MatchString(lines[0]) {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 {
+		last := strings.TrimSpace(lines[len(lines)-1])
+		if last != "" && !cellMarker.MatchString(last) {
+			break
+		}
+		lines = lines[:len(lines)-1]
+	}
+	return []byte(strings.Join(lines, "\n"))
+}
+
+// normalizeJupyter removes outputs, execution counts and metadata from the exported notebook, as they change
+// whenever the notebook is run, and only keeps the sources of the cells
+func normalizeJupyter(content []byte) []byte {
+	var notebook map[string]any
+	err := json.Unmarshal(content, &notebook)
+	if err != nil {
+		log.Printf("[WARN] Cannot parse exported notebook, comparing it as is: %s", err)
+		return normalizeLineEndings(content)
+	}
+	delete(notebook, "metadata")
+	cells, _ := notebook["cells"].([]any)
+	for _, v := range cells {
+		cell, ok := v.(map[string]any)
+		if !ok {
+			continue
+		}
+		delete(cell, "metadata")
+		delete(cell, "outputs")
+		delete(cell, "execution_count")
+	}
+	// keys are sorted, so that the result doesn't depend on their order in the export
+	normalized, err := json.Marshal(notebook)
+	if err != nil {
+		return normalizeLineEndings(content)
+	}
+	return normalized
+}
+
+// remoteContentSchema adds attributes to detect edits of the content made outside of Terraform
+func remoteContentSchema(s map[string]*schema.Schema) map[string]*schema.Schema {
+	s["detect_remote_changes"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+	}
+	s["remote_md5"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	}
+	s["remote_content_modified"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+	}
+	return s
+}
+
+// remoteContentHash exports the object in the given format and hashes its normalized content
+func remoteContentHash(ctx context.Context, c *common.DatabricksClient, path string,
+	format workspace.ExportFormat, normalize func([]byte) []byte) (string, error) {
+	w, err := c.WorkspaceClient()
+	if err != nil {
+		return "", err
+	}
+	exported, err := w.Workspace.Export(ctx, workspace.ExportRequest{
+		Path:   path,
+		Format: format,
+	})
+	if err != nil {
+		return "", err
+	}
+	content, err := base64.StdEncoding.DecodeString(exported.Content)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", md5.Sum(normalize(content))), nil
+}
+
+// recordRemoteContent stores the hash of the content right after it was imported. The import has already
+// succeeded at this point, so failed export only leaves the hash empty to be recorded by the next refresh.
+func recordRemoteContent(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient,
+	format workspace.ExportFormat, normalize func([]byte) []byte) error {
+	if !d.Get("detect_remote_changes").(bool) {
+		d.Set("remote_md5", "")
+		return nil
+	}
+	hash, err := remoteContentHash(ctx, c, d.Id(), format, normalize)
+	if err != nil {
+		log.Printf("[WARN] Cannot export %s to detect remote changes, the next refresh retries: %s", d.Id(), err)
+		d.Set("remote_md5", "")
+		return nil
+	}
+	d.Set("remote_md5", hash)
+	return d.Set("remote_content_modified", false)
+}
+
+// checkRemoteContent plans an overwrite, if the content was edited after the last apply. If the hash
+// wasn't recorded by the last apply, it's recorded now.
+func checkRemoteContent(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient,
+	format workspace.ExportFormat, normalize func([]byte) []byte) error {
+	if !d.Get("detect_remote_changes").(bool) {
+		return nil
+	}
+	stored := d.Get("remote_md5").(string)
+	hash, err := remoteContentHash(ctx, c, d.Id(), format, normalize)
+	if err != nil && stored == "" {
+		// don't fail the read right after the create, that couldn't record the hash either
+		log.Printf("[WARN] Cannot export %s to detect remote changes: %s", d.Id(), err)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if stored == "" {
+		d.Set("remote_md5", hash)
+		return d.Set("remote_content_modified", false)
+	}
+	if hash != stored {
+		log.Printf("[INFO] Content of %s was modified outside of Terraform", d.Id())
+	}
+	return d.Set("remote_content_modified", hash != stored)
+}
+
 // PathListHash ...
 func PathListHash(v any) int {
 	h := fnv.New32a()
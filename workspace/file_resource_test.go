@@ -59,3 +59,32 @@ func TestFileContentSchemaClean(t *testing.T) {
 	assert.True(t, d.HasError())
 	assert.Equal(t, "Clean path required", d[0].Summary)
 }
+
+func TestNormalizeNotebookSource(t *testing.T) {
+	assert.Equal(t, "SELECT 1\n\n-- COMMAND ----------\n\nSELECT 2", string(normalizeNotebookSource([]byte(
+		"-- Databricks notebook source\r\nSELECT 1\r\n\r\n-- COMMAND ----------\r\n\r\nSELECT 2\r\n\r\n-- COMMAND ----------\r\n\r\n"))))
+	assert.Equal(t, "print(1)", string(normalizeNotebookSource([]byte(
+		"\nprint(1)\n# COMMAND ----------\n"))))
+	assert.Equal(t,
+		normalizeNotebookSource([]byte("# Databricks notebook source\nprint(1)\n")),
+		normalizeNotebookSource([]byte("print(1)")))
+}
+
+func TestNormalizeLineEndings(t *testing.T) {
+	assert.Equal(t, "a\nb\n", string(normalizeLineEndings([]byte("a\r\nb\r\n"))))
+}
+
+func TestNormalizeJupyter(t *testing.T) {
+	assert.Equal(t, `{"cells":[{"cell_type":"code","source":["print(1)"]}],"nbformat":4}`, string(normalizeJupyter([]byte(`{
+		"nbformat": 4,
+		"metadata": {"application/vnd.databricks.v1+notebook": {"notebookName": "foo"}},
+		"cells": [{
+			"cell_type": "code",
+			"execution_count": 3,
+			"metadata": {"collapsed": true},
+			"outputs": [{"output_type": "stream", "text": ["1"]}],
+			"source": ["print(1)"]
+		}]
+	}`))))
+	assert.Equal(t, "not json\n", string(normalizeJupyter([]byte("not json\r\n"))))
+}
@@ -3,6 +3,7 @@ package workspace
 import (
 	"context"
 	"encoding/base64"
+	"errors"
 	"log"
 	"path/filepath"
 	"strings"
@@ -203,9 +204,22 @@ func (a NotebooksAPI) Delete(path string, recursive bool) error {
 	}, nil)
 }
 
+// notebookNormalizer returns normalization of the exported content, that depends on the export format
+func notebookNormalizer(format string) func([]byte) []byte {
+	switch format {
+	case "SOURCE":
+		return normalizeNotebookSource
+	case "JUPYTER":
+		return normalizeJupyter
+	}
+	return func(content []byte) []byte {
+		return content
+	}
+}
+
 // ResourceNotebook manages notebooks
 func ResourceNotebook() common.Resource {
-	s := FileContentSchema(map[string]*schema.Schema{
+	s := remoteContentSchema(FileContentSchema(map[string]*schema.Schema{
 		"language": {
 			Type:     schema.TypeString,
 			Optional: true,
@@ -253,11 +267,30 @@ func ResourceNotebook() common.Resource {
 			Type:     schema.TypeString,
 			Computed: true,
 		},
-	})
+	}))
 	s["content_base64"].RequiredWith = []string{"language"}
+	recordRemoteNotebook := func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+		format := d.Get("format").(string)
+		return recordRemoteContent(ctx, d, c, workspace.ExportFormat(format), notebookNormalizer(format))
+	}
 	return common.Resource{
 		Schema:        s,
 		SchemaVersion: 1,
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff) error {
+			if !d.Get("detect_remote_changes").(bool) {
+				return nil
+			}
+			format := d.Get("format").(string)
+			if d.Get("language").(string) == "" {
+				// the format is detected from the extension of the source on create
+				format = extMap[strings.ToLower(filepath.Ext(d.Get("source").(string)))].Format
+			}
+			if format == "DBC" {
+				// archives are different on every export, so edits can't be detected
+				return errors.New("detect_remote_changes isn't supported for notebooks in DBC format")
+			}
+			return nil
+		},
 		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 			content, err := ReadContent(d)
 			if err != nil {
@@ -298,7 +331,7 @@ func ResourceNotebook() common.Resource {
 				}
 			}
 			d.SetId(path)
-			return nil
+			return recordRemoteNotebook(ctx, d, c)
 		},
 		Read: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 			w, err := c.WorkspaceClient()
@@ -313,6 +346,11 @@ func ResourceNotebook() common.Resource {
 			}
 			d.Set("url", c.FormatURL("#workspace", d.Id()))
 			d.Set("workspace_path", "/Workspace"+objectStatus.Path)
+			format := d.Get("format").(string)
+			err = checkRemoteContent(ctx, d, c, workspace.ExportFormat(format), notebookNormalizer(format))
+			if err != nil {
+				return err
+			}
 			return common.StructToData(objectStatus, s, d)
 		},
 		Update: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
@@ -328,19 +366,24 @@ func ResourceNotebook() common.Resource {
 				if err != nil {
 					return err
 				}
-				return notebooksAPI.Create(ImportPath{
+				err = notebooksAPI.Create(ImportPath{
 					Content: base64.StdEncoding.EncodeToString(content),
 					Format:  format,
 					Path:    d.Id(),
 				})
+			} else {
+				err = notebooksAPI.Create(ImportPath{
+					Content:   base64.StdEncoding.EncodeToString(content),
+					Language:  d.Get("language").(string),
+					Format:    format,
+					Overwrite: true,
+					Path:      d.Id(),
+				})
 			}
-			return notebooksAPI.Create(ImportPath{
-				Content:   base64.StdEncoding.EncodeToString(content),
-				Language:  d.Get("language").(string),
-				Format:    format,
-				Overwrite: true,
-				Path:      d.Id(),
-			})
+			if err != nil {
+				return err
+			}
+			return recordRemoteNotebook(ctx, d, c)
 		},
 		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 			objType := d.Get("object_type")
@@ -489,3 +489,117 @@ func TestNotebookLanguageSuppressSourceDiff(t *testing.T) {
 	suppress := r.Schema["language"].DiffSuppressFunc
 	assert.True(t, suppress("language", Python, Python, d))
 }
+
+func TestResourceNotebookCreate_DetectRemoteChanges(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodPost,
+				Resource: "/api/2.0/workspace/import",
+				ExpectedRequest: ImportPath{
+					Content:   "cHJpbnQoMSk=",
+					Path:      "/foo",
+					Language:  "PYTHON",
+					Overwrite: true,
+					Format:    "SOURCE",
+				},
+			},
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/workspace/export?format=SOURCE&path=%2Ffoo",
+				Response: ExportPath{
+					// # Databricks notebook source\nprint(1)\n
+					Content: "IyBEYXRhYnJpY2tzIG5vdGVib29rIHNvdXJjZQpwcmludCgxKQo=",
+				},
+			},
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/workspace/get-status?path=%2Ffoo",
+				Response: ObjectStatus{
+					ObjectID:   4567,
+					ObjectType: Notebook,
+					Path:       "/foo",
+					Language:   "PYTHON",
+				},
+			},
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/workspace/export?format=SOURCE&path=%2Ffoo",
+				Response: ExportPath{
+					Content: "IyBEYXRhYnJpY2tzIG5vdGVib29rIHNvdXJjZQpwcmludCgxKQo=",
+				},
+			},
+		},
+		Resource: ResourceNotebook(),
+		Create:   true,
+		HCL: `
+		content_base64        = "cHJpbnQoMSk="
+		language              = "PYTHON"
+		path                  = "/foo"
+		detect_remote_changes = true
+		`,
+	}.ApplyAndExpectData(t, map[string]any{
+		// md5 of the normalized `print(1)`
+		"remote_md5":              "186bdbe41e79ea696410ba0a9e8d2762",
+		"remote_content_modified": false,
+	})
+}
+
+func TestResourceNotebookRead_RemoteChanges(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/workspace/get-status?path=%2Ffoo",
+				Response: ObjectStatus{
+					ObjectID:   4567,
+					ObjectType: Notebook,
+					Path:       "/foo",
+					Language:   "PYTHON",
+				},
+			},
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/workspace/export?format=SOURCE&path=%2Ffoo",
+				Response: ExportPath{
+					// # Databricks notebook source\nprint(2)\n
+					Content: "IyBEYXRhYnJpY2tzIG5vdGVib29rIHNvdXJjZQpwcmludCgyKQo=",
+				},
+			},
+		},
+		Resource: ResourceNotebook(),
+		Read:     true,
+		ID:       "/foo",
+		InstanceState: map[string]string{
+			"path":                  "/foo",
+			"language":              "PYTHON",
+			"format":                "SOURCE",
+			"content_base64":        "cHJpbnQoMSk=",
+			"detect_remote_changes": "true",
+			"remote_md5":            "186bdbe41e79ea696410ba0a9e8d2762",
+		},
+		HCL: `
+		content_base64        = "cHJpbnQoMSk="
+		language              = "PYTHON"
+		path                  = "/foo"
+		detect_remote_changes = true
+		`,
+	}.ApplyAndExpectData(t, map[string]any{
+		"remote_md5":              "186bdbe41e79ea696410ba0a9e8d2762",
+		"remote_content_modified": true,
+	})
+}
+
+func TestResourceNotebookCreate_DetectRemoteChangesOfDbc(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceNotebook(),
+		Create:   true,
+		HCL: `
+		content_base64        = "cHJpbnQoMSk="
+		language              = "PYTHON"
+		format                = "DBC"
+		path                  = "/foo"
+		detect_remote_changes = true
+		`,
+	}.ExpectError(t, "detect_remote_changes isn't supported for notebooks in DBC format")
+}
@@ -14,7 +14,7 @@ import (
 
 // ResourceWorkspaceFile manages files in workspace
 func ResourceWorkspaceFile() common.Resource {
-	s := FileContentSchema(map[string]*schema.Schema{
+	s := remoteContentSchema(FileContentSchema(map[string]*schema.Schema{
 		"url": {
 			Type:     schema.TypeString,
 			Computed: true,
@@ -28,7 +28,7 @@ func ResourceWorkspaceFile() common.Resource {
 			Type:     schema.TypeString,
 			Computed: true,
 		},
-	})
+	}))
 	return common.Resource{
 		Schema:        s,
 		SchemaVersion: 1,
@@ -65,7 +65,7 @@ func ResourceWorkspaceFile() common.Resource {
 				}
 			}
 			d.SetId(path)
-			return nil
+			return recordRemoteContent(ctx, d, c, workspace.ExportFormatAuto, normalizeLineEndings)
 		},
 		Read: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 			client, err := c.WorkspaceClient()
@@ -80,6 +80,10 @@ func ResourceWorkspaceFile() common.Resource {
 			}
 			d.Set("url", c.FormatURL("#workspace", d.Id()))
 			d.Set("workspace_path", "/Workspace"+objectStatus.Path)
+			err = checkRemoteContent(ctx, d, c, workspace.ExportFormatAuto, normalizeLineEndings)
+			if err != nil {
+				return err
+			}
 			return common.StructToData(objectStatus, s, d)
 		},
 		Update: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
@@ -91,13 +95,17 @@ func ResourceWorkspaceFile() common.Resource {
 			if err != nil {
 				return err
 			}
-			return client.Workspace.Import(ctx, workspace.Import{
+			err = client.Workspace.Import(ctx, workspace.Import{
 				Content:         base64.StdEncoding.EncodeToString(content),
 				Format:          workspace.ImportFormatAuto,
 				Overwrite:       true,
 				Path:            d.Id(),
 				ForceSendFields: []string{"Content"},
 			})
+			if err != nil {
+				return err
+			}
+			return recordRemoteContent(ctx, d, c, workspace.ExportFormatAuto, normalizeLineEndings)
 		},
 		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 			client, err := c.WorkspaceClient()
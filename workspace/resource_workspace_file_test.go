@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/databricks/databricks-sdk-go/apierr"
 	ws_api "github.com/databricks/databricks-sdk-go/service/workspace"
 	"github.com/databricks/terraform-provider-databricks/common"
 	"github.com/databricks/terraform-provider-databricks/qa"
@@ -410,3 +411,137 @@ func TestResourceWorkspaceFileUpdate(t *testing.T) {
 		Update:      true,
 	}.ApplyNoError(t)
 }
+
+func TestResourceWorkspaceFileRead_RemoteLineEndings(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/workspace/get-status?" + dummyWorkspaceFilePathUrl,
+				Response: ObjectStatus{
+					ObjectID:   12345,
+					ObjectType: File,
+					Path:       dummyWorkspaceFilePath,
+				},
+			},
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/workspace/export?format=AUTO&" + dummyWorkspaceFilePathUrl,
+				Response: ws_api.ExportResponse{
+					// a\r\n
+					Content: "YQ0K",
+				},
+			},
+		},
+		Resource: ResourceWorkspaceFile(),
+		Read:     true,
+		ID:       dummyWorkspaceFilePath,
+		InstanceState: map[string]string{
+			"path":                  dummyWorkspaceFilePath,
+			"content_base64":        "YQo=",
+			"detect_remote_changes": "true",
+			"remote_md5":            "60b725f10c9c85c70d97880dfe8191b3",
+		},
+		HCL: `
+		content_base64        = "YQo="
+		path                  = "/foo/path.py"
+		detect_remote_changes = true
+		`,
+	}.ApplyAndExpectData(t, map[string]any{
+		"remote_content_modified": false,
+	})
+}
+
+func TestResourceWorkspaceFileCreate_DetectRemoteChangesExportError(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/workspace/mkdirs",
+				ExpectedRequest: map[string]string{
+					"path": "/foo",
+				},
+			},
+			{
+				Method:   http.MethodPost,
+				Resource: "/api/2.0/workspace/import",
+				ExpectedRequest: ws_api.Import{
+					Content:   dummyWorkspaceFilePayload,
+					Path:      dummyWorkspaceFilePath,
+					Overwrite: true,
+					Format:    "AUTO",
+				},
+			},
+			{
+				// the import succeeded, so the failed export doesn't fail the create
+				Method:       http.MethodGet,
+				Resource:     "/api/2.0/workspace/export?format=AUTO&" + dummyWorkspaceFilePathUrl,
+				ReuseRequest: true,
+				Status:       500,
+				Response: apierr.APIError{
+					ErrorCode: "INTERNAL_ERROR",
+					Message:   "nope",
+				},
+			},
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/workspace/get-status?" + dummyWorkspaceFilePathUrl,
+				Response: ObjectStatus{
+					ObjectID:   4567,
+					ObjectType: File,
+					Path:       dummyWorkspaceFilePath,
+				},
+			},
+		},
+		Resource: ResourceWorkspaceFile(),
+		State: map[string]any{
+			"content_base64":        dummyWorkspaceFilePayload,
+			"path":                  dummyWorkspaceFilePath,
+			"detect_remote_changes": true,
+		},
+		Create: true,
+	}.ApplyAndExpectData(t, map[string]any{
+		"id":         dummyWorkspaceFilePath,
+		"remote_md5": "",
+	})
+}
+
+func TestResourceWorkspaceFileRead_RecordsMissingRemoteHash(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/workspace/get-status?" + dummyWorkspaceFilePathUrl,
+				Response: ObjectStatus{
+					ObjectID:   12345,
+					ObjectType: File,
+					Path:       dummyWorkspaceFilePath,
+				},
+			},
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/workspace/export?format=AUTO&" + dummyWorkspaceFilePathUrl,
+				Response: ws_api.ExportResponse{
+					// a\n
+					Content: "YQo=",
+				},
+			},
+		},
+		Resource: ResourceWorkspaceFile(),
+		Read:     true,
+		ID:       dummyWorkspaceFilePath,
+		InstanceState: map[string]string{
+			"path":                  dummyWorkspaceFilePath,
+			"content_base64":        "YQo=",
+			"detect_remote_changes": "true",
+		},
+		HCL: `
+		content_base64        = "YQo="
+		path                  = "/foo/path.py"
+		detect_remote_changes = true
+		`,
+	}.ApplyAndExpectData(t, map[string]any{
+		"remote_md5":              "60b725f10c9c85c70d97880dfe8191b3",
+		"remote_content_modified": false,
+	})
+}
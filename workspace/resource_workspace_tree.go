@@ -0,0 +1,533 @@
+package workspace
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/databricks/databricks-sdk-go/apierr"
+	"github.com/databricks/databricks-sdk-go/service/workspace"
+	"github.com/databricks/terraform-provider-databricks/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// treeEntry is a local file, that is uploaded as a notebook or a workspace file
+type treeEntry struct {
+	// slash-separated path relative to the source directory
+	relative string
+	remote   string
+	language workspace.Language
+	format   workspace.ImportFormat
+	md5      string
+}
+
+// treeRemotePath returns the workspace path of the local file. Notebooks are imported without extension,
+// and their language is detected by it, as in databricks_notebook. Other files are imported as is.
+func treeRemotePath(target, relative string) (string, notebookLanguageFormat, bool) {
+	ext := strings.ToLower(path.Ext(relative))
+	notebook, ok := extMap[ext]
+	// DBC archives can't be overwritten, so they're imported as regular files
+	if !ok || !notebook.Overwrite {
+		return path.Join(target, relative), notebookLanguageFormat{}, false
+	}
+	return path.Join(target, strings.TrimSuffix(relative, path.Ext(relative))), notebook, true
+}
+
+func newTreeEntry(target, relative string, content []byte) treeEntry {
+	remote, notebook, ok := treeRemotePath(target, relative)
+	entry := treeEntry{
+		relative: relative,
+		remote:   remote,
+		format:   workspace.ImportFormatAuto,
+		md5:      fmt.Sprintf("%x", md5.Sum(content)),
+	}
+	if ok {
+		entry.language = workspace.Language(notebook.Language)
+		entry.format = workspace.ImportFormat(notebook.Format)
+	}
+	return entry
+}
+
+// matchGlob matches the slash-separated path against the pattern, where `**` matches any number of directories
+func matchGlob(pattern, name string) (bool, error) {
+	return matchGlobSegments(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+func matchGlobSegments(pattern, name []string) (bool, error) {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(name); i++ {
+				ok, err := matchGlobSegments(pattern[1:], name[i:])
+				if ok || err != nil {
+					return ok, err
+				}
+			}
+			return false, nil
+		}
+		if len(name) == 0 {
+			return false, nil
+		}
+		ok, err := path.Match(pattern[0], name[0])
+		if !ok || err != nil {
+			return false, err
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0, nil
+}
+
+func matchAnyGlob(patterns []string, name string) (bool, error) {
+	for _, pattern := range patterns {
+		ok, err := matchGlob(pattern, name)
+		if err != nil {
+			return false, fmt.Errorf("invalid pattern %s: %w", pattern, err)
+		}
+		if ok {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// WorkspaceTree is the configuration of databricks_workspace_tree
+type WorkspaceTree struct {
+	Source  string
+	Path    string
+	Include []string
+	Exclude []string
+}
+
+func (t WorkspaceTree) selected(relative string) (bool, error) {
+	if len(t.Include) > 0 {
+		ok, err := matchAnyGlob(t.Include, relative)
+		if !ok || err != nil {
+			return false, err
+		}
+	}
+	excluded, err := matchAnyGlob(t.Exclude, relative)
+	return !excluded, err
+}
+
+// entries walks the source directory and returns the selected files by their relative paths
+func (t WorkspaceTree) entries() (map[string]treeEntry, error) {
+	entries := map[string]treeEntry{}
+	remotes := map[string]string{}
+	err := filepath.WalkDir(t.Source, func(name string, de fs.DirEntry, err error) error {
+		if err != nil || de.IsDir() {
+			return err
+		}
+		relative, err := filepath.Rel(t.Source, name)
+		if err != nil {
+			return err
+		}
+		relative = filepath.ToSlash(relative)
+		ok, err := t.selected(relative)
+		if !ok || err != nil {
+			return err
+		}
+		content, err := os.ReadFile(name)
+		if err != nil {
+			return err
+		}
+		entry := newTreeEntry(t.Path, relative, content)
+		if other, ok := remotes[entry.remote]; ok {
+			return fmt.Errorf("%s and %s are both imported as %s", other, relative, entry.remote)
+		}
+		remotes[entry.remote] = relative
+		entries[relative] = entry
+		return nil
+	})
+	return entries, err
+}
+
+func treeManifest(entries map[string]treeEntry) map[string]string {
+	manifest := map[string]string{}
+	for relative, entry := range entries {
+		manifest[relative] = entry.md5
+	}
+	return manifest
+}
+
+// parentDirectories returns the deepest directories of the entries, as mkdirs creates the parents as well
+func parentDirectories(entries []treeEntry) []string {
+	directories := map[string]bool{}
+	for _, entry := range entries {
+		directories[path.Dir(entry.remote)] = true
+	}
+	sorted := []string{}
+	for directory := range directories {
+		sorted = append(sorted, directory)
+	}
+	sort.Strings(sorted)
+	leaves := []string{}
+	for i, directory := range sorted {
+		if i+1 < len(sorted) && strings.HasPrefix(sorted[i+1], directory+"/") {
+			continue
+		}
+		leaves = append(leaves, directory)
+	}
+	return leaves
+}
+
+// emptiedDirectories returns directories below the target, that had deleted objects and have no entries left,
+// the deepest first, so that they could be deleted one by one
+func emptiedDirectories(target string, deleted []string, entries map[string]treeEntry) []string {
+	needed := map[string]bool{}
+	for _, entry := range entries {
+		for directory := path.Dir(entry.remote); strings.HasPrefix(directory, target+"/"); directory = path.Dir(directory) {
+			needed[directory] = true
+		}
+	}
+	emptied := map[string]bool{}
+	for _, remote := range deleted {
+		for directory := path.Dir(remote); strings.HasPrefix(directory, target+"/"); directory = path.Dir(directory) {
+			if needed[directory] {
+				break
+			}
+			emptied[directory] = true
+		}
+	}
+	sorted := []string{}
+	for directory := range emptied {
+		sorted = append(sorted, directory)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		di, dj := strings.Count(sorted[i], "/"), strings.Count(sorted[j], "/")
+		if di != dj {
+			return di > dj
+		}
+		return sorted[i] < sorted[j]
+	})
+	return sorted
+}
+
+// treeSync uploads and deletes workspace objects with the limited number of concurrent requests
+type treeSync struct {
+	ctx         context.Context
+	w           workspaceAPI
+	source      string
+	parallelism int
+}
+
+type workspaceAPI interface {
+	Import(ctx context.Context, request workspace.Import) error
+	Delete(ctx context.Context, request workspace.Delete) error
+	MkdirsByPath(ctx context.Context, path string) error
+}
+
+func (s treeSync) parallel(count int, task func(i int) error) error {
+	errs := make([]error, count)
+	semaphore := make(chan struct{}, max(s.parallelism, 1))
+	var wg sync.WaitGroup
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			errs[i] = task(i)
+		}(i)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s treeSync) upload(entry treeEntry) error {
+	content, err := os.ReadFile(filepath.Join(s.source, filepath.FromSlash(entry.relative)))
+	if err != nil {
+		return err
+	}
+	log.Printf("[INFO] Uploading %s to %s", entry.relative, entry.remote)
+	err = s.w.Import(s.ctx, workspace.Import{
+		Content:         base64.StdEncoding.EncodeToString(content),
+		Format:          entry.format,
+		Language:        entry.language,
+		Path:            entry.remote,
+		Overwrite:       true,
+		ForceSendFields: []string{"Content"},
+	})
+	if err != nil {
+		return fmt.Errorf("cannot upload %s: %w", entry.relative, err)
+	}
+	return nil
+}
+
+func (s treeSync) delete(remote string) error {
+	log.Printf("[INFO] Deleting %s", remote)
+	err := s.w.Delete(s.ctx, workspace.Delete{Path: remote})
+	if apierr.IsMissing(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("cannot delete %s: %w", remote, err)
+	}
+	return nil
+}
+
+// deleteDirectory deletes the directory, only if it's empty, so that objects created by others are kept
+func (s treeSync) deleteDirectory(remote string) error {
+	log.Printf("[INFO] Deleting directory %s", remote)
+	err := s.w.Delete(s.ctx, workspace.Delete{Path: remote})
+	var apiErr *apierr.APIError
+	if errors.As(err, &apiErr) && apiErr.ErrorCode == "DIRECTORY_NOT_EMPTY" {
+		log.Printf("[INFO] Keeping %s, as it has objects, that aren't synchronized", remote)
+		return nil
+	}
+	if apierr.IsMissing(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("cannot delete %s: %w", remote, err)
+	}
+	return nil
+}
+
+// apply makes the target directory match the entries. Only files, that changed since the previous manifest,
+// are uploaded, and objects of files, that are no longer in the source, are deleted together with directories
+// left empty. Deletes go first, so that a notebook could be replaced by a file with the same name.
+func (s treeSync) apply(target string, entries map[string]treeEntry, previous map[string]string) error {
+	deletes := []string{}
+	for relative := range previous {
+		if _, ok := entries[relative]; ok {
+			continue
+		}
+		remote, _, _ := treeRemotePath(target, relative)
+		deletes = append(deletes, remote)
+	}
+	sort.Strings(deletes)
+	err := s.parallel(len(deletes), func(i int) error {
+		return s.delete(deletes[i])
+	})
+	if err != nil {
+		return err
+	}
+	for _, directory := range emptiedDirectories(target, deletes, entries) {
+		err = s.deleteDirectory(directory)
+		if err != nil {
+			return err
+		}
+	}
+	uploads := []treeEntry{}
+	for relative, entry := range entries {
+		if previous[relative] == entry.md5 {
+			continue
+		}
+		uploads = append(uploads, entry)
+	}
+	sort.Slice(uploads, func(i, j int) bool {
+		return uploads[i].relative < uploads[j].relative
+	})
+	for _, directory := range parentDirectories(uploads) {
+		err = s.w.MkdirsByPath(s.ctx, directory)
+		if err != nil {
+			return err
+		}
+	}
+	return s.parallel(len(uploads), func(i int) error {
+		return s.upload(uploads[i])
+	})
+}
+
+func manifestFromState(v any) map[string]string {
+	manifest := map[string]string{}
+	m, _ := v.(map[string]any)
+	for relative, md5 := range m {
+		manifest[relative] = md5.(string)
+	}
+	return manifest
+}
+
+func workspaceTreeFrom(d attributeGetter) WorkspaceTree {
+	t := WorkspaceTree{
+		Source: d.Get("source").(string),
+		Path:   d.Get("path").(string),
+	}
+	for _, v := range d.Get("include").([]any) {
+		t.Include = append(t.Include, v.(string))
+	}
+	for _, v := range d.Get("exclude").([]any) {
+		t.Exclude = append(t.Exclude, v.(string))
+	}
+	return t
+}
+
+type attributeGetter interface {
+	Get(key string) any
+}
+
+// ResourceWorkspaceTree synchronizes a local directory with a workspace directory
+func ResourceWorkspaceTree() common.Resource {
+	s := map[string]*schema.Schema{
+		"source": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"path": {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+			ValidateFunc: validation.StringMatch(
+				regexp.MustCompile(`^/`), "path must be absolute"),
+		},
+		"include": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+		"exclude": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+		"parallelism": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      10,
+			ValidateFunc: validation.IntAtLeast(1),
+		},
+		"files": {
+			Type:     schema.TypeMap,
+			Computed: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+		"url": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"object_id": {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+		"workspace_path": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+	}
+	syncTree := func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+		w, err := c.WorkspaceClient()
+		if err != nil {
+			return err
+		}
+		tree := workspaceTreeFrom(d)
+		entries, err := tree.entries()
+		if err != nil {
+			return err
+		}
+		if d.Id() == "" && len(entries) == 0 {
+			// otherwise the target directory is created with the parents of uploaded files
+			err = w.Workspace.MkdirsByPath(ctx, tree.Path)
+			if err != nil {
+				return err
+			}
+		}
+		previous, _ := d.GetChange("files")
+		err = treeSync{
+			ctx:         ctx,
+			w:           w.Workspace,
+			source:      tree.Source,
+			parallelism: d.Get("parallelism").(int),
+		}.apply(tree.Path, entries, manifestFromState(previous))
+		if err != nil {
+			// keep the previous manifest, so that the next apply retries the failed uploads
+			d.Set("files", previous)
+			return err
+		}
+		d.SetId(tree.Path)
+		return d.Set("files", treeManifest(entries))
+	}
+	return common.Resource{
+		Schema: s,
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff) error {
+			for _, key := range []string{"source", "path", "include", "exclude"} {
+				if !d.NewValueKnown(key) {
+					return d.SetNewComputed("files")
+				}
+			}
+			entries, err := workspaceTreeFrom(d).entries()
+			if err != nil {
+				return err
+			}
+			manifest := treeManifest(entries)
+			if maps.Equal(manifestFromState(d.Get("files")), manifest) {
+				return nil
+			}
+			log.Printf("[INFO] Files in %s changed", d.Get("source"))
+			return d.SetNew("files", manifest)
+		},
+		Create: syncTree,
+		Read: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			w, err := c.WorkspaceClient()
+			if err != nil {
+				return err
+			}
+			objectStatus, err := common.RetryOnTimeout(ctx, func(ctx context.Context) (*workspace.ObjectInfo, error) {
+				return w.Workspace.GetStatusByPath(ctx, d.Id())
+			})
+			if err != nil {
+				return err
+			}
+			objects, err := w.Workspace.RecursiveList(ctx, d.Id())
+			if err != nil {
+				return err
+			}
+			remotes := map[string]bool{}
+			for _, object := range objects {
+				remotes[object.Path] = true
+			}
+			// files, that were deleted outside of Terraform, are dropped from the manifest to upload them again
+			manifest := manifestFromState(d.Get("files"))
+			for relative := range manifest {
+				remote, _, _ := treeRemotePath(d.Id(), relative)
+				if !remotes[remote] {
+					log.Printf("[INFO] %s was deleted outside of Terraform", remote)
+					delete(manifest, relative)
+				}
+			}
+			d.Set("files", manifest)
+			d.Set("path", objectStatus.Path)
+			d.Set("object_id", objectStatus.ObjectId)
+			d.Set("url", c.FormatURL("#workspace", d.Id()))
+			return d.Set("workspace_path", "/Workspace"+objectStatus.Path)
+		},
+		Update: syncTree,
+		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			w, err := c.WorkspaceClient()
+			if err != nil {
+				return err
+			}
+			// only objects of the manifest and directories left empty are deleted, as the directory may contain
+			// objects created by others
+			tree := treeSync{
+				ctx:         ctx,
+				w:           w.Workspace,
+				parallelism: d.Get("parallelism").(int),
+			}
+			// the manifest of the state, as the planned one reflects the source and not what was synchronized
+			files, _ := d.GetChange("files")
+			err = tree.apply(d.Id(), map[string]treeEntry{}, manifestFromState(files))
+			if err != nil {
+				return err
+			}
+			return tree.deleteDirectory(d.Id())
+		},
+	}
+}
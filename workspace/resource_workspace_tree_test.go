@@ -0,0 +1,515 @@
+package workspace
+
+import (
+	"crypto/md5"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	ws_api "github.com/databricks/databricks-sdk-go/service/workspace"
+	"github.com/databricks/terraform-provider-databricks/qa"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTree(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for name, content := range files {
+		name = filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(name), 0755))
+		require.NoError(t, os.WriteFile(name, []byte(content), 0644))
+	}
+	return dir
+}
+
+func md5Hex(content string) string {
+	return fmt.Sprintf("%x", md5.Sum([]byte(content)))
+}
+
+func TestMatchGlob(t *testing.T) {
+	for _, tc := range []struct {
+		pattern string
+		name    string
+		match   bool
+	}{
+		{"*.py", "a.py", true},
+		{"*.py", "lib/a.py", false},
+		{"**/*.py", "a.py", true},
+		{"**/*.py", "lib/sub/a.py", true},
+		{"lib/**", "lib/sub/a.py", true},
+		{"lib/**", "a.py", false},
+		{"lib/**/test_*.py", "lib/test_a.py", true},
+		{"lib/**/test_*.py", "lib/sub/a.py", false},
+	} {
+		ok, err := matchGlob(tc.pattern, tc.name)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.match, ok, "%s ~ %s", tc.pattern, tc.name)
+	}
+	_, err := matchGlob("[", "a")
+	assert.Error(t, err)
+}
+
+func TestWorkspaceTreeEntries(t *testing.T) {
+	dir := writeTree(t, map[string]string{
+		"etl.py":           "print(1)",
+		"report.ipynb":     "{}",
+		"lib/query.SQL":    "SELECT 1",
+		"lib/config.json":  "{}",
+		"tests/test_a.py":  "assert True",
+		"archive/old.dbc":  "dbc",
+		"README.md":        "# readme",
+		"lib/sub/model.r":  "x <- 1",
+		"lib/sub/notes.md": "notes",
+	})
+	entries, err := WorkspaceTree{
+		Source:  dir,
+		Path:    "/Shared/app",
+		Exclude: []string{"tests/**", "**/*.md"},
+	}.entries()
+	require.NoError(t, err)
+	remotes := map[string]string{}
+	for relative, entry := range entries {
+		remotes[relative] = fmt.Sprintf("%s %s %s", entry.remote, entry.format, entry.language)
+	}
+	assert.Equal(t, map[string]string{
+		"etl.py":          "/Shared/app/etl SOURCE PYTHON",
+		"report.ipynb":    "/Shared/app/report JUPYTER ",
+		"lib/query.SQL":   "/Shared/app/lib/query SOURCE SQL",
+		"lib/config.json": "/Shared/app/lib/config.json AUTO ",
+		"archive/old.dbc": "/Shared/app/archive/old.dbc AUTO ",
+		"lib/sub/model.r": "/Shared/app/lib/sub/model SOURCE R",
+	}, remotes)
+	assert.Equal(t, md5Hex("print(1)"), entries["etl.py"].md5)
+}
+
+func TestWorkspaceTreeEntries_Include(t *testing.T) {
+	dir := writeTree(t, map[string]string{
+		"etl.py":          "print(1)",
+		"lib/config.json": "{}",
+	})
+	entries, err := WorkspaceTree{
+		Source:  dir,
+		Path:    "/Shared/app",
+		Include: []string{"**/*.py"},
+	}.entries()
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+	assert.Contains(t, entries, "etl.py")
+}
+
+func TestWorkspaceTreeEntries_Conflict(t *testing.T) {
+	dir := writeTree(t, map[string]string{
+		"etl.py":  "print(1)",
+		"etl.sql": "SELECT 1",
+	})
+	_, err := WorkspaceTree{Source: dir, Path: "/Shared/app"}.entries()
+	assert.EqualError(t, err, "etl.py and etl.sql are both imported as /Shared/app/etl")
+}
+
+func TestParentDirectories(t *testing.T) {
+	assert.Equal(t, []string{"/a/b/c", "/a/d"}, parentDirectories([]treeEntry{
+		{remote: "/a/x"},
+		{remote: "/a/b/y"},
+		{remote: "/a/b/c/z"},
+		{remote: "/a/d/w"},
+	}))
+}
+
+func treeReadFixtures(objects ...ws_api.ObjectInfo) []qa.HTTPFixture {
+	return []qa.HTTPFixture{
+		{
+			Method:   http.MethodGet,
+			Resource: "/api/2.0/workspace/get-status?path=%2Fapp",
+			Response: ws_api.ObjectInfo{
+				ObjectId:   123,
+				ObjectType: ws_api.ObjectTypeDirectory,
+				Path:       "/app",
+			},
+		},
+		{
+			Method:   http.MethodGet,
+			Resource: "/api/2.0/workspace/list?path=%2Fapp",
+			Response: ws_api.ListResponse{
+				Objects: objects,
+			},
+		},
+	}
+}
+
+func TestResourceWorkspaceTreeCreate(t *testing.T) {
+	dir := writeTree(t, map[string]string{
+		"etl.py":           "print(1)",
+		"conf/config.json": "{}",
+	})
+	qa.ResourceFixture{
+		Fixtures: append([]qa.HTTPFixture{
+			{
+				Method:          http.MethodPost,
+				Resource:        "/api/2.0/workspace/mkdirs",
+				ExpectedRequest: ws_api.Mkdirs{Path: "/app/conf"},
+			},
+			{
+				Method:   http.MethodPost,
+				Resource: "/api/2.0/workspace/import",
+				ExpectedRequest: ws_api.Import{
+					Content:   "e30=",
+					Format:    "AUTO",
+					Path:      "/app/conf/config.json",
+					Overwrite: true,
+				},
+			},
+			{
+				Method:   http.MethodPost,
+				Resource: "/api/2.0/workspace/import",
+				ExpectedRequest: ws_api.Import{
+					Content:   "cHJpbnQoMSk=",
+					Format:    "SOURCE",
+					Language:  "PYTHON",
+					Path:      "/app/etl",
+					Overwrite: true,
+				},
+			},
+		}, append(treeReadFixtures(
+			ws_api.ObjectInfo{Path: "/app/etl", ObjectType: ws_api.ObjectTypeNotebook},
+			ws_api.ObjectInfo{Path: "/app/conf", ObjectType: ws_api.ObjectTypeDirectory},
+		), qa.HTTPFixture{
+			Method:   http.MethodGet,
+			Resource: "/api/2.0/workspace/list?path=%2Fapp%2Fconf",
+			Response: ws_api.ListResponse{
+				Objects: []ws_api.ObjectInfo{
+					{Path: "/app/conf/config.json", ObjectType: ws_api.ObjectTypeFile},
+				},
+			},
+		})...),
+		Resource: ResourceWorkspaceTree(),
+		Create:   true,
+		HCL: fmt.Sprintf(`
+		source = "%s"
+		path = "/app"
+		parallelism = 1
+		`, filepath.ToSlash(dir)),
+	}.ApplyAndExpectData(t, map[string]any{
+		"id":             "/app",
+		"object_id":      123,
+		"workspace_path": "/Workspace/app",
+		"files": map[string]any{
+			"etl.py":           md5Hex("print(1)"),
+			"conf/config.json": md5Hex("{}"),
+		},
+	})
+}
+
+func TestResourceWorkspaceTreeCreate_Empty(t *testing.T) {
+	dir := writeTree(t, map[string]string{})
+	qa.ResourceFixture{
+		Fixtures: append([]qa.HTTPFixture{
+			{
+				Method:          http.MethodPost,
+				Resource:        "/api/2.0/workspace/mkdirs",
+				ExpectedRequest: ws_api.Mkdirs{Path: "/app"},
+			},
+		}, treeReadFixtures()...),
+		Resource: ResourceWorkspaceTree(),
+		Create:   true,
+		HCL: fmt.Sprintf(`
+		source = "%s"
+		path = "/app"
+		`, filepath.ToSlash(dir)),
+	}.ApplyAndExpectData(t, map[string]any{
+		"id":    "/app",
+		"files": map[string]any{},
+	})
+}
+
+func TestResourceWorkspaceTreeCreate_Error(t *testing.T) {
+	dir := writeTree(t, map[string]string{
+		"etl.py": "print(1)",
+	})
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodPost,
+				Resource: "/api/2.0/workspace/mkdirs",
+			},
+			{
+				Method:   http.MethodPost,
+				Resource: "/api/2.0/workspace/import",
+				Status:   400,
+				Response: map[string]string{
+					"error_code": "INVALID_PARAMETER_VALUE",
+					"message":    "Invalid notebook",
+				},
+			},
+		},
+		Resource: ResourceWorkspaceTree(),
+		Create:   true,
+		HCL: fmt.Sprintf(`
+		source = "%s"
+		path = "/app"
+		`, filepath.ToSlash(dir)),
+	}.ExpectError(t, "cannot upload etl.py: Invalid notebook")
+}
+
+func TestResourceWorkspaceTreeUpdate(t *testing.T) {
+	dir := writeTree(t, map[string]string{
+		"etl.py":       "print(2)",
+		"unchanged.py": "print(0)",
+		"lib/new.txt":  "new",
+	})
+	qa.ResourceFixture{
+		Fixtures: append([]qa.HTTPFixture{
+			{
+				Method:          http.MethodPost,
+				Resource:        "/api/2.0/workspace/delete",
+				ExpectedRequest: ws_api.Delete{Path: "/app/removed"},
+			},
+			{
+				Method:          http.MethodPost,
+				Resource:        "/api/2.0/workspace/mkdirs",
+				ExpectedRequest: ws_api.Mkdirs{Path: "/app/lib"},
+			},
+			{
+				Method:   http.MethodPost,
+				Resource: "/api/2.0/workspace/import",
+				ExpectedRequest: ws_api.Import{
+					Content:   "cHJpbnQoMik=",
+					Format:    "SOURCE",
+					Language:  "PYTHON",
+					Path:      "/app/etl",
+					Overwrite: true,
+				},
+			},
+			{
+				Method:   http.MethodPost,
+				Resource: "/api/2.0/workspace/import",
+				ExpectedRequest: ws_api.Import{
+					Content:   "bmV3",
+					Format:    "AUTO",
+					Path:      "/app/lib/new.txt",
+					Overwrite: true,
+				},
+			},
+		}, append(treeReadFixtures(
+			ws_api.ObjectInfo{Path: "/app/etl", ObjectType: ws_api.ObjectTypeNotebook},
+			ws_api.ObjectInfo{Path: "/app/unchanged", ObjectType: ws_api.ObjectTypeNotebook},
+			ws_api.ObjectInfo{Path: "/app/lib", ObjectType: ws_api.ObjectTypeDirectory},
+		), qa.HTTPFixture{
+			Method:   http.MethodGet,
+			Resource: "/api/2.0/workspace/list?path=%2Fapp%2Flib",
+			Response: ws_api.ListResponse{
+				Objects: []ws_api.ObjectInfo{
+					{Path: "/app/lib/new.txt", ObjectType: ws_api.ObjectTypeFile},
+				},
+			},
+		})...),
+		Resource: ResourceWorkspaceTree(),
+		Update:   true,
+		ID:       "/app",
+		InstanceState: map[string]string{
+			"source":             filepath.ToSlash(dir),
+			"path":               "/app",
+			"parallelism":        "1",
+			"files.%":            "3",
+			"files.etl.py":       md5Hex("print(1)"),
+			"files.unchanged.py": md5Hex("print(0)"),
+			"files.removed.py":   md5Hex("print(3)"),
+		},
+		HCL: fmt.Sprintf(`
+		source = "%s"
+		path = "/app"
+		parallelism = 1
+		`, filepath.ToSlash(dir)),
+	}.ApplyAndExpectData(t, map[string]any{
+		"id": "/app",
+		"files": map[string]any{
+			"etl.py":       md5Hex("print(2)"),
+			"unchanged.py": md5Hex("print(0)"),
+			"lib/new.txt":  md5Hex("new"),
+		},
+	})
+}
+
+func TestResourceWorkspaceTreeUpdate_DeletesEmptiedDirectories(t *testing.T) {
+	dir := writeTree(t, map[string]string{
+		"lib/keep.txt": "keep",
+	})
+	qa.ResourceFixture{
+		Fixtures: append([]qa.HTTPFixture{
+			{
+				Method:          http.MethodPost,
+				Resource:        "/api/2.0/workspace/delete",
+				ExpectedRequest: ws_api.Delete{Path: "/app/lib/gone.txt"},
+			},
+			{
+				Method:          http.MethodPost,
+				Resource:        "/api/2.0/workspace/delete",
+				ExpectedRequest: ws_api.Delete{Path: "/app/old/etl"},
+			},
+			{
+				// lib is kept, as it still has synchronized files
+				Method:          http.MethodPost,
+				Resource:        "/api/2.0/workspace/delete",
+				ExpectedRequest: ws_api.Delete{Path: "/app/old"},
+			},
+		}, append(treeReadFixtures(
+			ws_api.ObjectInfo{Path: "/app/lib", ObjectType: ws_api.ObjectTypeDirectory},
+		), qa.HTTPFixture{
+			Method:   http.MethodGet,
+			Resource: "/api/2.0/workspace/list?path=%2Fapp%2Flib",
+			Response: ws_api.ListResponse{
+				Objects: []ws_api.ObjectInfo{
+					{Path: "/app/lib/keep.txt", ObjectType: ws_api.ObjectTypeFile},
+				},
+			},
+		})...),
+		Resource: ResourceWorkspaceTree(),
+		Update:   true,
+		ID:       "/app",
+		InstanceState: map[string]string{
+			"source":             filepath.ToSlash(dir),
+			"path":               "/app",
+			"parallelism":        "1",
+			"files.%":            "3",
+			"files.lib/keep.txt": md5Hex("keep"),
+			"files.lib/gone.txt": md5Hex("gone"),
+			"files.old/etl.py":   md5Hex("print(1)"),
+		},
+		HCL: fmt.Sprintf(`
+		source = "%s"
+		path = "/app"
+		parallelism = 1
+		`, filepath.ToSlash(dir)),
+	}.ApplyAndExpectData(t, map[string]any{
+		"id": "/app",
+		"files": map[string]any{
+			"lib/keep.txt": md5Hex("keep"),
+		},
+	})
+}
+
+func TestResourceWorkspaceTreeRead_DeletedRemotely(t *testing.T) {
+	dir := writeTree(t, map[string]string{
+		"etl.py":      "print(1)",
+		"config.json": "{}",
+	})
+	qa.ResourceFixture{
+		Fixtures: treeReadFixtures(
+			ws_api.ObjectInfo{Path: "/app/etl", ObjectType: ws_api.ObjectTypeNotebook},
+		),
+		Resource: ResourceWorkspaceTree(),
+		Read:     true,
+		New:      true,
+		ID:       "/app",
+		InstanceState: map[string]string{
+			"source":            filepath.ToSlash(dir),
+			"path":              "/app",
+			"files.%":           "2",
+			"files.etl.py":      md5Hex("print(1)"),
+			"files.config.json": md5Hex("{}"),
+		},
+		HCL: fmt.Sprintf(`
+		source = "%s"
+		path = "/app"
+		`, filepath.ToSlash(dir)),
+	}.ApplyAndExpectData(t, map[string]any{
+		"files": map[string]any{
+			"etl.py": md5Hex("print(1)"),
+		},
+	})
+}
+
+func TestResourceWorkspaceTreeRead_NotFound(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/workspace/get-status?path=%2Fapp",
+				Status:   404,
+				Response: map[string]string{
+					"error_code": "RESOURCE_DOES_NOT_EXIST",
+					"message":    "Path (/app) doesn't exist.",
+				},
+			},
+		},
+		Resource: ResourceWorkspaceTree(),
+		Read:     true,
+		Removed:  true,
+		ID:       "/app",
+		HCL: fmt.Sprintf(`
+		source = "%s"
+		path = "/app"
+		`, filepath.ToSlash(t.TempDir())),
+	}.ApplyNoError(t)
+}
+
+func TestResourceWorkspaceTreeDelete(t *testing.T) {
+	dir := filepath.ToSlash(t.TempDir())
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:          http.MethodPost,
+				Resource:        "/api/2.0/workspace/delete",
+				ExpectedRequest: ws_api.Delete{Path: "/app/conf/config.json"},
+			},
+			{
+				Method:          http.MethodPost,
+				Resource:        "/api/2.0/workspace/delete",
+				ExpectedRequest: ws_api.Delete{Path: "/app/etl"},
+				Status:          404,
+				Response: map[string]string{
+					"error_code": "RESOURCE_DOES_NOT_EXIST",
+					"message":    "Path (/app/etl) doesn't exist.",
+				},
+			},
+			{
+				// directories left empty are deleted
+				Method:          http.MethodPost,
+				Resource:        "/api/2.0/workspace/delete",
+				ExpectedRequest: ws_api.Delete{Path: "/app/conf"},
+			},
+			{
+				// the target directory is kept with objects, that weren't synchronized
+				Method:          http.MethodPost,
+				Resource:        "/api/2.0/workspace/delete",
+				ExpectedRequest: ws_api.Delete{Path: "/app"},
+				Status:          400,
+				Response: map[string]string{
+					"error_code": "DIRECTORY_NOT_EMPTY",
+					"message":    "Folder (/app) is not empty",
+				},
+			},
+		},
+		Resource: ResourceWorkspaceTree(),
+		Delete:   true,
+		ID:       "/app",
+		InstanceState: map[string]string{
+			"source":                 dir,
+			"path":                   "/app",
+			"parallelism":            "1",
+			"files.%":                "2",
+			"files.etl.py":           md5Hex("print(1)"),
+			"files.conf/config.json": md5Hex("{}"),
+		},
+		HCL: fmt.Sprintf(`
+		source = "%s"
+		path = "/app"
+		parallelism = 1
+		`, dir),
+	}.ApplyNoError(t)
+}
+
+func TestEmptiedDirectories(t *testing.T) {
+	entries := map[string]treeEntry{
+		"conf/c.json": newTreeEntry("/app", "conf/c.json", []byte("{}")),
+	}
+	assert.Equal(t, []string{"/app/conf/old/deep", "/app/conf/old", "/app/lib"}, emptiedDirectories("/app", []string{
+		"/app/conf/old/deep/a.json",
+		"/app/conf/b.json",
+		"/app/conf/old/x.json",
+		"/app/lib/util",
+		"/app/etl",
+	}, entries))
+}